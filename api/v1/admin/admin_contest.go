@@ -0,0 +1,184 @@
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"online_judge/consts/resp_code"
+	"online_judge/models/admin/request"
+	"online_judge/models/common/response"
+	contestReq "online_judge/models/contest/request"
+)
+
+type ApiAdminContest struct{}
+
+// CreateContest 创建比赛
+// @Tags Admin API
+// @Summary 创建比赛
+// @Description 创建比赛接口，rule 为 icpc 或 oi，题目的 label 和 score 可以省略，freeze_minutes 为比赛结束前封榜的分钟数
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "token"
+// @Param contest body string true "比赛信息"
+// @Success 200 {object} common.CreateProblemResponse "1000 创建成功"
+// @Failure 200 {object} common.CreateProblemResponse "1001 参数错误"
+// @Failure 200 {object} common.CreateProblemResponse "1021 题目ID不存在"
+// @Failure 200 {object} common.CreateProblemResponse "1014 服务器内部错误"
+// @Router /admin/contest/create [POST]
+func (a *ApiAdminContest) CreateContest(c *gin.Context) {
+	var req request.AdminContestReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		zap.L().Error("controller-CreateContest-ShouldBindJSON ", zap.Error(err))
+		response.ResponseError(c, response.CodeInvalidParam)
+		return
+	}
+
+	ret := AdminService.CreateContest(req)
+	switch ret.Code {
+	case resp_code.Success:
+		response.ResponseSuccess(c, ret.Data)
+
+	default:
+		responseContestError(c, ret.Code)
+	}
+}
+
+// UpdateContest 更新比赛
+// @Tags Admin API
+// @Summary 更新比赛
+// @Description 更新比赛接口，题目列表整体替换
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "token"
+// @Param contest body string true "比赛信息"
+// @Success 200 {object} common.CreateProblemResponse "1000 更新成功"
+// @Failure 200 {object} common.CreateProblemResponse "1001 参数错误"
+// @Failure 200 {object} common.CreateProblemResponse "1042 比赛不存在"
+// @Failure 200 {object} common.CreateProblemResponse "1014 服务器内部错误"
+// @Router /admin/contest/update [PUT]
+func (a *ApiAdminContest) UpdateContest(c *gin.Context) {
+	var req request.AdminContestReq
+	if err := c.ShouldBindJSON(&req); err != nil || req.ContestID == "" {
+		zap.L().Error("controller-UpdateContest-ShouldBindJSON ", zap.Error(err))
+		response.ResponseError(c, response.CodeInvalidParam)
+		return
+	}
+
+	ret := AdminService.UpdateContest(req)
+	switch ret.Code {
+	case resp_code.Success:
+		response.ResponseSuccess(c, response.CodeSuccess)
+
+	default:
+		responseContestError(c, ret.Code)
+	}
+}
+
+// DeleteContest 删除比赛
+// @Tags Admin API
+// @Summary 删除比赛
+// @Description 删除比赛接口
+// @Produce json
+// @Param Authorization header string true "token"
+// @Param contest_id query string true "比赛ID"
+// @Success 200 {object} common.CreateProblemResponse "1000 删除成功"
+// @Failure 200 {object} common.CreateProblemResponse "1001 参数错误"
+// @Failure 200 {object} common.CreateProblemResponse "1042 比赛不存在"
+// @Failure 200 {object} common.CreateProblemResponse "1014 服务器内部错误"
+// @Router /admin/contest/delete [DELETE]
+func (a *ApiAdminContest) DeleteContest(c *gin.Context) {
+	contestID := c.Query("contest_id")
+	if contestID == "" {
+		zap.L().Error("contestID is null")
+		response.ResponseError(c, response.CodeInvalidParam)
+		return
+	}
+
+	ret := AdminService.DeleteContest(contestID)
+	switch ret.Code {
+	case resp_code.Success:
+		response.ResponseSuccess(c, response.CodeSuccess)
+
+	default:
+		responseContestError(c, ret.Code)
+	}
+}
+
+// GetContestResolver 导出揭晓顺序
+// @Tags Admin API
+// @Summary 导出揭晓顺序
+// @Description 比赛结束后导出封榜结果的揭晓顺序，从排名最后的用户开始逐题揭晓，不改变封榜状态
+// @Produce json
+// @Param Authorization header string true "token"
+// @Param contest_id query string true "比赛ID"
+// @Success 200 {object} common.ContestResponse "1000 获取成功"
+// @Failure 200 {object} common.ContestResponse "1042 比赛不存在"
+// @Failure 200 {object} common.ContestResponse "1048 比赛尚未结束"
+// @Failure 200 {object} common.ContestResponse "1014 服务器内部错误"
+// @Router /admin/contest/resolver [GET]
+func (a *ApiAdminContest) GetContestResolver(c *gin.Context) {
+	var req contestReq.ContestReq
+	req.ContestID = c.Query("contest_id")
+	if req.ContestID == "" {
+		response.ResponseError(c, response.CodeInvalidParam)
+		return
+	}
+
+	ret := ContestService.GetResolver(req)
+	switch ret.Code {
+	case resp_code.Success:
+		response.ResponseSuccess(c, ret.Data)
+
+	default:
+		responseContestError(c, ret.Code)
+	}
+}
+
+// UnfreezeContest 揭晓封榜结果
+// @Tags Admin API
+// @Summary 揭晓封榜结果
+// @Description 比赛结束后揭晓封榜结果，之后所有用户都能看到最终排行榜，返回揭晓顺序
+// @Accept multipart/form-data
+// @Produce json
+// @Param Authorization header string true "token"
+// @Param contest_id formData string true "比赛ID"
+// @Success 200 {object} common.ContestResponse "1000 揭晓成功"
+// @Failure 200 {object} common.ContestResponse "1042 比赛不存在"
+// @Failure 200 {object} common.ContestResponse "1048 比赛尚未结束"
+// @Failure 200 {object} common.ContestResponse "1014 服务器内部错误"
+// @Router /admin/contest/unfreeze [POST]
+func (a *ApiAdminContest) UnfreezeContest(c *gin.Context) {
+	var req contestReq.ContestReq
+	req.ContestID = c.PostForm("contest_id")
+	if req.ContestID == "" {
+		response.ResponseError(c, response.CodeInvalidParam)
+		return
+	}
+
+	ret := ContestService.Unfreeze(req)
+	switch ret.Code {
+	case resp_code.Success:
+		response.ResponseSuccess(c, ret.Data)
+
+	default:
+		responseContestError(c, ret.Code)
+	}
+}
+
+func responseContestError(c *gin.Context, code int) {
+	switch code {
+	case resp_code.InvalidContest:
+		response.ResponseError(c, response.CodeInvalidContest)
+
+	case resp_code.ContestNotExist:
+		response.ResponseError(c, response.CodeContestNotExist)
+
+	case resp_code.ContestNotEnded:
+		response.ResponseError(c, response.CodeContestNotEnded)
+
+	case resp_code.ProblemNotExist:
+		response.ResponseError(c, response.CodeProblemIDNotExist)
+
+	default:
+		response.ResponseError(c, response.CodeInternalServerError)
+	}
+}
@@ -3,17 +3,26 @@ package admin
 import (
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
+	"io"
 	"mime/multipart"
+	"net/http"
 	"online_judge/consts"
 	"online_judge/consts/resp_code"
 	"online_judge/models/admin/request"
 	"online_judge/models/common/response"
+	submissionRequest "online_judge/models/submission/request"
+	"online_judge/pkg/language"
+	"online_judge/pkg/problempkg"
+	"online_judge/pkg/testcase"
 	"online_judge/pkg/utils"
 	"os"
 	"path/filepath"
 	"strconv"
 )
 
+// maxTestCaseArchiveSize 上传测试数据压缩包的最大字节数
+const maxTestCaseArchiveSize = 64 << 20
+
 type ApiAdminProblem struct{}
 
 // CreateProblem 创建新题目接口
@@ -29,6 +38,7 @@ type ApiAdminProblem struct{}
 // @Failure 200 {object} common.CreateProblemResponse "1001 参数错误"
 // @Failure 200 {object} common.CreateProblemResponse "1018 测试用例格式错误"
 // @Failure 200 {object} common.CreateProblemResponse "1019 题目标题已存在"
+// @Failure 200 {object} common.CreateProblemResponse "检查器参数错误"
 // @Failure 200 {object} common.CreateProblemResponse "1008 需要登录"
 // @Failure 200 {object} common.CreateProblemResponse "1014 服务器内部错误"
 // @Router /admin/problem/create [POST]
@@ -57,6 +67,12 @@ func (a *ApiAdminProblem) CreateProblem(c *gin.Context) {
 		return
 	}
 
+	if !a.checkChecker(req.ProblemChecker, false) || !a.checkInteractor(req.ProblemInteractor) {
+		zap.L().Error("controller-CreateProblem-checkChecker invalid checker")
+		response.ResponseError(c, response.CodeInvalidChecker)
+		return
+	}
+
 	req.ProblemID = utils.GetUUID()
 
 	for _, v := range req.TestCases {
@@ -92,6 +108,7 @@ func (a *ApiAdminProblem) CreateProblem(c *gin.Context) {
 // @Failure 200 {object} common.UpdateProblemResponse "题目ID不存在"
 // @Failure 200 {object} common.UpdateProblemResponse "题目标题已存在"
 // @Failure 200 {object} common.UpdateProblemResponse "测试用例格式错误"
+// @Failure 200 {object} common.UpdateProblemResponse "检查器参数错误"
 // @Failure 200 {object} common.UpdateProblemResponse "需要登录"
 // @Failure 200 {object} common.UpdateProblemResponse "服务器内部错误"
 // @Router /admin/problem/update [PUT]
@@ -112,6 +129,12 @@ func (a *ApiAdminProblem) UpdateProblem(c *gin.Context) {
 		return
 	}
 
+	if !a.checkChecker(req.ProblemChecker, true) || !a.checkInteractor(req.ProblemInteractor) {
+		zap.L().Error("controller-UpdateProblem-checkChecker invalid checker")
+		response.ResponseError(c, response.CodeInvalidChecker)
+		return
+	}
+
 	for _, v := range req.TestCases {
 		v.TID = utils.GetUUID()
 		v.PID = req.ProblemID
@@ -168,6 +191,268 @@ func (a *ApiAdminProblem) DeleteProblem(c *gin.Context) {
 	return
 }
 
+// GetProblemTestCases 获取题目全部测试数据接口
+// @Tags Admin API
+// @Summary 获取题目全部测试数据
+// @Description 获取题目的全部测试数据，包括隐藏的测试数据，download 为 true 时以 JSON 文件的形式下载
+// @Produce json
+// @Param Authorization header string true "token"
+// @Param problem_id path string true "题目ID"
+// @Param download query bool false "是否下载"
+// @Success 200 {object} common.GetProblemTestCasesResponse "1000 获取成功"
+// @Failure 200 {object} common.GetProblemTestCasesResponse "1021 题目ID不存在"
+// @Failure 200 {object} common.GetProblemTestCasesResponse "1008 需要登录"
+// @Failure 200 {object} common.GetProblemTestCasesResponse "1014 服务器内部错误"
+// @Router /admin/problem/test-cases/{problem_id} [GET]
+func (a *ApiAdminProblem) GetProblemTestCases(c *gin.Context) {
+	pid := c.Param("problem_id")
+	resp := AdminService.GetProblemTestCases(pid)
+
+	switch resp.Code {
+	case resp_code.Success:
+		if download, _ := strconv.ParseBool(c.Query("download")); download {
+			c.Header("Content-Disposition", "attachment; filename="+pid+"_test_cases.json")
+			c.JSON(http.StatusOK, resp.Data)
+			return
+		}
+		response.ResponseSuccess(c, resp.Data)
+
+	case resp_code.ProblemNotExist:
+		response.ResponseError(c, response.CodeProblemIDNotExist)
+
+	default:
+		response.ResponseError(c, response.CodeInternalServerError)
+	}
+}
+
+// ImportTestCases 从压缩包导入测试数据接口
+// @Tags Admin API
+// @Summary 从压缩包导入测试数据
+// @Description 上传 zip 压缩包替换题目的测试数据，支持 1.in/1.out、input1.in/expected1.out 和 manifest.json 三种格式
+// @Accept multipart/form-data
+// @Produce json
+// @Param Authorization header string true "token"
+// @Param problem_id path string true "题目ID"
+// @Param file formData file true "测试数据压缩包"
+// @Success 200 {object} common.ImportTestCasesResponse "1000 导入成功，data 为配对结果"
+// @Failure 200 {object} common.ImportTestCasesResponse "1001 参数错误"
+// @Failure 200 {object} common.ImportTestCasesResponse "1021 题目ID不存在"
+// @Failure 200 {object} common.ImportTestCasesResponse "1049 测试数据压缩包格式错误，data 为配对结果"
+// @Failure 200 {object} common.ImportTestCasesResponse "1014 服务器内部错误"
+// @Router /admin/problem/{problem_id}/testcases/import [POST]
+func (a *ApiAdminProblem) ImportTestCases(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxTestCaseArchiveSize)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		zap.L().Error("controller-ImportTestCases-FormFile ", zap.Error(err))
+		response.ResponseError(c, response.CodeInvalidParam)
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		zap.L().Error("controller-ImportTestCases-Open ", zap.Error(err))
+		response.ResponseError(c, response.CodeInternalServerError)
+		return
+	}
+	defer file.Close()
+
+	resp := CacheService.ImportTestCases(request.AdminImportTestCasesReq{
+		ProblemID: c.Param("problem_id"),
+		Archive:   file,
+		Size:      fileHeader.Size,
+	})
+	switch resp.Code {
+	case resp_code.Success:
+		response.ResponseSuccess(c, resp.Data)
+
+	case resp_code.InvalidTestCaseArchive:
+		response.ResponseErrorWithData(c, response.CodeInvalidTestCaseArchive, resp.Data)
+
+	case resp_code.ProblemNotExist:
+		response.ResponseError(c, response.CodeProblemIDNotExist)
+
+	default:
+		response.ResponseError(c, response.CodeInternalServerError)
+	}
+}
+
+// ExportTestCases 导出测试数据接口
+// @Tags Admin API
+// @Summary 导出测试数据
+// @Description 把题目的全部测试数据导出为 1.in/1.out 格式的 zip 压缩包，附带记录样例的 manifest.json
+// @Produce application/zip
+// @Param Authorization header string true "token"
+// @Param problem_id path string true "题目ID"
+// @Failure 200 {object} common.ImportTestCasesResponse "1021 题目ID不存在"
+// @Failure 200 {object} common.ImportTestCasesResponse "1014 服务器内部错误"
+// @Router /admin/problem/{problem_id}/testcases/export [GET]
+func (a *ApiAdminProblem) ExportTestCases(c *gin.Context) {
+	pid := c.Param("problem_id")
+	resp := AdminService.ExportTestCases(pid)
+
+	switch resp.Code {
+	case resp_code.Success:
+		c.Header("Content-Type", "application/zip")
+		c.Header("Content-Disposition", "attachment; filename="+pid+"_test_cases.zip")
+		if err := testcase.WriteZip(c.Writer, resp.Data.([]testcase.Case)); err != nil {
+			zap.L().Error("controller-ExportTestCases-WriteZip ", zap.Error(err))
+		}
+
+	case resp_code.ProblemNotExist:
+		response.ResponseError(c, response.CodeProblemIDNotExist)
+
+	default:
+		response.ResponseError(c, response.CodeInternalServerError)
+	}
+}
+
+// Rejudge 重新评测接口
+// @Tags Admin API
+// @Summary 重新评测题目的提交
+// @Description 使用题目的最新版本重新评测题目的全部提交或指定的提交，评测记录原地更新，并重新计算受影响用户的通过数量
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "token"
+// @Param problem_id path string true "题目ID"
+// @Param req body submissionRequest.RejudgeReq false "其他过滤条件，和 /admin/submission/rejudge 相同，为空表示题目的全部提交"
+// @Success 200 {object} common.RejudgeResponse "1000 已重新加入评测队列，data 为使用的题目版本和重新评测的数量"
+// @Failure 200 {object} common.RejudgeResponse "1001 参数错误"
+// @Failure 200 {object} common.RejudgeResponse "1021 题目ID不存在"
+// @Failure 200 {object} common.RejudgeResponse "1014 服务器内部错误"
+// @Router /admin/problem/{problem_id}/rejudge [POST]
+func (a *ApiAdminProblem) Rejudge(c *gin.Context) {
+	var req submissionRequest.RejudgeReq
+	// 请求体可以为空
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			zap.L().Error("controller-Rejudge-ShouldBindJSON ", zap.Error(err))
+			response.ResponseError(c, response.CodeInvalidParam)
+			return
+		}
+	}
+	req.ProblemID = c.Param("problem_id")
+	if !checkRejudgeReq(req) {
+		response.ResponseError(c, response.CodeInvalidParam)
+		return
+	}
+	responseRejudge(c, SubmissionService.Rejudge(req))
+}
+
+// GetSimilarityReport 代码相似度报告接口
+// @Tags Admin API
+// @Summary 代码相似度报告
+// @Description 比较题目中每个用户最近一次通过的代码，列出相似度不低于阈值的代码对和相同的代码片段，重命名变量、修改注释和格式不影响结果
+// @Produce json
+// @Param Authorization header string true "token"
+// @Param problem_id path string true "题目ID"
+// @Param threshold query number false "相似度阈值，范围 (0, 1]，默认 0.6"
+// @Param limit query int false "最多比较的通过代码数量，默认和最大值为 500"
+// @Success 200 {object} common.SimilarityReportResponse "1000 获取成功"
+// @Failure 200 {object} common.SimilarityReportResponse "1001 参数错误"
+// @Failure 200 {object} common.SimilarityReportResponse "1021 题目ID不存在"
+// @Failure 200 {object} common.SimilarityReportResponse "1014 服务器内部错误"
+// @Router /admin/problem/{problem_id}/similarity [GET]
+func (a *ApiAdminProblem) GetSimilarityReport(c *gin.Context) {
+	var req request.AdminSimilarityReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		zap.L().Error("controller-GetSimilarityReport-ShouldBindQuery ", zap.Error(err))
+		response.ResponseError(c, response.CodeInvalidParam)
+		return
+	}
+	req.ProblemID = c.Param("problem_id")
+
+	resp := AdminService.GetSimilarityReport(req)
+	switch resp.Code {
+	case resp_code.Success:
+		response.ResponseSuccess(c, resp.Data)
+
+	case resp_code.ProblemNotExist:
+		response.ResponseError(c, response.CodeProblemIDNotExist)
+
+	default:
+		response.ResponseError(c, response.CodeInternalServerError)
+	}
+}
+
+// ImportProblemPackage 导入题目包接口
+// @Tags Admin API
+// @Summary 导入题目包
+// @Description 上传题目包创建新题目，题目包是包含 problem.json、statement.md 和 tests/ 目录的 zip 压缩包，也支持 Hydro 导出的 problem.yaml 和 testdata/ 格式
+// @Accept multipart/form-data
+// @Produce json
+// @Param Authorization header string true "token"
+// @Param file formData file true "题目包"
+// @Success 200 {object} common.ImportProblemPackageResponse "1000 导入成功，data 为新题目的ID"
+// @Failure 200 {object} common.ImportProblemPackageResponse "1001 参数错误"
+// @Failure 200 {object} common.ImportProblemPackageResponse "1019 题目标题已存在"
+// @Failure 200 {object} common.ImportProblemPackageResponse "1050 题目包格式错误，data 为错误原因"
+// @Failure 200 {object} common.ImportProblemPackageResponse "1014 服务器内部错误"
+// @Router /admin/problem/package/import [POST]
+func (a *ApiAdminProblem) ImportProblemPackage(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxTestCaseArchiveSize)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		zap.L().Error("controller-ImportProblemPackage-FormFile ", zap.Error(err))
+		response.ResponseError(c, response.CodeInvalidParam)
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		zap.L().Error("controller-ImportProblemPackage-Open ", zap.Error(err))
+		response.ResponseError(c, response.CodeInternalServerError)
+		return
+	}
+	defer file.Close()
+
+	resp := CacheService.ImportProblemPackage(request.AdminImportProblemPackageReq{
+		Archive: file,
+		Size:    fileHeader.Size,
+	})
+	switch resp.Code {
+	case resp_code.Success:
+		response.ResponseSuccess(c, resp.Data)
+
+	case resp_code.InvalidProblemPackage:
+		response.ResponseErrorWithData(c, response.CodeInvalidProblemPackage, resp.Data)
+
+	case resp_code.ProblemAlreadyExist:
+		response.ResponseError(c, response.CodeProblemTitleExist)
+
+	default:
+		response.ResponseError(c, response.CodeInternalServerError)
+	}
+}
+
+// ExportProblemPackage 导出题目包接口
+// @Tags Admin API
+// @Summary 导出题目包
+// @Description 把题目的描述、限制、分类、检查器、交互器和全部测试数据导出为题目包
+// @Produce application/zip
+// @Param Authorization header string true "token"
+// @Param problem_id path string true "题目ID"
+// @Failure 200 {object} common.ImportProblemPackageResponse "1021 题目ID不存在"
+// @Failure 200 {object} common.ImportProblemPackageResponse "1014 服务器内部错误"
+// @Router /admin/problem/package/export/{problem_id} [GET]
+func (a *ApiAdminProblem) ExportProblemPackage(c *gin.Context) {
+	pid := c.Param("problem_id")
+	resp := AdminService.ExportProblemPackage(pid)
+
+	switch resp.Code {
+	case resp_code.Success:
+		c.Header("Content-Type", "application/zip")
+		c.Header("Content-Disposition", "attachment; filename="+pid+"_package.zip")
+		if err := problempkg.Write(c.Writer, resp.Data.(*problempkg.Package)); err != nil {
+			zap.L().Error("controller-ExportProblemPackage-Write ", zap.Error(err))
+		}
+
+	case resp_code.ProblemNotExist:
+		response.ResponseError(c, response.CodeProblemIDNotExist)
+
+	default:
+		response.ResponseError(c, response.CodeInternalServerError)
+	}
+}
+
 // CreateProblemWithFile 创建新题目接口，输入输出是文件的形式
 // @Tags Admin API
 // @Summary 创建新题目，输入输出是文件的形式
@@ -182,10 +467,18 @@ func (a *ApiAdminProblem) DeleteProblem(c *gin.Context) {
 // @Param max_memory formData int true "内存限制"
 // @Param input formData []file true "问题的输入文件(.in)" collectionFormat(multi)
 // @Param expected formData []file true "问题的输出文件(.out)" collectionFormat(multi)
+// @Param checker_mode formData string false "检查方式 exact token float custom"
+// @Param checker_epsilon formData number false "float 模式的误差，绝对误差或相对误差不超过该值即可"
+// @Param checker_language formData string false "custom 模式的检查器语言"
+// @Param checker formData file false "custom 模式的检查器源代码"
+// @Param interactor_language formData string false "交互题的交互器语言"
+// @Param interactor formData file false "交互题的交互器源代码"
+// @Param stop_on_pretest_failure formData bool false "预测试模式下预测试未通过时不再评测其余测试数据"
 // @Success 200 {object} common.CreateProblemResponse "1000 创建成功"
 // @Failure 200 {object} common.CreateProblemResponse "1001 参数错误"
 // @Failure 200 {object} common.CreateProblemResponse "1018 测试用例格式错误"
 // @Failure 200 {object} common.CreateProblemResponse "1019 题目标题已存在"
+// @Failure 200 {object} common.CreateProblemResponse "检查器参数错误"
 // @Failure 200 {object} common.CreateProblemResponse "1008 需要登录"
 // @Failure 200 {object} common.CreateProblemResponse "1014 服务器内部错误"
 // @Router /admin/problem/file/create [POST]
@@ -204,6 +497,17 @@ func (a *ApiAdminProblem) CreateProblemWithFile(c *gin.Context) {
 	req.Difficulty = c.PostForm("difficulty")
 	req.MaxRuntime, _ = strconv.Atoi(c.PostForm("max_runtime"))
 	req.MaxMemory, _ = strconv.Atoi(c.PostForm("max_memory"))
+	req.StopOnPretestFailure, _ = strconv.ParseBool(c.PostForm("stop_on_pretest_failure"))
+
+	req.ProblemChecker, err = a.bindCheckerForm(c)
+	if err == nil {
+		req.ProblemInteractor, err = a.bindInteractorForm(c)
+	}
+	if err != nil || !a.checkChecker(req.ProblemChecker, false) || !a.checkInteractor(req.ProblemInteractor) {
+		zap.L().Error("controller-CreateProblemWithFile-bindCheckerForm invalid checker", zap.Error(err))
+		response.ResponseError(c, response.CodeInvalidChecker)
+		return
+	}
 
 	inputFile := c.Request.MultipartForm.File["input"]
 	outputFile := c.Request.MultipartForm.File["expected"]
@@ -287,10 +591,18 @@ func (a *ApiAdminProblem) DeleteProblemWithFile(c *gin.Context) {
 // @Param max_memory formData string false "内存限制"
 // @Param input formData []file true "问题的输入文件(.in)" collectionFormat(multi)
 // @Param expected formData []file true "问题的输出文件(.out)" collectionFormat(multi)
+// @Param checker_mode formData string false "检查方式 exact token float custom，为空表示不修改"
+// @Param checker_epsilon formData number false "float 模式的误差，绝对误差或相对误差不超过该值即可"
+// @Param checker_language formData string false "custom 模式的检查器语言"
+// @Param checker formData file false "custom 模式的检查器源代码"
+// @Param interactor_language formData string false "交互题的交互器语言"
+// @Param interactor formData file false "交互题的交互器源代码"
+// @Param stop_on_pretest_failure formData bool false "预测试模式下预测试未通过时不再评测其余测试数据，为空表示不修改"
 // @Success 200 {object} common.UpdateProblemResponse "修改成功"
 // @Failure 200 {object} common.UpdateProblemResponse "题目ID不存在"
 // @Failure 200 {object} common.UpdateProblemResponse "题目标题已存在"
 // @Failure 200 {object} common.UpdateProblemResponse "测试用例格式错误"
+// @Failure 200 {object} common.UpdateProblemResponse "检查器参数错误"
 // @Failure 200 {object} common.UpdateProblemResponse "需要登录"
 // @Failure 200 {object} common.UpdateProblemResponse "服务器内部错误"
 // @Router /admin/problem/file/update [PUT]
@@ -304,6 +616,26 @@ func (a *ApiAdminProblem) UpdateProblemWithFile(c *gin.Context) {
 	req.MaxRuntime, _ = strconv.Atoi(c.PostForm("max_runtime"))
 	req.MaxMemory, _ = strconv.Atoi(c.PostForm("max_memory"))
 
+	var err error
+	if v := c.PostForm("stop_on_pretest_failure"); v != "" {
+		stop, err := strconv.ParseBool(v)
+		if err != nil {
+			zap.L().Error("controller-UpdateProblemWithFile-ParseBool ", zap.Error(err))
+			response.ResponseError(c, response.CodeInvalidParam)
+			return
+		}
+		req.StopOnPretestFailure = &stop
+	}
+	req.ProblemChecker, err = a.bindCheckerForm(c)
+	if err == nil {
+		req.ProblemInteractor, err = a.bindInteractorForm(c)
+	}
+	if err != nil || !a.checkChecker(req.ProblemChecker, true) || !a.checkInteractor(req.ProblemInteractor) {
+		zap.L().Error("controller-UpdateProblemWithFile-bindCheckerForm invalid checker", zap.Error(err))
+		response.ResponseError(c, response.CodeInvalidChecker)
+		return
+	}
+
 	inputFile := c.Request.MultipartForm.File["input"]
 	outputFile := c.Request.MultipartForm.File["expected"]
 
@@ -362,3 +694,90 @@ func (a *ApiAdminProblem) SaveFile(c *gin.Context, fileHeader []*multipart.FileH
 		}
 	}
 }
+
+// checkChecker 检查题目的检查方式是否合法，allowEmpty 为 true 时允许不指定检查方式
+func (a *ApiAdminProblem) checkChecker(checker request.ProblemChecker, allowEmpty bool) bool {
+	if checker.CheckerEpsilon < 0 {
+		return false
+	}
+	switch checker.CheckerMode {
+	case "":
+		return allowEmpty || checker.CheckerCode == ""
+	case consts.CheckerExact, consts.CheckerToken, consts.CheckerFloat:
+		return true
+	case consts.CheckerCustom:
+		// 自定义检查器需要源代码和评测机支持的语言
+		if checker.CheckerCode == "" {
+			return false
+		}
+		_, ok := language.Get(checker.CheckerLanguage)
+		return ok
+	default:
+		return false
+	}
+}
+
+// bindCheckerForm 从表单中读取检查方式，检查器源代码可以通过 checker 文件上传
+func (a *ApiAdminProblem) bindCheckerForm(c *gin.Context) (checker request.ProblemChecker, err error) {
+	checker.CheckerMode = c.PostForm("checker_mode")
+	checker.CheckerLanguage = c.PostForm("checker_language")
+	checker.CheckerCode = c.PostForm("checker_code")
+	if eps := c.PostForm("checker_epsilon"); eps != "" {
+		if checker.CheckerEpsilon, err = strconv.ParseFloat(eps, 64); err != nil {
+			return
+		}
+	}
+
+	code, err := a.readFormFile(c, "checker")
+	if err != nil {
+		return
+	}
+	if code != "" {
+		checker.CheckerCode = code
+	}
+	return
+}
+
+// checkInteractor 检查交互器是否合法，上传交互器时必须指定评测机支持的语言
+func (a *ApiAdminProblem) checkInteractor(interactor request.ProblemInteractor) bool {
+	if interactor.InteractorCode == "" {
+		return true
+	}
+	_, ok := language.Get(interactor.InteractorLanguage)
+	return ok
+}
+
+// bindInteractorForm 从表单中读取交互器，交互器源代码可以通过 interactor 文件上传
+func (a *ApiAdminProblem) bindInteractorForm(c *gin.Context) (interactor request.ProblemInteractor, err error) {
+	interactor.InteractorLanguage = c.PostForm("interactor_language")
+	interactor.InteractorCode = c.PostForm("interactor_code")
+	code, err := a.readFormFile(c, "interactor")
+	if err != nil {
+		return
+	}
+	if code != "" {
+		interactor.InteractorCode = code
+	}
+	return
+}
+
+// readFormFile 读取上传的源代码文件，没有上传时返回空字符串
+func (a *ApiAdminProblem) readFormFile(c *gin.Context, name string) (string, error) {
+	fileHeader, err := c.FormFile(name)
+	if err == http.ErrMissingFile {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	code, err := io.ReadAll(file)
+	if err != nil {
+		return "", err
+	}
+	return string(code), nil
+}
@@ -0,0 +1,103 @@
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"online_judge/consts/resp_code"
+	"online_judge/models/common/response"
+	"online_judge/models/submission/request"
+)
+
+type ApiAdminSubmission struct{}
+
+// RejudgeSubmissions 按条件重新评测接口
+// @Tags Admin API
+// @Summary 按条件重新评测提交
+// @Description 重新评测满足全部条件的提交，可以指定提交ID、题目、用户、当前的评测结果和提交时间范围，至少需要一个条件
+// @Description 例如重新评测一段时间内 system error 的提交：{"verdict": "system error", "from": "2024-05-01T10:00:00+08:00", "to": "2024-05-01T12:00:00+08:00"}
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "token"
+// @Param req body request.RejudgeReq true "重新评测的条件"
+// @Success 200 {object} common.RejudgeResponse "1000 已重新加入评测队列，data 为使用的题目版本和重新评测的数量"
+// @Failure 200 {object} common.RejudgeResponse "1001 参数错误"
+// @Failure 200 {object} common.RejudgeResponse "1021 题目ID不存在"
+// @Failure 200 {object} common.RejudgeResponse "1014 服务器内部错误"
+// @Router /admin/submission/rejudge [POST]
+func (a *ApiAdminSubmission) RejudgeSubmissions(c *gin.Context) {
+	var req request.RejudgeReq
+	if err := c.ShouldBindJSON(&req); err != nil || !checkRejudgeReq(req) {
+		zap.L().Error("controller-RejudgeSubmissions-ShouldBindJSON invalid request", zap.Error(err))
+		response.ResponseError(c, response.CodeInvalidParam)
+		return
+	}
+	responseRejudge(c, SubmissionService.Rejudge(req))
+}
+
+// OverrideVerdict 手动修改评测结果接口
+// @Tags Admin API
+// @Summary 手动修改评测结果
+// @Description 修改提交的评测结果并记录修改原因，同时更新题目统计和用户的通过数量，等待评测的提交不能修改
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "token"
+// @Param id path string true "提交ID"
+// @Param req body request.VerdictOverrideReq true "修改后的评测结果和原因"
+// @Success 200 {object} common.OverrideVerdictResponse "1000 修改成功"
+// @Failure 200 {object} common.OverrideVerdictResponse "1001 参数错误"
+// @Failure 200 {object} common.OverrideVerdictResponse "1040 提交记录不存在"
+// @Failure 200 {object} common.OverrideVerdictResponse "1051 评测尚未完成"
+// @Failure 200 {object} common.OverrideVerdictResponse "1014 服务器内部错误"
+// @Router /admin/submission/{id}/verdict [PUT]
+func (a *ApiAdminSubmission) OverrideVerdict(c *gin.Context) {
+	var req request.VerdictOverrideReq
+	if err := c.ShouldBindJSON(&req); err != nil ||
+		!resp_code.ValidVerdict(req.Verdict) || req.Verdict == resp_code.VerdictPending {
+		zap.L().Error("controller-OverrideVerdict-ShouldBindJSON invalid request", zap.Error(err))
+		response.ResponseError(c, response.CodeInvalidParam)
+		return
+	}
+	req.SubmissionID = c.Param("id")
+	req.AdminID = c.GetInt64(response.CtxUserIDKey)
+
+	resp := SubmissionService.OverrideVerdict(req)
+	switch resp.Code {
+	case resp_code.Success:
+		response.ResponseSuccess(c, nil)
+
+	case resp_code.SubmissionNotExist:
+		response.ResponseError(c, response.CodeSubmissionNotExist)
+
+	case resp_code.JudgementPending:
+		response.ResponseError(c, response.CodeJudgementPending)
+
+	default:
+		response.ResponseError(c, response.CodeInternalServerError)
+	}
+}
+
+// checkRejudgeReq 至少需要一个条件，避免误操作重新评测全部提交
+func checkRejudgeReq(req request.RejudgeReq) bool {
+	if req.ProblemID == "" && len(req.SubmissionIDs) == 0 && req.UserID == 0 &&
+		req.Verdict == "" && req.From.IsZero() && req.To.IsZero() {
+		return false
+	}
+	if req.Verdict != "" && (!resp_code.ValidVerdict(req.Verdict) || req.Verdict == resp_code.VerdictPending) {
+		return false
+	}
+	return req.From.IsZero() || req.To.IsZero() || req.From.Before(req.To)
+}
+
+// responseRejudge 把重新评测的结果转换为响应
+func responseRejudge(c *gin.Context, resp response.ResponseWithData) {
+	switch resp.Code {
+	case resp_code.Success:
+		response.ResponseSuccess(c, resp.Data)
+
+	case resp_code.ProblemNotExist:
+		response.ResponseError(c, response.CodeProblemIDNotExist)
+
+	default:
+		response.ResponseError(c, response.CodeInternalServerError)
+	}
+}
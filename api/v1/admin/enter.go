@@ -9,9 +9,13 @@ type ApiGroup struct {
 	ApiAdminUser
 	ApiAdminProblem
 	ApiAdminCategory
+	ApiAdminContest
+	ApiAdminSubmission
 }
 
 var (
-	AdminService = services.ServiceGroupApp.AdminService
-	CacheService = cache.CacheGroupApp.CacheAdmin
+	AdminService      = services.ServiceGroupApp.AdminService
+	ContestService    = services.ServiceGroupApp.ContestService
+	SubmissionService = services.ServiceGroupApp.SubmissionService
+	CacheService      = cache.CacheGroupApp.CacheAdmin
 )
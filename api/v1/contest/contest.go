@@ -0,0 +1,120 @@
+package contest
+
+import (
+	"github.com/gin-gonic/gin"
+	"online_judge/api/v1/evaluation"
+	"online_judge/consts/resp_code"
+	"online_judge/models/common/response"
+	"online_judge/models/contest/request"
+	"online_judge/pkg/define"
+	"strconv"
+)
+
+type ApiContest struct{}
+
+// GetContestList 获取比赛列表接口
+// @Tags Contest API
+// @Summary 获取比赛列表
+// @Description 按开始时间倒序分页获取比赛列表
+// @Produce json
+// @Param page query int false "page, default: 1"
+// @Param size query int false "pageSize, default: 10"
+// @Success 200 {object} common.ContestResponse "1000 获取成功"
+// @Failure 200 {object} common.ContestResponse "1014 服务器内部错误"
+// @Router /contest/list [GET]
+func (a *ApiContest) GetContestList(c *gin.Context) {
+	var req request.ContestListReq
+	req.Size, _ = strconv.Atoi(c.DefaultQuery("size", define.DefaultSize))
+	req.Page, _ = strconv.Atoi(c.DefaultQuery("page", define.DefaultPage))
+	if req.Page <= 0 || req.Size <= 0 {
+		response.ResponseError(c, response.CodeInvalidParam)
+		return
+	}
+
+	resp := ContestService.GetContestList(req)
+	responseContest(c, resp.Code, resp.Data)
+}
+
+// GetContestDetail 获取比赛详细接口
+// @Tags Contest API
+// @Summary 获取比赛详细
+// @Description 获取比赛详细接口，比赛开始前只有管理员能看到题目
+// @Produce json
+// @Param Authorization header string false "token"
+// @Param contest_id path string true "比赛ID"
+// @Success 200 {object} common.ContestResponse "1000 获取成功"
+// @Failure 200 {object} common.ContestResponse "1042 比赛不存在"
+// @Failure 200 {object} common.ContestResponse "1014 服务器内部错误"
+// @Router /contest/{contest_id} [GET]
+func (a *ApiContest) GetContestDetail(c *gin.Context) {
+	var req request.ContestReq
+	req.ContestID = c.Param("contest_id")
+	req.UserID, req.IsAdmin = evaluation.Viewer(c)
+
+	resp := ContestService.GetContestDetail(req)
+	responseContest(c, resp.Code, resp.Data)
+}
+
+// RegisterContest 报名比赛接口
+// @Tags Contest API
+// @Summary 报名比赛
+// @Description 报名比赛接口，比赛结束前都可以报名，重复报名不会报错
+// @Produce json
+// @Param Authorization header string true "token"
+// @Param contest_id path string true "比赛ID"
+// @Success 200 {object} common.ContestResponse "1000 报名成功"
+// @Failure 200 {object} common.ContestResponse "1008 需要登录"
+// @Failure 200 {object} common.ContestResponse "1042 比赛不存在"
+// @Failure 200 {object} common.ContestResponse "1045 比赛已经结束"
+// @Failure 200 {object} common.ContestResponse "1014 服务器内部错误"
+// @Router /contest/{contest_id}/register [POST]
+func (a *ApiContest) RegisterContest(c *gin.Context) {
+	var req request.ContestReq
+	userId, ok := c.Get(response.CtxUserIDKey)
+	if !ok {
+		response.ResponseError(c, response.CodeNeedLogin)
+		return
+	}
+	req.ContestID = c.Param("contest_id")
+	req.UserID = userId.(int64)
+
+	resp := ContestService.RegisterContest(req)
+	responseContest(c, resp.Code, response.CodeSuccess)
+}
+
+// GetScoreboard 获取比赛排行榜接口
+// @Tags Contest API
+// @Summary 获取比赛排行榜
+// @Description ICPC 赛制按通过题数和罚时排名，OI 赛制按总分排名
+// @Description 封榜期间封榜后的提交显示为 pending，管理员可以看到实时结果
+// @Produce json
+// @Param Authorization header string false "token"
+// @Param contest_id path string true "比赛ID"
+// @Success 200 {object} common.ContestResponse "1000 获取成功"
+// @Failure 200 {object} common.ContestResponse "1042 比赛不存在"
+// @Failure 200 {object} common.ContestResponse "1014 服务器内部错误"
+// @Router /contest/{contest_id}/scoreboard [GET]
+func (a *ApiContest) GetScoreboard(c *gin.Context) {
+	var req request.ContestReq
+	req.ContestID = c.Param("contest_id")
+	req.UserID, req.IsAdmin = evaluation.Viewer(c)
+
+	resp := LeaderboardCache.GetScoreboardWithCache(req)
+	responseContest(c, resp.Code, resp.Data)
+}
+
+func responseContest(c *gin.Context, code int, data interface{}) {
+	switch code {
+	case resp_code.Success:
+		response.ResponseSuccess(c, data)
+
+	case resp_code.ContestNotExist:
+		response.ResponseError(c, response.CodeContestNotExist)
+
+	case resp_code.ContestAlreadyEnded:
+		response.ResponseError(c, response.CodeContestAlreadyEnded)
+
+	default:
+		response.ResponseError(c, response.CodeInternalServerError)
+	}
+}
@@ -0,0 +1,15 @@
+package contest
+
+import (
+	"online_judge/dao/redis/cache"
+	"online_judge/services"
+)
+
+type ApiGroup struct {
+	ApiContest
+}
+
+var (
+	ContestService   = services.ServiceGroupApp.ContestService
+	LeaderboardCache = cache.CacheGroupApp.CacheLeaderboard
+)
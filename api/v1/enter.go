@@ -4,7 +4,9 @@ import (
 	"online_judge/api/v1/admin"
 	"online_judge/api/v1/auth"
 	"online_judge/api/v1/category"
+	"online_judge/api/v1/contest"
 	"online_judge/api/v1/evaluation"
+	"online_judge/api/v1/language"
 	"online_judge/api/v1/leaderboard"
 	"online_judge/api/v1/problem"
 	"online_judge/api/v1/submission"
@@ -22,6 +24,7 @@ type ApiGroupInterface interface {
 	GetLeaderboardApiGroup() leaderboard.ApiGroup
 	GetEvaluationApiGroup() evaluation.ApiGroup
 	GetCategoryApiGroup() category.ApiGroup
+	GetLanguageApiGroup() language.ApiGroup
 }
 
 type ApiGroup struct {
@@ -34,6 +37,8 @@ type ApiGroup struct {
 	ApiLeaderboard leaderboard.ApiGroup
 	ApiEvaluation  evaluation.ApiGroup
 	ApiCategory    category.ApiGroup
+	ApiLanguage    language.ApiGroup
+	ApiContest     contest.ApiGroup
 }
 
 //func (a *ApiGroup) GetAdminApiGroup() admin.ApiGroup {
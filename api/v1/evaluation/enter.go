@@ -1,5 +1,10 @@
 package evaluation
 
+import (
+	"online_judge/dao/redis/cache"
+	"online_judge/services"
+)
+
 type ApiGroup struct {
 	ApiEvaluation
 }
@@ -7,3 +12,8 @@ type ApiGroup struct {
 func (a *ApiGroup) GetEvaluationApiGroup() ApiEvaluation {
 	return a.ApiEvaluation
 }
+
+var (
+	EvaluationService = services.ServiceGroupApp.EvaluationService
+	EvaluationCache   = cache.CacheGroupApp.CacheEvaluation
+)
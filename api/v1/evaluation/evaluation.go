@@ -1,13 +1,150 @@
 package evaluation
 
-import "github.com/gin-gonic/gin"
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"online_judge/models/common/response"
+	"online_judge/models/evaluation/request"
+	"online_judge/pkg/define"
+	"online_judge/pkg/utils"
+	"strconv"
+)
 
 type ApiEvaluation struct{}
 
+// GetEvaluationResult 获取评测结果接口
+// @Tags Evaluation API
+// @Summary 获取评测结果
+// @Description 获取单条评测记录，代码和错误信息只对提交者和管理员返回
+// @Produce json
+// @Param Authorization header string false "token"
+// @Param id path string true "评测ID"
+// @Success 200 {object} common.GetEvaluationResponse "1000 获取成功"
+// @Failure 200 {object} common.GetEvaluationResponse "1041 评测记录不存在"
+// @Failure 200 {object} common.GetEvaluationResponse "1014 服务器内部错误"
+// @Router /evaluation/{id} [GET]
 func (e *ApiEvaluation) GetEvaluationResult(c *gin.Context) {
+	var req request.EvaluationDetailReq
+	req.ID = c.Param("id")
+	req.ViewerID, req.IsAdmin = Viewer(c)
 
+	data, err := EvaluationCache.GetEvaluationDetailWithCache(req)
+	if err != nil {
+		ResponseEvaluationError(c, err)
+		return
+	}
+	response.ResponseSuccess(c, data)
 }
 
-func (e *ApiEvaluation) GetUserEvaluations(c *gin.Context) {}
+// GetUserEvaluations 获取用户的评测记录接口
+// @Tags Evaluation API
+// @Summary 获取用户的评测记录
+// @Description 按评测时间倒序分页，下一页使用上一页返回的 next_cursor
+// @Produce json
+// @Param Authorization header string false "token"
+// @Param user_id path string true "用户ID"
+// @Param verdict query string false "评测结果"
+// @Param language query string false "编程语言"
+// @Param from query string false "起始日期"
+// @Param to query string false "结束日期"
+// @Param cursor query string false "分页游标"
+// @Param size query int false "每页数量"
+// @Success 200 {object} common.GetEvaluationResponse "1000 获取成功"
+// @Failure 200 {object} common.GetEvaluationResponse "1001 请求参数错误"
+// @Failure 200 {object} common.GetEvaluationResponse "1014 服务器内部错误"
+// @Router /evaluation/user/{user_id} [GET]
+func (e *ApiEvaluation) GetUserEvaluations(c *gin.Context) {
+	req, ok := BindUserListReq(c)
+	if !ok {
+		return
+	}
+	data, err := EvaluationService.GetUserEvaluations(req)
+	if err != nil {
+		ResponseEvaluationError(c, err)
+		return
+	}
+	response.ResponseSuccess(c, data)
+}
+
+// GetProblemEvaluations 获取题目的评测记录接口
+// @Tags Evaluation API
+// @Summary 获取题目的评测记录
+// @Description 按评测时间倒序分页，下一页使用上一页返回的 next_cursor
+// @Produce json
+// @Param Authorization header string false "token"
+// @Param problem_id path string true "题目ID"
+// @Param verdict query string false "评测结果"
+// @Param language query string false "编程语言"
+// @Param from query string false "起始日期"
+// @Param to query string false "结束日期"
+// @Param cursor query string false "分页游标"
+// @Param size query int false "每页数量"
+// @Success 200 {object} common.GetEvaluationResponse "1000 获取成功"
+// @Failure 200 {object} common.GetEvaluationResponse "1001 请求参数错误"
+// @Failure 200 {object} common.GetEvaluationResponse "1014 服务器内部错误"
+// @Router /evaluation/problem/{problem_id} [GET]
+func (e *ApiEvaluation) GetProblemEvaluations(c *gin.Context) {
+	req, ok := BindProblemListReq(c)
+	if !ok {
+		return
+	}
+	data, err := EvaluationService.GetProblemEvaluations(req)
+	if err != nil {
+		ResponseEvaluationError(c, err)
+		return
+	}
+	response.ResponseSuccess(c, data)
+}
+
+// Viewer 获取当前登录的用户，未登录时返回 0
+func Viewer(c *gin.Context) (userID int64, isAdmin bool) {
+	userID = c.GetInt64(response.CtxUserIDKey)
+	isAdmin = c.GetBool(response.CtxUserIsAdminKey)
+	return
+}
+
+// BindUserListReq 绑定用户评测记录的查询参数
+func BindUserListReq(c *gin.Context) (req request.EvaluationListReq, ok bool) {
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.ResponseError(c, response.CodeInvalidParam)
+		return req, false
+	}
+	uid, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil || uid <= 0 {
+		response.ResponseError(c, response.CodeInvalidParam)
+		return req, false
+	}
+	req.UserID = uid
+	req.ViewerID, req.IsAdmin = Viewer(c)
+	return req, true
+}
 
-func (e *ApiEvaluation) GetProblemEvaluations(c *gin.Context) {}
+// BindProblemListReq 绑定题目评测记录的查询参数
+func BindProblemListReq(c *gin.Context) (req request.EvaluationListReq, ok bool) {
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.ResponseError(c, response.CodeInvalidParam)
+		return req, false
+	}
+	req.ProblemID = c.Param("problem_id")
+	req.ViewerID, req.IsAdmin = Viewer(c)
+	return req, true
+}
+
+// ResponseEvaluationError 把评测记录查询的错误转换为响应码
+func ResponseEvaluationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, define.ErrInvalidFilter), errors.Is(err, utils.ErrInvalidCursor):
+		response.ResponseError(c, response.CodeInvalidParam)
+
+	case errors.Is(err, define.ErrJudgementNotFound):
+		response.ResponseError(c, response.CodeJudgementNotExist)
+
+	case errors.Is(err, define.ErrSubmissionNotFound):
+		response.ResponseError(c, response.CodeSubmissionNotExist)
+
+	default:
+		zap.L().Error("controller-ResponseEvaluationError ", zap.Error(err))
+		response.ResponseError(c, response.CodeInternalServerError)
+	}
+}
@@ -0,0 +1,11 @@
+package language
+
+import "online_judge/services"
+
+type ApiGroup struct {
+	ApiLanguage
+}
+
+var (
+	LanguageService = services.ServiceGroupApp.LanguageService
+)
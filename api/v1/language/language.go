@@ -0,0 +1,19 @@
+package language
+
+import (
+	"github.com/gin-gonic/gin"
+	"online_judge/models/common/response"
+)
+
+type ApiLanguage struct{}
+
+// GetLanguageList 获取支持的语言列表接口
+// @Tags Language API
+// @Summary 获取支持的语言列表
+// @Description 获取支持的语言列表接口，提交代码时 language 字段使用返回的 name
+// @Produce json
+// @Success 200 {object} common.GetLanguageListResponse "获取语言列表成功"
+// @Router /languages [GET]
+func (l *ApiLanguage) GetLanguageList(c *gin.Context) {
+	response.ResponseSuccess(c, LanguageService.GetLanguageList())
+}
@@ -2,32 +2,90 @@ package leaderboard
 
 import (
 	"github.com/gin-gonic/gin"
+	"online_judge/consts"
 	"online_judge/consts/resp_code"
 	response2 "online_judge/models/common/response"
+	"online_judge/models/leaderboard/request"
+	"online_judge/pkg/define"
+	"strconv"
 )
 
+// maxLeaderboardSize 每页最大数量
+const maxLeaderboardSize = 100
+
 type ApiLeaderboard struct{}
 
 func (l *ApiLeaderboard) GetLeaderboard(c *gin.Context) {
 
 }
 
+// GetProblemLeaderboard 获取题目最快通过排行接口
+// @Tags Rank API
+// @Summary 获取题目最快通过排行
+// @Description 分页获取题目的通过记录，按运行时间、内存、提交时间排序，每个用户只保留最优的一次
+// @Produce json
+// @Param problem_id path string true "problem_id"
+// @Param language query string false "编程语言"
+// @Param page query int false "page, default: 1"
+// @Param size query int false "pageSize, default: 10"
+// @Success 200 {object} common.GetUserLeaderboardResponse "1000 获取用户题解排名成功"
+// @Failure 200 {object} common.GetUserLeaderboardResponse "1001 请求参数错误"
+// @Failure 200 {object} common.GetUserLeaderboardResponse "1021 题目ID不存在"
+// @Failure 200 {object} common.GetUserLeaderboardResponse "1022 获取用户题解排名失败"
+// @Router /leaderboard/problem/{problem_id} [GET]
 func (l *ApiLeaderboard) GetProblemLeaderboard(c *gin.Context) {
+	var req request.ProblemLeaderboardReq
+	req.ProblemID = c.Param("problem_id")
+	req.Language = c.Query("language")
+	req.Size, _ = strconv.Atoi(c.DefaultQuery("size", define.DefaultSize))
+	req.Page, _ = strconv.Atoi(c.DefaultQuery("page", define.DefaultPage))
+	if req.ProblemID == "" || req.Page <= 0 || req.Size <= 0 || req.Size > maxLeaderboardSize {
+		response2.ResponseError(c, response2.CodeInvalidParam)
+		return
+	}
 
+	response, err := LeaderboardService.GetProblemLeaderboard(req)
+	if err != nil {
+		response2.ResponseError(c, response2.CodeGetUserRankError)
+		return
+	}
+	switch response.Code {
+	case resp_code.Success:
+		response2.ResponseSuccess(c, response.Data)
+	case resp_code.ProblemNotExist:
+		response2.ResponseError(c, response2.CodeProblemIDNotExist)
+	default:
+		response2.ResponseError(c, response2.CodeInternalServerError)
+	}
 }
 
 // GetUserLeaderboard 获取用户题解排名接口
 // @Tags Rank API
 // @Summary 获取用户题解排名
-// @Description 获取用户题解排名接口
+// @Description 分页获取用户题解排名，按通过题目数量排名，数量相同时最后通过时间较早者在前
 // @Accept multipart/form-data
 // @Produce json
+// @Param page query int false "page, default: 1"
+// @Param size query int false "pageSize, default: 10"
+// @Param window query string false "week month all, default: all"
+// @Param category_id query string false "category_id"
 // @Success 200 {object} common.GetUserLeaderboardResponse "1000 获取用户题解排名成功"
+// @Failure 200 {object} common.GetUserLeaderboardResponse "1001 请求参数错误"
 // @Failure 200 {object} common.GetUserLeaderboardResponse "1022 获取用户题解排名失败"
 // @Failure 200 {object} common.GetUserLeaderboardResponse "1014 服务器内部错误"
 // @Router /leaderboard/user [GET]
 func (l *ApiLeaderboard) GetUserLeaderboard(c *gin.Context) {
-	response, err := LeaderboardService.GetUserLeaderboard()
+	var req request.LeaderboardReq
+	req.Size, _ = strconv.Atoi(c.DefaultQuery("size", define.DefaultSize))
+	req.Page, _ = strconv.Atoi(c.DefaultQuery("page", define.DefaultPage))
+	req.Window = c.DefaultQuery("window", consts.LeaderboardWindowAll)
+	req.CategoryID = c.Query("category_id")
+	if req.Page <= 0 || req.Size <= 0 || req.Size > maxLeaderboardSize || !validWindow(req.Window) {
+		response2.ResponseError(c, response2.CodeInvalidParam)
+		return
+	}
+
+	response, err := LeaderboardService.GetUserLeaderboard(req)
 	if err != nil {
 		response2.ResponseError(c, response2.CodeGetUserRankError)
 		return
@@ -40,3 +98,55 @@ func (l *ApiLeaderboard) GetUserLeaderboard(c *gin.Context) {
 	}
 	return
 }
+
+// GetMyRank 获取当前用户排名接口
+// @Tags Rank API
+// @Summary 获取当前用户排名
+// @Description 获取当前登录用户的排名，没有通过任何题目时 rank 为 0
+// @Produce json
+// @Param Authorization header string true "token"
+// @Param window query string false "week month all, default: all"
+// @Param category_id query string false "category_id"
+// @Success 200 {object} common.GetUserLeaderboardResponse "1000 获取用户题解排名成功"
+// @Failure 200 {object} common.GetUserLeaderboardResponse "1001 请求参数错误"
+// @Failure 200 {object} common.GetUserLeaderboardResponse "1008 需要登录"
+// @Failure 200 {object} common.GetUserLeaderboardResponse "1022 获取用户题解排名失败"
+// @Router /leaderboard/me [GET]
+func (l *ApiLeaderboard) GetMyRank(c *gin.Context) {
+	userId, ok := c.Get(response2.CtxUserIDKey)
+	if !ok {
+		response2.ResponseError(c, response2.CodeNeedLogin)
+		return
+	}
+
+	req := request.LeaderboardReq{
+		Window:     c.DefaultQuery("window", consts.LeaderboardWindowAll),
+		CategoryID: c.Query("category_id"),
+		UserID:     userId.(int64),
+	}
+	if !validWindow(req.Window) {
+		response2.ResponseError(c, response2.CodeInvalidParam)
+		return
+	}
+
+	response, err := LeaderboardService.GetMyRank(req)
+	if err != nil {
+		response2.ResponseError(c, response2.CodeGetUserRankError)
+		return
+	}
+	switch response.Code {
+	case resp_code.Success:
+		response2.ResponseSuccess(c, response.Data)
+	default:
+		response2.ResponseError(c, response2.CodeInternalServerError)
+	}
+}
+
+// validWindow 检查排行榜统计时间段是否合法
+func validWindow(window string) bool {
+	switch window {
+	case consts.LeaderboardWindowAll, consts.LeaderboardWindowWeek, consts.LeaderboardWindowMonth:
+		return true
+	}
+	return false
+}
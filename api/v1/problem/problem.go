@@ -15,7 +15,8 @@ type ApiProblem struct{}
 // GetProblemList 获取题目列表接口
 // @Tags Problem API
 // @Summary 获取题目列表
-// @Description 获取题目列表接口
+// @Description 获取题目列表接口，登录后会标注当前用户在每道题目上的状态
+// @Param Authorization header string false "token"
 // @Param page query int false "input current page num, default: 1"
 // @Param size query int false "pageSize, default: 10"
 // @Success 200 {object} common.GetProblemListResponse "获取题目列表成功"
@@ -41,16 +42,22 @@ func (p *ApiProblem) GetProblemList(c *gin.Context) {
 		zap.L().Error("controller-GetProblemList-GetProblemList ", zap.Error(err))
 		return
 	}
+	// 状态获取失败时仍然返回题目列表
+	if uid := c.GetInt64(response.CtxUserIDKey); uid != 0 {
+		if err = ProblemService.FillProblemListStatus(uid, data.Data); err != nil {
+			zap.L().Error("controller-GetProblemList-FillProblemListStatus ", zap.Error(err))
+		}
+	}
 	response.ResponseSuccess(c, data)
 }
 
 // GetProblemDetail 获取单个题目详细接口
 // @Tags Problem API
 // @Summary 获取单个题目详细
-// @Description 获取单个题目详细接口
+// @Description 获取单个题目详细接口，登录后会标注当前用户在题目上的状态
 // @Accept multipart/form-data
 // @Produce json,multipart/form-data
-// // @Param Authorization header string true "token"
+// @Param Authorization header string false "token"
 // @Param problem_id path string true "题目ID"
 // @Success 200 {object} common.GetProblemDetailResponse "1000 获取成功"
 // @Failure 200 {object} common.GetProblemDetailResponse "1008 需要登录"
@@ -67,6 +74,11 @@ func (p *ApiProblem) GetProblemDetail(c *gin.Context) {
 		zap.L().Error("controller-GetProblemDetail-GetProblemDetail ", zap.Error(err))
 		return
 	}
+	if uid := c.GetInt64(response.CtxUserIDKey); uid != 0 {
+		if err = ProblemService.FillProblemDetailStatus(uid, data); err != nil {
+			zap.L().Error("controller-GetProblemDetail-FillProblemDetailStatus ", zap.Error(err))
+		}
+	}
 	response.ResponseSuccess(c, data)
 }
 
@@ -1,6 +1,9 @@
 package submission
 
-import "online_judge/services"
+import (
+	"online_judge/dao/redis/cache"
+	"online_judge/services"
+)
 
 type ApiGroup struct {
 	ApiSubmission
@@ -8,4 +11,6 @@ type ApiGroup struct {
 
 var (
 	SubmissionService = services.ServiceGroupApp.SubmissionService
+	EvaluationService = services.ServiceGroupApp.EvaluationService
+	SubmissionCache   = cache.CacheGroupApp.CacheSubmission
 )
@@ -2,29 +2,40 @@ package submission
 
 import (
 	"github.com/gin-gonic/gin"
+	"io"
+	"online_judge/api/v1/evaluation"
+	"online_judge/consts"
 	"online_judge/consts/resp_code"
 	"online_judge/models/common/response"
+	evaluationReq "online_judge/models/evaluation/request"
 	"online_judge/models/submission/request"
 	"online_judge/pkg/utils"
+	"strconv"
 	"time"
 )
 
 type ApiSubmission struct{}
 
+// progressHeartbeat SSE 心跳间隔
+const progressHeartbeat = 15 * time.Second
+
 // SubmitCode 提交代码接口
 // @Tags Submission API
 // @Summary 提交代码
-// @Description 提交代码接口
+// @Description 提交代码接口，评测在后台异步进行，返回提交ID和等待评测的记录ID
 // @Accept multipart/form-data
 // @Produce json
 // @Param Authorization header string true "token"
 // @Param problem_id formData string true "题目id"
 // @Param language formData string true "语言"
 // @Param code formData string true "代码"
+// @Param contest_id formData string false "比赛ID"
+// @Param pretest formData bool false "预测试模式，先评测预测试再评测其余测试数据"
 // @Success 200 {object} common.SubmitCodeResponse "提交代码成功"
 // @Failure 200 {object} common.SubmitCodeResponse "用户ID不存在"
 // @Failure 200 {object} common.SubmitCodeResponse "题目ID不存在"
 // @Failure 200 {object} common.SubmitCodeResponse "不支持的语言类型"
+// @Failure 200 {object} common.SubmitCodeResponse "比赛不存在、不在比赛时间内或未报名"
 // @Failure 200 {object} common.SubmitCodeResponse "需要登录"
 // @Failure 200 {object} common.SubmitCodeResponse "服务器内部错误"
 // @Router /submission/code [POST]
@@ -39,6 +50,8 @@ func (s *ApiSubmission) SubmitCode(c *gin.Context) {
 	submissionReq.ProblemID = c.PostForm("problem_id")
 	submissionReq.Language = c.PostForm("language")
 	submissionReq.Code = c.PostForm("code")
+	submissionReq.ContestID = c.PostForm("contest_id")
+	submissionReq.Pretest, _ = strconv.ParseBool(c.PostForm("pretest"))
 
 	submissionReq.SubmissionID = utils.GetUUID()
 	submissionReq.UserID = userId.(int64)
@@ -58,6 +71,18 @@ func (s *ApiSubmission) SubmitCode(c *gin.Context) {
 	case resp_code.UnsupportedLanguage:
 		response.ResponseError(c, response.CodeUnsupportedLanguage)
 
+	case resp_code.ContestNotExist:
+		response.ResponseError(c, response.CodeContestNotExist)
+
+	case resp_code.ContestNotRunning:
+		response.ResponseError(c, response.CodeContestNotRunning)
+
+	case resp_code.ContestNotRegistered:
+		response.ResponseError(c, response.CodeContestNotRegistered)
+
+	case resp_code.ProblemNotInContest:
+		response.ResponseError(c, response.CodeProblemNotInContest)
+
 	default:
 		response.ResponseError(c, response.CodeInternalServerError)
 	}
@@ -66,17 +91,20 @@ func (s *ApiSubmission) SubmitCode(c *gin.Context) {
 // SubmitCodeWithFile 提交代码接口(文件)
 // @Tags Submission API
 // @Summary 提交代码(文件)
-// @Description 提交代码接口(文件)
+// @Description 提交代码接口(文件)，评测在后台异步进行，返回提交ID和等待评测的记录ID
 // @Accept multipart/form-data
 // @Produce json
 // @Param Authorization header string true "token"
 // @Param problem_id formData string true "题目id"
 // @Param language formData string true "语言"
 // @Param code formData string true "代码"
+// @Param contest_id formData string false "比赛ID"
+// @Param pretest formData bool false "预测试模式，先评测预测试再评测其余测试数据"
 // @Success 200 {object} common.SubmitCodeResponse "提交代码成功"
 // @Failure 200 {object} common.SubmitCodeResponse "用户ID不存在"
 // @Failure 200 {object} common.SubmitCodeResponse "题目ID不存在"
 // @Failure 200 {object} common.SubmitCodeResponse "不支持的语言类型"
+// @Failure 200 {object} common.SubmitCodeResponse "比赛不存在、不在比赛时间内或未报名"
 // @Failure 200 {object} common.SubmitCodeResponse "需要登录"
 // @Failure 200 {object} common.SubmitCodeResponse "服务器内部错误"
 // @Router /submission/file/code [POST]
@@ -91,6 +119,8 @@ func (s *ApiSubmission) SubmitCodeWithFile(c *gin.Context) {
 	req.ProblemID = c.PostForm("problem_id")
 	req.Language = c.PostForm("language")
 	req.Code = c.PostForm("code")
+	req.ContestID = c.PostForm("contest_id")
+	req.Pretest, _ = strconv.ParseBool(c.PostForm("pretest"))
 
 	req.SubmissionID = utils.GetUUID()
 	req.UserID = userId.(int64)
@@ -109,19 +139,212 @@ func (s *ApiSubmission) SubmitCodeWithFile(c *gin.Context) {
 	case resp_code.UnsupportedLanguage:
 		response.ResponseError(c, response.CodeUnsupportedLanguage)
 
+	case resp_code.ContestNotExist:
+		response.ResponseError(c, response.CodeContestNotExist)
+
+	case resp_code.ContestNotRunning:
+		response.ResponseError(c, response.CodeContestNotRunning)
+
+	case resp_code.ContestNotRegistered:
+		response.ResponseError(c, response.CodeContestNotRegistered)
+
+	case resp_code.ProblemNotInContest:
+		response.ResponseError(c, response.CodeProblemNotInContest)
+
+	default:
+		response.ResponseError(c, response.CodeInternalServerError)
+	}
+}
+
+// RunCode 自定义输入运行代码接口
+// @Tags Submission API
+// @Summary 自定义输入运行代码
+// @Description 使用题目的时间和内存限制，以自定义输入运行代码并同步返回输出，不创建提交记录，不影响通过数量，每个用户每分钟的运行次数单独限流
+// @Accept multipart/form-data
+// @Produce json
+// @Param Authorization header string true "token"
+// @Param problem_id formData string true "题目id"
+// @Param language formData string true "语言"
+// @Param code formData string true "代码"
+// @Param input formData string false "标准输入"
+// @Success 200 {object} common.RunCodeResponse "1000 运行完成，data 为运行结果"
+// @Failure 200 {object} common.RunCodeResponse "1001 参数错误"
+// @Failure 200 {object} common.RunCodeResponse "1021 题目ID不存在"
+// @Failure 200 {object} common.RunCodeResponse "1024 不支持的语言类型"
+// @Failure 200 {object} common.RunCodeResponse "1052 请求过于频繁"
+// @Failure 200 {object} common.RunCodeResponse "1014 服务器内部错误"
+// @Router /submission/run [POST]
+func (s *ApiSubmission) RunCode(c *gin.Context) {
+	var req request.RunCodeReq
+	if err := c.ShouldBind(&req); err != nil {
+		response.ResponseError(c, response.CodeInvalidParam)
+		return
+	}
+	req.UserID = c.GetInt64(response.CtxUserIDKey)
+	req.RunID = utils.GetUUID()
+
+	resp := SubmissionService.RunCode(req)
+	switch resp.Code {
+	case resp_code.Success:
+		response.ResponseSuccess(c, resp.Data)
+
+	case resp_code.ProblemNotExist:
+		response.ResponseError(c, response.CodeProblemIDNotExist)
+
+	case resp_code.UnsupportedLanguage:
+		response.ResponseError(c, response.CodeUnsupportedLanguage)
+
 	default:
 		response.ResponseError(c, response.CodeInternalServerError)
 	}
 }
 
+// StreamSubmission 推送评测进度接口
+// @Tags Submission API
+// @Summary 推送评测进度
+// @Description 通过 SSE 推送评测进度，事件名为 progress，stage 依次为 received compiling running finished
+// @Description finished 事件附带最终结果，之后服务端关闭连接
+// @Produce text/event-stream
+// @Param Authorization header string true "token"
+// @Param id path string true "提交ID"
+// @Success 200 {string} string "event: progress"
+// @Failure 200 {object} common.SubmitCodeResponse "提交记录不存在"
+// @Failure 200 {object} common.SubmitCodeResponse "需要登录"
+// @Failure 200 {object} common.SubmitCodeResponse "服务器内部错误"
+// @Router /submission/{id}/stream [GET]
+func (s *ApiSubmission) StreamSubmission(c *gin.Context) {
+	var req request.SubmissionProgressReq
+	userId, ok := c.Get(response.CtxUserIDKey)
+	if !ok {
+		response.ResponseError(c, response.CodeNeedLogin)
+		return
+	}
+	req.SubmissionID = c.Param("id")
+	req.UserID = userId.(int64)
+
+	resp, events := SubmissionService.StreamProgress(c.Request.Context(), req)
+	switch resp.Code {
+	case resp_code.Success:
+
+	case resp_code.SubmissionNotExist:
+		response.ResponseError(c, response.CodeSubmissionNotExist)
+		return
+
+	case resp_code.PermissionDenied:
+		response.ResponseError(c, response.CodeUnauthorized)
+		return
+
+	default:
+		response.ResponseError(c, response.CodeInternalServerError)
+		return
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// 关闭 nginx 的响应缓冲
+	c.Header("X-Accel-Buffering", "no")
+
+	heartbeat := time.NewTicker(progressHeartbeat)
+	defer heartbeat.Stop()
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			c.SSEvent("progress", event)
+			return event.Stage != consts.ProgressFinished
+		case <-heartbeat.C:
+			// SSE 注释行，防止连接被代理断开
+			_, err := io.WriteString(w, ": ping\n\n")
+			return err == nil
+		}
+	})
+}
+
+// GetSubmissionDetail 获取单个提交详细接口
+// @Tags Submission API
+// @Summary 获取单个提交详细
+// @Description 获取提交记录及评测结果，代码和错误信息只对提交者和管理员返回
+// @Produce json
+// @Param Authorization header string true "token"
+// @Param id path string true "提交ID"
+// @Success 200 {object} common.GetEvaluationResponse "1000 获取成功"
+// @Failure 200 {object} common.GetEvaluationResponse "1040 提交记录不存在"
+// @Failure 200 {object} common.GetEvaluationResponse "1008 需要登录"
+// @Failure 200 {object} common.GetEvaluationResponse "1014 服务器内部错误"
+// @Router /submission/{id} [GET]
 func (s *ApiSubmission) GetSubmissionDetail(c *gin.Context) {
+	var req evaluationReq.EvaluationDetailReq
+	req.ID = c.Param("id")
+	req.ViewerID, req.IsAdmin = evaluation.Viewer(c)
 
+	data, err := SubmissionCache.GetSubmissionDetailWithCache(req)
+	if err != nil {
+		evaluation.ResponseEvaluationError(c, err)
+		return
+	}
+	response.ResponseSuccess(c, data)
 }
 
+// GetUserSubmissions 获取用户的提交记录接口
+// @Tags Submission API
+// @Summary 获取用户的提交记录
+// @Description 按提交时间倒序分页，下一页使用上一页返回的 next_cursor
+// @Produce json
+// @Param Authorization header string true "token"
+// @Param user_id path string true "用户ID"
+// @Param verdict query string false "评测结果"
+// @Param language query string false "编程语言"
+// @Param from query string false "起始日期"
+// @Param to query string false "结束日期"
+// @Param cursor query string false "分页游标"
+// @Param size query int false "每页数量"
+// @Success 200 {object} common.GetEvaluationResponse "1000 获取成功"
+// @Failure 200 {object} common.GetEvaluationResponse "1001 请求参数错误"
+// @Failure 200 {object} common.GetEvaluationResponse "1008 需要登录"
+// @Failure 200 {object} common.GetEvaluationResponse "1014 服务器内部错误"
+// @Router /submission/user/{user_id} [GET]
 func (s *ApiSubmission) GetUserSubmissions(c *gin.Context) {
-
+	req, ok := evaluation.BindUserListReq(c)
+	if !ok {
+		return
+	}
+	data, err := EvaluationService.GetUserEvaluations(req)
+	if err != nil {
+		evaluation.ResponseEvaluationError(c, err)
+		return
+	}
+	response.ResponseSuccess(c, data)
 }
 
+// GetProblemSubmissions 获取题目的提交记录接口
+// @Tags Submission API
+// @Summary 获取题目的提交记录
+// @Description 按提交时间倒序分页，下一页使用上一页返回的 next_cursor
+// @Produce json
+// @Param Authorization header string true "token"
+// @Param problem_id path string true "题目ID"
+// @Param verdict query string false "评测结果"
+// @Param language query string false "编程语言"
+// @Param from query string false "起始日期"
+// @Param to query string false "结束日期"
+// @Param cursor query string false "分页游标"
+// @Param size query int false "每页数量"
+// @Success 200 {object} common.GetEvaluationResponse "1000 获取成功"
+// @Failure 200 {object} common.GetEvaluationResponse "1001 请求参数错误"
+// @Failure 200 {object} common.GetEvaluationResponse "1008 需要登录"
+// @Failure 200 {object} common.GetEvaluationResponse "1014 服务器内部错误"
+// @Router /submission/problem/{problem_id} [GET]
 func (s *ApiSubmission) GetProblemSubmissions(c *gin.Context) {
-
+	req, ok := evaluation.BindProblemListReq(c)
+	if !ok {
+		return
+	}
+	data, err := EvaluationService.GetProblemEvaluations(req)
+	if err != nil {
+		evaluation.ResponseEvaluationError(c, err)
+		return
+	}
+	response.ResponseSuccess(c, data)
 }
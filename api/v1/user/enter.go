@@ -1,6 +1,9 @@
 package user
 
-import "online_judge/services"
+import (
+	"online_judge/dao/redis/cache"
+	"online_judge/services"
+)
 
 type ApiGroup struct {
 	ApiUser
@@ -8,4 +11,5 @@ type ApiGroup struct {
 
 var (
 	UserService = services.ServiceGroupApp.UserService
+	UserCache   = cache.CacheGroupApp.CacheUser
 )
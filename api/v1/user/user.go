@@ -6,6 +6,7 @@ import (
 	"online_judge/consts/resp_code"
 	"online_judge/models/common/response"
 	"online_judge/models/user/request"
+	"strconv"
 )
 
 type ApiUser struct{}
@@ -55,6 +56,44 @@ func (u *ApiUser) GetUserDetail(c *gin.Context) {
 	}
 }
 
+// GetUserProfile 获取用户主页统计接口
+// @Tags User API
+// @Summary 获取用户主页统计
+// @Description 获取用户按难度和分类的通过数量、通过率、常用语言、连续提交天数和最近 365 天的提交热力图
+// @Produce json
+// @Param Authorization header string true "token"
+// @Param user_id query int false "用户ID，默认为当前用户"
+// @Success 200 {object} common.GetUserProfileResponse "1000 获取用户信息成功"
+// @Failure 200 {object} common.GetUserProfileResponse "1001 参数错误"
+// @Failure 200 {object} common.GetUserProfileResponse "1004 没有此用户ID"
+// @Failure 200 {object} common.GetUserProfileResponse "1014 服务器内部错误"
+// @Router /users/profile [GET]
+func (u *ApiUser) GetUserProfile(c *gin.Context) {
+	var req request.GetUserDetailReq
+	req.UserID = c.GetInt64(response.CtxUserIDKey)
+	if uid := c.Query("user_id"); uid != "" {
+		var err error
+		if req.UserID, err = strconv.ParseInt(uid, 10, 64); err != nil || req.UserID <= 0 {
+			response.ResponseError(c, response.CodeInvalidParam)
+			return
+		}
+	}
+	if req.UserID == 0 {
+		response.ResponseError(c, response.CodeNeedLogin)
+		return
+	}
+
+	ret := UserCache.GetUserProfileWithCache(req)
+	switch ret.Code {
+	case resp_code.Success:
+		response.ResponseSuccess(c, ret.Data)
+	case resp_code.NotExistUserID:
+		response.ResponseError(c, response.CodeUseNotExist)
+	default:
+		response.ResponseError(c, response.CodeInternalServerError)
+	}
+}
+
 // UpdateUserDetail 更新用户详细信息接口
 // @Tags User API
 // @Summary 更新用户详细信息
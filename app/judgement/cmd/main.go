@@ -5,17 +5,68 @@ import (
 	"github.com/go-micro/plugins/v4/registry/etcd"
 	"go-micro.dev/v4"
 	"go-micro.dev/v4/registry"
+	"go.uber.org/zap"
 	"online_judge/app/judgement/service"
+	"online_judge/app/judgement/service/judging"
+	"online_judge/app/judgement/service/judging/sandbox"
+	"online_judge/app/judgement/service/judging/workspace"
+	"online_judge/dao/mq"
+	"online_judge/dao/mysql"
+	"online_judge/dao/redis"
+	"online_judge/logger"
+	"online_judge/pkg/language"
 	pb "online_judge/proto"
 	"online_judge/setting"
 )
 
 func main() {
+	// 沙箱子进程在这里完成隔离并 exec 用户程序，不会返回
+	sandbox.ChildInit()
 	//  loading config files
 	if err := setting.Init(); err != nil {
 		fmt.Printf("init setting failed, err: %v\n", err)
 		return
 	}
+	// 初始化日志
+	if err := logger.Init(setting.Conf.LogConfig, setting.Conf.Mode); err != nil {
+		fmt.Printf("init logger failed, err: %v\n", err)
+		return
+	}
+	defer zap.L().Sync()
+	// 评测结果写回 judgement 表
+	if err := mysql.Init(setting.Conf.MySQLConfig); err != nil {
+		fmt.Printf("init mysql failed, err: %v\n", err)
+		return
+	}
+	// 发布评测进度
+	if err := redis.Init(setting.Conf.RedisConfig); err != nil {
+		fmt.Printf("init redis failed, err: %v\n", err)
+		return
+	}
+	defer redis.Close()
+	// 从消息队列消费评测任务
+	if err := mq.InitRabbitMQ(setting.Conf.RabbitMQConfig); err != nil {
+		fmt.Printf("init rabbitmq failed, err: %v\n", err)
+		return
+	}
+	defer mq.Close()
+	// 初始化语言注册表
+	if err := language.Init(setting.Conf.Languages); err != nil {
+		fmt.Printf("init language failed, err: %v\n", err)
+		return
+	}
+	// 初始化评测工作目录
+	if err := workspace.Init(setting.Conf.JudgementConfig); err != nil {
+		fmt.Printf("init workspace failed, err: %v\n", err)
+		return
+	}
+	// 初始化评测流水线
+	judging.Init(setting.Conf.JudgementConfig)
+	// 初始化沙箱
+	if err := sandbox.Init(setting.Conf.SandboxConfig); err != nil {
+		fmt.Printf("init sandbox failed, err: %v\n", err)
+		return
+	}
 	addr := setting.Conf.EtcdConfig.Host
 	port := setting.Conf.EtcdConfig.Port
 	// etcd 注册
@@ -28,8 +79,19 @@ func main() {
 		micro.Address("127.0.0.1:8082"),
 		micro.Registry(etcdReg),
 	)
+	// 启动评测 worker，服务停止前等待正在进行的评测完成
+	workers := service.NewJudgeWorkers(setting.Conf.JudgementConfig)
 	// 结构命令行参数，初始化
-	microService.Init()
+	microService.Init(
+		micro.AfterStart(func() error {
+			workers.Start()
+			return nil
+		}),
+		micro.BeforeStop(func() error {
+			workers.Stop()
+			return nil
+		}),
+	)
 	// 服务注册
 	_ = pb.RegisterSubmissionHandler(microService.Server(), service.GetSubmitSrv())
 	// 启动微服务
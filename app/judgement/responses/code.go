@@ -9,9 +9,3 @@ const (
 	MemoryLimited
 	SystemError
 )
-
-// Path 位置
-const (
-	//Path = "D:/online_judge/submit"
-	Path = "./submit"
-)
@@ -2,29 +2,22 @@ package service
 
 import (
 	"fmt"
-	"online_judge/app/judgement/service/judging/cpp"
-	"online_judge/app/judgement/service/judging/golang"
-	"online_judge/app/judgement/service/judging/java"
-	"online_judge/app/judgement/service/judging/python"
-	"online_judge/consts"
+	"online_judge/app/judgement/responses"
+	"online_judge/app/judgement/service/judging"
+	"online_judge/pkg/language"
 	pb "online_judge/proto"
 )
 
-func LanguageCheck(request *pb.SubmitRequest, response *pb.SubmitResponse) (err error) {
-	language := request.Language
-	if language == consts.CPP {
-		response, err = cpp.JudgeCpp(request, response)
-	}
-	if language == consts.GO {
-		response, err = golang.JudgeGO(request, response)
-	}
-	if language == consts.JAVA {
-		response, err = java.JudgeJAVA(request, response)
-	}
-	if language == consts.PYTHON {
-		response, err = python.JudgePYTHON(request, response)
+func LanguageCheck(request *pb.SubmitRequest, response *pb.SubmitResponse, progress judging.ProgressFunc) (err error) {
+	lang, ok := language.Get(request.Language)
+	if !ok {
+		response.Status = responses.SystemError
+		response.UserId = request.UserId
+		response.Output = fmt.Sprintf("unsupported language %q", request.Language)
+		response.TotalNum = request.TotalNum
+		return fmt.Errorf("unsupported language %q", request.Language)
 	}
+	response, err = judging.Judge(lang, request, response, progress)
 	response.TotalNum = request.TotalNum
-	fmt.Println("response: ", response.Status)
-	return nil
+	return err
 }
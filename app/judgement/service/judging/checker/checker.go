@@ -0,0 +1,105 @@
+package checker
+
+import (
+	"math"
+	"online_judge/consts"
+	pb "online_judge/proto"
+	"strconv"
+	"strings"
+)
+
+// Result 一次答案检查的结果
+type Result struct {
+	Accepted bool
+	Message  string // 检查器给出的说明，答案错误时返回给用户
+}
+
+// Checker 比较用户输出与标准答案
+type Checker interface {
+	Check(index int, input, output, answer string) (Result, error)
+}
+
+// New 根据题目的检查方式创建内置检查器，custom 模式需要使用 NewCustom
+func New(cfg *pb.Checker) Checker {
+	if cfg == nil {
+		return Exact{}
+	}
+	switch cfg.Mode {
+	case consts.CheckerToken:
+		return Token{}
+	case consts.CheckerFloat:
+		eps := cfg.Epsilon
+		if eps <= 0 {
+			eps = consts.DefaultCheckerEpsilon
+		}
+		return Float{Epsilon: eps}
+	default:
+		return Exact{}
+	}
+}
+
+// Exact 输出与答案逐字节相同
+type Exact struct{}
+
+func (Exact) Check(_ int, _, output, answer string) (Result, error) {
+	return Result{Accepted: output == answer}, nil
+}
+
+// Token 忽略空白字符的差异
+type Token struct{}
+
+func (Token) Check(_ int, _, output, answer string) (Result, error) {
+	out, ans := strings.Fields(output), strings.Fields(answer)
+	if len(out) != len(ans) {
+		return Result{Message: tokenCountMessage(len(out), len(ans))}, nil
+	}
+	for i := range ans {
+		if out[i] != ans[i] {
+			return Result{Message: tokenMessage(i, out[i])}, nil
+		}
+	}
+	return Result{Accepted: true}, nil
+}
+
+// Float 逐个 token 比较，两边都是数字时允许绝对或相对误差不超过 Epsilon
+// 同一个 Epsilon 同时作为绝对误差和相对误差，满足其中一个即可：|out-ans| <= eps 或 |out-ans| <= eps*|ans|
+// 答案的绝对值不超过 1 时相当于只比较绝对误差，大于 1 时相当于只比较相对误差
+type Float struct {
+	Epsilon float64
+}
+
+func (f Float) Check(_ int, _, output, answer string) (Result, error) {
+	out, ans := strings.Fields(output), strings.Fields(answer)
+	if len(out) != len(ans) {
+		return Result{Message: tokenCountMessage(len(out), len(ans))}, nil
+	}
+	for i := range ans {
+		if out[i] == ans[i] {
+			continue
+		}
+		a, errA := strconv.ParseFloat(ans[i], 64)
+		o, errO := strconv.ParseFloat(out[i], 64)
+		if errA != nil || errO != nil || !f.equal(o, a) {
+			return Result{Message: tokenMessage(i, out[i])}, nil
+		}
+	}
+	return Result{Accepted: true}, nil
+}
+
+// equal 相对误差以标准答案为基准，NaN 与任何值都不相等
+func (f Float) equal(out, ans float64) bool {
+	if math.IsNaN(out) || math.IsNaN(ans) {
+		return false
+	}
+	diff := math.Abs(out - ans)
+	return diff <= f.Epsilon || diff <= f.Epsilon*math.Abs(ans)
+}
+
+func tokenCountMessage(out, ans int) string {
+	return "expected " + strconv.Itoa(ans) + " tokens, found " + strconv.Itoa(out)
+}
+
+// tokenMessage 不包含标准答案，隐藏的测试数据也会返回检查器信息
+func tokenMessage(i int, out string) string {
+	return "token " + strconv.Itoa(i+1) + " differs, found " + strconv.Quote(out)
+}
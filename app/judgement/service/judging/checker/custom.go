@@ -0,0 +1,115 @@
+package checker
+
+import (
+	"bytes"
+	"fmt"
+	"online_judge/app/judgement/service/judging/sandbox"
+	"online_judge/pkg/language"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// customTimeLimit 检查器单次运行的时间限制
+	customTimeLimit = 5 * time.Second
+	// customMemoryLimit 检查器单次运行的内存限制：单位 KB
+	customMemoryLimit = 512 * 1024
+	// messageLimit 检查器说明最多返回的字节数
+	messageLimit = 1024
+)
+
+// testlib 检查器的退出码
+const (
+	exitOK               = 0
+	exitWrongAnswer      = 1
+	exitPresentationFail = 2
+)
+
+// Custom 管理员上传的检查器程序，调用方式为 checker input output answer
+type Custom struct {
+	lang *language.Language
+	dir  string // 检查器编译后所在的目录
+}
+
+// NewCustom 使用已经编译好的检查器，dir 为检查器所在目录
+func NewCustom(lang *language.Language, dir string) (*Custom, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Custom{lang: lang, dir: abs}, nil
+}
+
+func (c *Custom) Check(index int, input, output, answer string) (Result, error) {
+	// 每个测试样例使用单独的目录存放检查器的输入文件
+	caseDir := filepath.Join(c.dir, "case-"+strconv.Itoa(index))
+	if err := os.MkdirAll(caseDir, 0755); err != nil {
+		return Result{}, err
+	}
+	defer os.RemoveAll(caseDir)
+
+	files := []struct {
+		name, content string
+	}{
+		{"input.txt", input},
+		{"output.txt", output},
+		{"answer.txt", answer},
+	}
+	args := append([]string{}, c.lang.RunCmd...)
+	for _, f := range files {
+		path := filepath.Join(caseDir, f.name)
+		if err := os.WriteFile(path, []byte(f.content), 0644); err != nil {
+			return Result{}, err
+		}
+		args = append(args, path)
+	}
+
+	var stdout, stderr bytes.Buffer
+	res, err := sandbox.Run(sandbox.Config{
+		Args:        args,
+		Env:         append([]string{"PATH=/usr/local/bin:/usr/bin:/bin", "HOME=/tmp"}, c.lang.Env...),
+		Dir:         c.dir,
+		Stdout:      &stdout,
+		Stderr:      &stderr,
+		TimeLimit:   customTimeLimit,
+		MemoryLimit: customMemoryLimit,
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	// testlib 把说明写到 stderr
+	message := Message(stderr.String())
+	if message == "" {
+		message = Message(stdout.String())
+	}
+
+	if res.Status != sandbox.StatusOK && res.Status != sandbox.StatusRuntimeError {
+		return Result{}, fmt.Errorf("checker %s: %s", res.Status, res.Error)
+	}
+	return FromExitCode(res.ExitCode, message)
+}
+
+// FromExitCode 按照 testlib 的退出码约定得到检查结果，交互器也使用相同的约定
+func FromExitCode(code int, message string) (Result, error) {
+	switch code {
+	case exitOK:
+		return Result{Accepted: true, Message: message}, nil
+	case exitWrongAnswer, exitPresentationFail:
+		return Result{Message: message}, nil
+	default:
+		return Result{}, fmt.Errorf("checker failed with exit code %d: %s", code, message)
+	}
+}
+
+// Message 整理检查器输出的说明，超过长度限制时截断
+func Message(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) > messageLimit {
+		s = s[:messageLimit]
+	}
+	return s
+}
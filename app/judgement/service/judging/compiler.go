@@ -0,0 +1,64 @@
+package judging
+
+import (
+	"bytes"
+	"errors"
+	"online_judge/app/judgement/service/judging/sandbox"
+	"online_judge/pkg/language"
+	"path/filepath"
+	"time"
+)
+
+// errCompile 用户代码编译失败
+var errCompile = errors.New("compile error")
+
+// compileTimeLimit 编译的 CPU 时间限制，墙上时间为两倍
+const compileTimeLimit = 15 * time.Second
+
+// compileMemoryLimit 编译的内存限制，单位 KB
+const compileMemoryLimit = 1024 * 1024
+
+// compileOutputLimit 编译错误信息最多返回的字节数
+const compileOutputLimit = 4096
+
+// Compile 在沙箱中执行语言的编译命令，失败时返回编译器输出
+// 编译器以沙箱用户运行，只能看到最小的环境变量，工作目录在编译期间可写
+// 编译错误返回 errCompile，其他错误为评测环境的问题
+func Compile(lang *language.Language, dir string) (string, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	var output bytes.Buffer
+	res, err := sandbox.Run(sandbox.Config{
+		Args:        lang.CompileCmd,
+		Env:         append(append([]string{}, defaultEnv...), lang.Env...),
+		Dir:         absDir,
+		Stdout:      &output,
+		Stderr:      &output,
+		TimeLimit:   compileTimeLimit,
+		MemoryLimit: compileMemoryLimit,
+		OutputLimit: compileOutputLimit,
+		WritableDir: true,
+	})
+	if err != nil {
+		return "", err
+	}
+	// 编译器输出超过限制只截断信息，以退出码判断是否编译成功
+	if res.ExitCode == 0 && (res.Status == sandbox.StatusOK || res.Status == sandbox.StatusOutputLimitExceeded) {
+		return "", nil
+	}
+
+	msg := output.String()
+	switch {
+	case res.Status == sandbox.StatusTimeLimitExceeded:
+		msg = "compile time limit exceeded"
+	case res.Status == sandbox.StatusMemoryLimitExceeded:
+		msg = "compile memory limit exceeded"
+	case msg == "" && res.Error != "":
+		msg = res.Error
+	case msg == "":
+		msg = "compiler exited with " + res.Status.String()
+	}
+	return msg, errCompile
+}
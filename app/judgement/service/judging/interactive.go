@@ -0,0 +1,244 @@
+package judging
+
+import (
+	"bytes"
+	"fmt"
+	"go.uber.org/zap"
+	"io"
+	"online_judge/app/judgement/responses"
+	"online_judge/app/judgement/service/judging/checker"
+	"online_judge/app/judgement/service/judging/sandbox"
+	"online_judge/pkg/language"
+	pb "online_judge/proto"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// interactorTimeLimit 交互器单个测试样例的 CPU 时间限制，与用户程序分开计算
+	interactorTimeLimit = 10 * time.Second
+	// interactorMemoryLimit 交互器的内存限制：单位 KB
+	interactorMemoryLimit = 512 * 1024
+	// transcriptLimit 每个测试样例最多记录的交互内容字节数
+	transcriptLimit = 4096
+)
+
+// interactor 交互题的交互器，调用方式为 interactor input answer
+// 交互器的标准输入输出与用户程序交叉连接，由交互器的退出码决定评测结果
+type interactor struct {
+	lang *language.Language
+	dir  string // 交互器编译后所在的目录
+}
+
+func newInteractor(lang *language.Language, dir string) (*interactor, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &interactor{lang: lang, dir: abs}, nil
+}
+
+// runCase 同时运行用户程序和交互器，双方的 CPU 时间分别计算
+func (it *interactor) runCase(lang *language.Language, dir string, index int, input, answer string, request *pb.SubmitRequest) caseResult {
+	args, cleanup, err := it.prepare(index, input, answer)
+	if err != nil {
+		zap.L().Error("judging-interactor-prepare ", zap.Error(err))
+		return caseResult{status: responses.SystemError}
+	}
+	defer cleanup()
+
+	// 交互器 -> 用户程序
+	userIn, toUser, err := os.Pipe()
+	if err != nil {
+		zap.L().Error("judging-interactor-Pipe ", zap.Error(err))
+		return caseResult{status: responses.SystemError}
+	}
+	// 用户程序 -> 交互器
+	interIn, toInter, err := os.Pipe()
+	if err != nil {
+		userIn.Close()
+		toUser.Close()
+		zap.L().Error("judging-interactor-Pipe ", zap.Error(err))
+		return caseResult{status: responses.SystemError}
+	}
+
+	tr := &transcript{limit: transcriptLimit}
+	var stderr, message bytes.Buffer
+	var res, ires *sandbox.Result
+	var runErr, interErr error
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		res, runErr = sandbox.Run(sandbox.Config{
+			Args:        lang.RunCmd,
+			Env:         append(append([]string{}, defaultEnv...), lang.Env...),
+			Dir:         dir,
+			Stdin:       userIn,
+			Stdout:      tr.writer("< ", toInter),
+			Stderr:      &stderr,
+			TimeLimit:   time.Duration(lang.TimeLimit(int64(request.TimeLimit))) * time.Millisecond,
+			MemoryLimit: lang.MemoryLimit(int64(request.MemoryLimit)),
+		})
+		// 用户程序结束后交互器读到 EOF
+		toInter.Close()
+		userIn.Close()
+	}()
+	go func() {
+		defer wg.Done()
+		ires, interErr = sandbox.Run(sandbox.Config{
+			Args:        args,
+			Env:         append(append([]string{}, defaultEnv...), it.lang.Env...),
+			Dir:         it.dir,
+			Stdin:       interIn,
+			Stdout:      tr.writer("> ", toUser),
+			Stderr:      &message,
+			TimeLimit:   interactorTimeLimit,
+			MemoryLimit: interactorMemoryLimit,
+		})
+		// 交互器结束后用户程序读到 EOF
+		toUser.Close()
+		interIn.Close()
+	}()
+	wg.Wait()
+
+	if runErr != nil || interErr != nil {
+		zap.L().Error("judging-interactor-Run ", zap.NamedError("user", runErr), zap.NamedError("interactor", interErr))
+		return caseResult{status: responses.SystemError}
+	}
+
+	cr := caseResult{result: res, interactorTime: ires.Time, transcript: tr.String()}
+	switch res.Status {
+	case sandbox.StatusTimeLimitExceeded:
+		cr.status = responses.TimeLimited
+		return cr
+	case sandbox.StatusMemoryLimitExceeded:
+		cr.status = responses.MemoryLimited
+		return cr
+	}
+
+	if ires.Status != sandbox.StatusOK && ires.Status != sandbox.StatusRuntimeError {
+		res.Error = fmt.Sprintf("interactor %s: %s", ires.Status, ires.Error)
+		cr.status = responses.SystemError
+		return cr
+	}
+	check, err := checker.FromExitCode(ires.ExitCode, checker.Message(message.String()))
+	if err != nil {
+		zap.L().Error("judging-interactor-FromExitCode ", zap.Error(err))
+		res.Error = err.Error()
+		cr.status = responses.SystemError
+		return cr
+	}
+	cr.message = check.Message
+
+	// 交互器判定答案错误时，用户程序因为管道关闭而异常退出不影响结果
+	switch {
+	case !check.Accepted:
+		cr.status = responses.WrongAnswer
+	case res.Status == sandbox.StatusRuntimeError || res.Status == sandbox.StatusOutputLimitExceeded:
+		cr.status = responses.RuntimeError
+		cr.output = truncate(stderr.String(), stderrLimit)
+	case res.Status == sandbox.StatusOK:
+		cr.status = responses.Accepted
+	default:
+		zap.L().Error("judging-interactor-Status ", zap.String("error", res.Error))
+		cr.status = responses.SystemError
+	}
+	return cr
+}
+
+// prepare 写入交互器需要读取的输入和答案文件，返回交互器的运行参数
+func (it *interactor) prepare(index int, input, answer string) ([]string, func(), error) {
+	caseDir := filepath.Join(it.dir, "case-"+strconv.Itoa(index))
+	if err := os.MkdirAll(caseDir, 0755); err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() { _ = os.RemoveAll(caseDir) }
+
+	args := append([]string{}, it.lang.RunCmd...)
+	for _, f := range []struct{ name, content string }{
+		{"input.txt", input},
+		{"answer.txt", answer},
+	} {
+		path := filepath.Join(caseDir, f.name)
+		if err := os.WriteFile(path, []byte(f.content), 0644); err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		args = append(args, path)
+	}
+	return args, cleanup, nil
+}
+
+// transcript 按时间顺序记录交互双方的输出，"> " 为交互器的输出，"< " 为用户程序的输出
+type transcript struct {
+	mu        sync.Mutex
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+	last      *transcriptWriter
+}
+
+func (t *transcript) writer(prefix string, w io.Writer) *transcriptWriter {
+	return &transcriptWriter{t: t, prefix: prefix, w: w, lineStart: true}
+}
+
+func (t *transcript) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.truncated {
+		return t.buf.String() + "\n..."
+	}
+	return t.buf.String()
+}
+
+func (t *transcript) record(w *transcriptWriter, p []byte) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	// 另一方还没有输出完整的一行时先换行
+	if t.last != nil && t.last != w && !t.last.lineStart {
+		t.buf.WriteByte('\n')
+		t.last.lineStart = true
+	}
+	t.last = w
+	for len(p) > 0 {
+		if t.buf.Len() >= t.limit {
+			t.truncated = true
+			return
+		}
+		if w.lineStart {
+			t.buf.WriteString(w.prefix)
+			w.lineStart = false
+		}
+		line := p
+		if i := bytes.IndexByte(p, '\n'); i >= 0 {
+			line = p[:i+1]
+			w.lineStart = true
+		}
+		t.buf.Write(line)
+		p = p[len(line):]
+	}
+}
+
+// transcriptWriter 把一方的输出转发给另一方，同时写入交互记录
+type transcriptWriter struct {
+	t         *transcript
+	prefix    string
+	w         io.Writer
+	lineStart bool
+	broken    bool // 对方已经退出，之后的输出只记录不转发
+}
+
+func (w *transcriptWriter) Write(p []byte) (int, error) {
+	w.t.record(w, p)
+	if !w.broken {
+		if _, err := w.w.Write(p); err != nil {
+			w.broken = true
+		}
+	}
+	// 总是返回成功，让沙箱继续读取输出，避免程序阻塞在写管道上
+	return len(p), nil
+}
@@ -0,0 +1,169 @@
+package judging
+
+import (
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"online_judge/app/judgement/responses"
+	"online_judge/app/judgement/service/judging/checker"
+	"online_judge/app/judgement/service/judging/utility"
+	"online_judge/app/judgement/service/judging/workspace"
+	"online_judge/consts"
+	"online_judge/pkg/language"
+	pb "online_judge/proto"
+)
+
+// Progress 评测进度，Done 和 Total 只在运行测试样例时有效，Group 只在测试组评测结束时有效
+type Progress struct {
+	Stage string
+	Done  int
+	Total int
+	Group *pb.GroupResult
+}
+
+// ProgressFunc 评测进度回调
+type ProgressFunc func(p Progress)
+
+func (f ProgressFunc) report(p Progress) {
+	if f != nil {
+		f(p)
+	}
+}
+
+// Judge 按照语言配置保存、编译并在沙箱中运行用户代码
+// 每次评测使用独立的工作目录，评测结束后删除，progress 可以为 nil
+func Judge(lang *language.Language, request *pb.SubmitRequest, response *pb.SubmitResponse, progress ProgressFunc) (*pb.SubmitResponse, error) {
+	uid := request.UserId
+	response.UserId = uid
+
+	ws, err := workspace.New(request.SubmissionId)
+	if err != nil {
+		response.Status = responses.SystemError
+		return response, err
+	}
+	defer func() {
+		if err := ws.Close(); err != nil {
+			zap.L().Error("judging-Judge-Close ", zap.String("dir", ws.Dir), zap.Error(err))
+		}
+	}()
+
+	err = utility.CodeSave(request.Code, ws.Dir, lang.SourceFile)
+	if err != nil {
+		response.Status = responses.SystemError
+		return response, err
+	}
+
+	if lang.NeedCompile() {
+		progress.report(Progress{Stage: consts.ProgressCompiling})
+		output, err := Compile(lang, ws.Dir)
+		if err != nil && !errors.Is(err, errCompile) {
+			zap.L().Error("judging-Judge-Compile ", zap.Error(err))
+			response.Status = responses.SystemError
+			return response, err
+		}
+		if err != nil {
+			zap.L().Debug("judging-Judge-Compile ", zap.String("submission_id", request.SubmissionId), zap.Error(err))
+			response.Status = responses.CompilerError
+			response.PassNum = 0
+			response.Output = output
+			return response, nil
+		}
+	}
+	// 编译产物超过磁盘配额
+	if err = ws.CheckQuota(); err != nil {
+		response.Status = responses.CompilerError
+		response.PassNum = 0
+		response.Output = err.Error()
+		return response, nil
+	}
+
+	chk, closeChecker, err := newChecker(request.Checker, request.SubmissionId)
+	if err != nil {
+		zap.L().Error("judging-Judge-newChecker ", zap.Error(err))
+		response.Status = responses.SystemError
+		response.Output = err.Error()
+		return response, nil
+	}
+	defer closeChecker()
+
+	it, closeInteractor, err := prepareInteractor(request.Interactor, request.SubmissionId)
+	if err != nil {
+		zap.L().Error("judging-Judge-prepareInteractor ", zap.Error(err))
+		response.Status = responses.SystemError
+		response.Output = err.Error()
+		return response, nil
+	}
+	defer closeInteractor()
+
+	// 在沙箱中运行所有测试样例
+	response = RunTestCases(lang, chk, it, ws.Dir, request, response, progress)
+	zap.L().Debug("judging-Judge-finished ",
+		zap.String("submission_id", request.SubmissionId), zap.Int32("status", response.Status))
+	return response, nil
+}
+
+// newChecker 创建题目的答案检查器，custom 模式需要先编译检查器
+// 检查器放在单独的工作目录中，避免用户程序读取到标准答案
+func newChecker(cfg *pb.Checker, submissionID string) (checker.Checker, func(), error) {
+	if cfg == nil || cfg.Mode != consts.CheckerCustom {
+		return checker.New(cfg), func() {}, nil
+	}
+	lang, ws, closeFn, err := buildProgram("checker", cfg.Code, cfg.Language, submissionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	chk, err := checker.NewCustom(lang, ws.Dir)
+	if err != nil {
+		closeFn()
+		return nil, nil, err
+	}
+	return chk, closeFn, nil
+}
+
+// prepareInteractor 编译交互题的交互器，普通题目返回 nil
+func prepareInteractor(cfg *pb.Interactor, submissionID string) (*interactor, func(), error) {
+	if cfg == nil || cfg.Code == "" {
+		return nil, func() {}, nil
+	}
+	lang, ws, closeFn, err := buildProgram("interactor", cfg.Code, cfg.Language, submissionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	it, err := newInteractor(lang, ws.Dir)
+	if err != nil {
+		closeFn()
+		return nil, nil, err
+	}
+	return it, closeFn, nil
+}
+
+// buildProgram 在单独的工作目录中保存并编译检查器或交互器，返回的函数用于删除工作目录
+func buildProgram(kind, code, langName, submissionID string) (*language.Language, *workspace.Workspace, func(), error) {
+	lang, ok := language.Get(langName)
+	if !ok {
+		return nil, nil, nil, fmt.Errorf("unsupported %s language %q", kind, langName)
+	}
+	ws, err := workspace.New(submissionID + "-" + kind)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	closeFn := func() {
+		if err := ws.Close(); err != nil {
+			zap.L().Error("judging-buildProgram-Close ", zap.String("dir", ws.Dir), zap.Error(err))
+		}
+	}
+	if err = utility.CodeSave(code, ws.Dir, lang.SourceFile); err != nil {
+		closeFn()
+		return nil, nil, nil, err
+	}
+	if lang.NeedCompile() {
+		if output, err := Compile(lang, ws.Dir); err != nil {
+			closeFn()
+			if errors.Is(err, errCompile) {
+				return nil, nil, nil, fmt.Errorf("compile %s: %s", kind, output)
+			}
+			return nil, nil, nil, fmt.Errorf("compile %s: %w", kind, err)
+		}
+	}
+	return lang, ws, closeFn, nil
+}
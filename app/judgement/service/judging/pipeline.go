@@ -0,0 +1,313 @@
+package judging
+
+import (
+	"bytes"
+	"fmt"
+	"go.uber.org/zap"
+	"online_judge/app/judgement/responses"
+	"online_judge/app/judgement/service/judging/checker"
+	"online_judge/app/judgement/service/judging/sandbox"
+	"online_judge/consts"
+	"online_judge/pkg/language"
+	pb "online_judge/proto"
+	"online_judge/setting"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultEnv 用户程序的环境变量
+var defaultEnv = []string{
+	"PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin",
+	"HOME=/tmp",
+	"LANG=C.UTF-8",
+}
+
+// stderrLimit 运行错误时最多返回的标准错误字节数
+const stderrLimit = 1024
+
+// verdictPriority 多个测试样例结果不同时，优先级高的作为最终结果
+// SE > CE > RE > TLE > MLE > WA > AC
+var verdictPriority = map[int32]int{
+	responses.Accepted:      0,
+	responses.WrongAnswer:   1,
+	responses.MemoryLimited: 2,
+	responses.TimeLimited:   3,
+	responses.RuntimeError:  4,
+	responses.CompilerError: 5,
+	responses.SystemError:   6,
+}
+
+// WorseVerdict status 的优先级是否高于 than，用于合并测试组和整个评测的最终结果
+func WorseVerdict(status, than int32) bool {
+	return verdictPriority[status] > verdictPriority[than]
+}
+
+// slots 限制同时在沙箱中运行的测试样例数量，所有评测共享
+var slots = make(chan struct{}, runtime.NumCPU())
+
+// Init 根据配置初始化评测流水线
+func Init(cfg *setting.JudgementConfig) {
+	if cfg != nil && cfg.Parallelism > 0 {
+		slots = make(chan struct{}, cfg.Parallelism)
+	}
+}
+
+// caseResult 单个测试样例的运行结果
+type caseResult struct {
+	status         int32
+	output         string
+	message        string // 检查器或交互器的说明
+	transcript     string // 交互题的交互记录
+	interactorTime int64  // 交互器的 CPU 时间，单位 ms
+	result         *sandbox.Result
+}
+
+// RunTestCases 在沙箱中运行所有测试样例，每个样例单独计算时间和内存限制
+// 最终结果取所有运行过的样例中优先级最高的状态，运行命令中的相对路径以 dir 为工作目录解析
+// it 不为空时按交互题评测，答案由交互器判定
+// 请求中有测试组时按顺序逐组评测，设置了 stop_on_first_failure 的测试组未通过时跳过之后的测试组
+func RunTestCases(lang *language.Language, chk checker.Checker, it *interactor, dir string, request *pb.SubmitRequest, response *pb.SubmitResponse, progress ProgressFunc) *pb.SubmitResponse {
+	input := request.Input
+	expected := request.Expected
+	response.UserId = request.UserId
+
+	// 输入和期望输出按下标配对，数量不一致时不运行任何样例，避免在运行样例的 goroutine 中越界
+	if len(expected) != len(input) {
+		zap.L().Error("judging-RunTestCases-len ", zap.Int("input", len(input)), zap.Int("expected", len(expected)))
+		response.Status = responses.SystemError
+		response.Output = fmt.Sprintf("test case count mismatch: %d inputs, %d expected outputs", len(input), len(expected))
+		return response
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		zap.L().Error("judging-RunTestCases-Abs ", zap.Error(err))
+		response.Status = responses.SystemError
+		return response
+	}
+	groups := request.Groups
+	if len(groups) == 0 {
+		groups = []*pb.TestGroup{allCases(len(input))}
+	} else if err = checkGroups(groups, len(input)); err != nil {
+		zap.L().Error("judging-RunTestCases-checkGroups ", zap.Error(err))
+		response.Status = responses.SystemError
+		response.Output = err.Error()
+		return response
+	}
+
+	results := make([]caseResult, len(input))
+	ran := make([]bool, len(input))
+	var mu sync.Mutex
+	done := 0
+	// runGroup 并发运行测试组中的样例，stop 为 true 时有样例未通过后不再运行还没有开始的样例
+	runGroup := func(cases []int32, stop bool) {
+		var wg sync.WaitGroup
+		var failed atomic.Bool
+		for _, i := range cases {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				slots <- struct{}{}
+				if stop && failed.Load() {
+					<-slots
+					return
+				}
+				if it != nil {
+					results[i] = it.runCase(lang, absDir, i, input[i], expected[i], request)
+				} else {
+					results[i] = runCase(lang, chk, absDir, i, input[i], expected[i], request)
+				}
+				<-slots
+				ran[i] = true
+				if results[i].status != responses.Accepted {
+					failed.Store(true)
+				}
+
+				// 按完成顺序上报进度，保证 done 单调递增
+				mu.Lock()
+				done++
+				progress.report(Progress{Stage: consts.ProgressRunning, Done: done, Total: len(input)})
+				mu.Unlock()
+			}(int(i))
+		}
+		wg.Wait()
+	}
+
+	progress.report(Progress{Stage: consts.ProgressRunning, Total: len(input)})
+	groupResults := make([]*pb.GroupResult, 0, len(groups))
+	stopped := false
+	for _, g := range groups {
+		gr := &pb.GroupResult{Name: g.Name, TotalNum: int32(len(g.Cases))}
+		if stopped {
+			gr.Skipped = true
+		} else {
+			runGroup(g.Cases, g.StopOnFirstFailure)
+			gr.Status = responses.Accepted
+			for _, i := range g.Cases {
+				if !ran[i] {
+					continue
+				}
+				res := results[i]
+				if res.status == responses.Accepted {
+					gr.PassNum++
+				}
+				if WorseVerdict(res.status, gr.Status) {
+					gr.Status = res.status
+				}
+				if res.result != nil {
+					gr.Runtime = max(gr.Runtime, int32(res.result.Time))
+					gr.MemoryUsage = max(gr.MemoryUsage, int32(res.result.Memory))
+				}
+			}
+			stopped = g.StopOnFirstFailure && gr.Status != responses.Accepted
+		}
+		groupResults = append(groupResults, gr)
+		if len(request.Groups) > 0 {
+			progress.report(Progress{Stage: consts.ProgressGroupFinished, Group: gr})
+		}
+	}
+	if len(request.Groups) > 0 {
+		response.Groups = groupResults
+	}
+
+	response.Status = responses.Accepted
+	response.PassNum = 0
+	response.Cases = make([]*pb.CaseResult, 0, len(results))
+	// 输出信息取第一个与最终结果相同的样例
+	detail := -1
+	for i, res := range results {
+		if !ran[i] {
+			continue
+		}
+		cr := &pb.CaseResult{Index: int32(i), Status: res.status, InteractorRuntime: int32(res.interactorTime)}
+		if res.result != nil {
+			cr.Runtime = int32(res.result.Time)
+			cr.MemoryUsage = int32(res.result.Memory)
+			response.Runtime = max(response.Runtime, cr.Runtime)
+			response.MemoryUsage = max(response.MemoryUsage, cr.MemoryUsage)
+		}
+		response.Cases = append(response.Cases, cr)
+
+		if res.status == responses.Accepted {
+			response.PassNum++
+		}
+		if WorseVerdict(res.status, response.Status) {
+			response.Status = res.status
+			detail = i
+		}
+	}
+
+	// 只有公开样例返回输入、期望输出和标准错误，隐藏的测试数据只返回编号和检查器信息，避免通过错误信息逐个泄露
+	sample := detail >= 0 && detail < len(request.IsSample) && request.IsSample[detail]
+	if detail >= 0 {
+		res := results[detail]
+		switch {
+		case res.status == responses.WrongAnswer && !sample:
+			response.Output = fmt.Sprintf("Test: #%d", detail+1)
+			if res.message != "" {
+				response.Output += "\nChecker: " + res.message
+			}
+		case res.status == responses.RuntimeError && !sample:
+			// 标准错误由用户程序控制，可能包含读到的测试数据
+			response.Output = fmt.Sprintf("Test: #%d", detail+1)
+			if res.result != nil && res.result.Error != "" {
+				response.Output += "\n" + res.result.Error
+			}
+		case res.status == responses.WrongAnswer && it != nil:
+			response.Output = fmt.Sprintf("Intput: %s\nInteractor: %s", input[detail], res.message)
+		case res.status == responses.WrongAnswer:
+			response.Output = fmt.Sprintf("Intput: %s\nExpected: %s\nOutput: %s", input[detail], expected[detail], res.output)
+			if res.message != "" {
+				response.Output += "\nChecker: " + res.message
+			}
+		case res.result != nil && res.result.Error != "":
+			response.Output = res.result.Error
+		case res.status == responses.RuntimeError:
+			response.Output = res.output
+		}
+	}
+	// 交互题附带交互记录，全部通过时取第一个样例，交互记录包含测试数据，同样只对公开样例返回
+	if detail < 0 {
+		sample = len(request.IsSample) > 0 && request.IsSample[0]
+	}
+	if it != nil && len(results) > 0 && sample {
+		if tr := results[max(detail, 0)].transcript; tr != "" {
+			response.Output = strings.TrimPrefix(response.Output+"\nTranscript:\n"+tr, "\n")
+		}
+	}
+	return response
+}
+
+func runCase(lang *language.Language, chk checker.Checker, dir string, index int, input, expected string, request *pb.SubmitRequest) caseResult {
+	var stdout, stderr bytes.Buffer
+	res, err := sandbox.Run(sandbox.Config{
+		Args:        lang.RunCmd,
+		Env:         append(append([]string{}, defaultEnv...), lang.Env...),
+		Dir:         dir,
+		Stdin:       strings.NewReader(input + "\n"),
+		Stdout:      &stdout,
+		Stderr:      &stderr,
+		TimeLimit:   time.Duration(lang.TimeLimit(int64(request.TimeLimit))) * time.Millisecond,
+		MemoryLimit: lang.MemoryLimit(int64(request.MemoryLimit)),
+	})
+	if err != nil {
+		zap.L().Error("judging-runCase-Run ", zap.Error(err))
+		return caseResult{status: responses.SystemError}
+	}
+
+	switch res.Status {
+	case sandbox.StatusOK:
+		check, err := chk.Check(index, input, stdout.String(), expected)
+		if err != nil {
+			zap.L().Error("judging-runCase-Check ", zap.Error(err))
+			res.Error = err.Error()
+			return caseResult{status: responses.SystemError, result: res}
+		}
+		if !check.Accepted {
+			return caseResult{status: responses.WrongAnswer, output: stdout.String(), message: check.Message, result: res}
+		}
+		return caseResult{status: responses.Accepted, result: res}
+	case sandbox.StatusTimeLimitExceeded:
+		return caseResult{status: responses.TimeLimited, result: res}
+	case sandbox.StatusMemoryLimitExceeded:
+		return caseResult{status: responses.MemoryLimited, result: res}
+	case sandbox.StatusRuntimeError, sandbox.StatusOutputLimitExceeded:
+		return caseResult{status: responses.RuntimeError, output: truncate(stderr.String(), stderrLimit), result: res}
+	default:
+		zap.L().Error("judging-runCase-Status ", zap.String("error", res.Error))
+		return caseResult{status: responses.SystemError, result: res}
+	}
+}
+
+// allCases 没有指定测试组时，所有测试样例作为一组
+func allCases(n int) *pb.TestGroup {
+	g := &pb.TestGroup{Cases: make([]int32, n)}
+	for i := range g.Cases {
+		g.Cases[i] = int32(i)
+	}
+	return g
+}
+
+// checkGroups 检查测试组中的下标有效，并且每个测试样例最多属于一个测试组
+func checkGroups(groups []*pb.TestGroup, n int) error {
+	seen := make([]bool, n)
+	for _, g := range groups {
+		for _, i := range g.Cases {
+			if i < 0 || int(i) >= n || seen[i] {
+				return fmt.Errorf("invalid test case %d in group %q", i, g.Name)
+			}
+			seen[i] = true
+		}
+	}
+	return nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
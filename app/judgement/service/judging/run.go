@@ -0,0 +1,101 @@
+package judging
+
+import (
+	"bytes"
+	"errors"
+	"go.uber.org/zap"
+	"online_judge/app/judgement/responses"
+	"online_judge/app/judgement/service/judging/sandbox"
+	"online_judge/app/judgement/service/judging/utility"
+	"online_judge/app/judgement/service/judging/workspace"
+	"online_judge/pkg/language"
+	pb "online_judge/proto"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// runOutputLimit 自定义输入运行时最多返回的标准输出和标准错误字节数
+const runOutputLimit = 64 * 1024
+
+// Run 编译用户代码并在沙箱中使用自定义输入运行一次，不检查答案
+// 程序正常退出时状态为 Accepted，非零退出码和被信号杀死都视为 RuntimeError
+func Run(lang *language.Language, request *pb.RunRequest, response *pb.RunResponse) error {
+	ws, err := workspace.New(request.RunId)
+	if err != nil {
+		response.Status = responses.SystemError
+		return err
+	}
+	defer func() {
+		if err := ws.Close(); err != nil {
+			zap.L().Error("judging-Run-Close ", zap.String("dir", ws.Dir), zap.Error(err))
+		}
+	}()
+
+	if err = utility.CodeSave(request.Code, ws.Dir, lang.SourceFile); err != nil {
+		response.Status = responses.SystemError
+		return err
+	}
+	if lang.NeedCompile() {
+		output, err := Compile(lang, ws.Dir)
+		if err != nil && !errors.Is(err, errCompile) {
+			response.Status = responses.SystemError
+			return err
+		}
+		if err != nil {
+			response.Status = responses.CompilerError
+			response.Output = output
+			return nil
+		}
+	}
+	if err = ws.CheckQuota(); err != nil {
+		response.Status = responses.CompilerError
+		response.Output = err.Error()
+		return nil
+	}
+
+	absDir, err := filepath.Abs(ws.Dir)
+	if err != nil {
+		response.Status = responses.SystemError
+		return err
+	}
+	var stdout, stderr bytes.Buffer
+	// 和评测共享同时运行的程序数量限制
+	slots <- struct{}{}
+	res, err := sandbox.Run(sandbox.Config{
+		Args:        lang.RunCmd,
+		Env:         append(append([]string{}, defaultEnv...), lang.Env...),
+		Dir:         absDir,
+		Stdin:       strings.NewReader(request.Input),
+		Stdout:      &stdout,
+		Stderr:      &stderr,
+		TimeLimit:   time.Duration(lang.TimeLimit(int64(request.TimeLimit))) * time.Millisecond,
+		MemoryLimit: lang.MemoryLimit(int64(request.MemoryLimit)),
+	})
+	<-slots
+	if err != nil {
+		response.Status = responses.SystemError
+		return err
+	}
+
+	response.Stdout = truncate(stdout.String(), runOutputLimit)
+	response.Stderr = truncate(stderr.String(), runOutputLimit)
+	response.ExitCode = int32(res.ExitCode)
+	response.Runtime = int32(res.Time)
+	response.MemoryUsage = int32(res.Memory)
+	response.Output = res.Error
+	switch res.Status {
+	case sandbox.StatusOK:
+		response.Status = responses.Accepted
+	case sandbox.StatusTimeLimitExceeded:
+		response.Status = responses.TimeLimited
+	case sandbox.StatusMemoryLimitExceeded:
+		response.Status = responses.MemoryLimited
+	case sandbox.StatusRuntimeError, sandbox.StatusOutputLimitExceeded:
+		response.Status = responses.RuntimeError
+	default:
+		zap.L().Error("judging-Run-Status ", zap.String("error", res.Error))
+		response.Status = responses.SystemError
+	}
+	return nil
+}
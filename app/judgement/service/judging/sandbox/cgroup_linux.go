@@ -0,0 +1,121 @@
+package sandbox
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// cgroup 一次运行使用的 cgroup v2 叶子节点
+type cgroup struct {
+	path string
+	dir  *os.File
+}
+
+// cgroupStat 运行结束后从 cgroup 读取的资源统计
+type cgroupStat struct {
+	cpuUsec    int64 // cpu.stat usage_usec
+	memoryPeak int64 // memory.peak，单位 byte
+	oomKill    int64 // memory.events oom_kill
+}
+
+// setupCgroupRoot 创建沙箱的 cgroup 根节点并开启 memory、pids 控制器
+func setupCgroupRoot(root string) error {
+	if _, err := os.Stat("/sys/fs/cgroup/cgroup.controllers"); err != nil {
+		return fmt.Errorf("cgroup v2 is not mounted: %w", err)
+	}
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(root, "cgroup.subtree_control"), []byte("+memory +pids"), 0644)
+}
+
+// newCgroup 为一次运行创建 cgroup，memoryLimit 单位 KB
+func newCgroup(root, name string, memoryLimit int64, processLimit int) (*cgroup, error) {
+	path := filepath.Join(root, name)
+	if err := os.Mkdir(path, 0755); err != nil {
+		return nil, err
+	}
+	cg := &cgroup{path: path}
+	settings := map[string]string{
+		"memory.swap.max": "0",
+	}
+	if memoryLimit > 0 {
+		settings["memory.max"] = strconv.FormatInt(memoryLimit*1024, 10)
+	}
+	if processLimit > 0 {
+		settings["pids.max"] = strconv.Itoa(processLimit)
+	}
+	for file, value := range settings {
+		if err := os.WriteFile(filepath.Join(path, file), []byte(value), 0644); err != nil && !os.IsNotExist(err) {
+			cg.remove()
+			return nil, fmt.Errorf("write %s: %w", file, err)
+		}
+	}
+	dir, err := os.Open(path)
+	if err != nil {
+		cg.remove()
+		return nil, err
+	}
+	cg.dir = dir
+	return cg, nil
+}
+
+func (c *cgroup) fd() int {
+	return int(c.dir.Fd())
+}
+
+// kill 杀死 cgroup 中的所有进程 (Linux 5.14+)
+func (c *cgroup) kill() {
+	_ = os.WriteFile(filepath.Join(c.path, "cgroup.kill"), []byte("1"), 0644)
+}
+
+func (c *cgroup) stat() (s cgroupStat) {
+	s.cpuUsec = readKeyedValue(filepath.Join(c.path, "cpu.stat"), "usage_usec")
+	s.oomKill = readKeyedValue(filepath.Join(c.path, "memory.events"), "oom_kill")
+	if data, err := os.ReadFile(filepath.Join(c.path, "memory.peak")); err == nil {
+		s.memoryPeak, _ = strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	}
+	return
+}
+
+// remove 删除 cgroup，进程退出后 cgroup 可能还没有被内核释放，需要重试
+func (c *cgroup) remove() {
+	if c.dir != nil {
+		_ = c.dir.Close()
+	}
+	c.kill()
+	for i := 0; i < 100; i++ {
+		err := syscall.Rmdir(c.path)
+		if err == nil || os.IsNotExist(err) {
+			return
+		}
+		if err != syscall.EBUSY {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// readKeyedValue 读取 "key value" 格式文件中的某个值
+func readKeyedValue(file, key string) int64 {
+	f, err := os.Open(file)
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[0] == key {
+			v, _ := strconv.ParseInt(fields[1], 10, 64)
+			return v
+		}
+	}
+	return 0
+}
@@ -0,0 +1,167 @@
+package sandbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"golang.org/x/sys/unix"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"syscall"
+)
+
+const (
+	// initArg 沙箱子进程的 argv[0]，用于识别以 init 身份启动的进程
+	initArg = "online-judge-sandbox-init"
+	// specEnv 父进程通过这个环境变量把运行参数传给子进程
+	specEnv = "ONLINE_JUDGE_SANDBOX_SPEC"
+	// errFd 子进程初始化失败时写入错误信息的文件描述符，对应 ExtraFiles[0]
+	errFd = 3
+)
+
+// writableDirs 沙箱中挂载私有 tmpfs 的目录
+var writableDirs = []string{"/tmp", "/var/tmp", "/dev/shm"}
+
+// childSpec 父进程传递给沙箱子进程的参数
+type childSpec struct {
+	Args         []string `json:"args"`
+	Env          []string `json:"env"`
+	Dir          string   `json:"dir"`
+	CPUSeconds   uint64   `json:"cpu_seconds"`
+	FileSize     uint64   `json:"file_size"`
+	ProcessLimit uint64   `json:"process_limit"`
+	UID          int      `json:"uid"`
+	GID          int      `json:"gid"`
+	Namespace    bool     `json:"namespace"`
+	TmpfsSize    int      `json:"tmpfs_size"`
+	Seccomp      bool     `json:"seccomp"`
+	WritableDir  bool     `json:"writable_dir"`
+}
+
+// ChildInit 如果当前进程是沙箱子进程，则完成隔离设置并 exec 用户程序，不会返回
+// 必须在 main 函数的最开始调用
+func ChildInit() {
+	if len(os.Args) == 0 || os.Args[0] != initArg {
+		return
+	}
+	// seccomp 和 exec 需要在同一个线程上完成
+	runtime.LockOSThread()
+
+	var spec childSpec
+	if err := json.Unmarshal([]byte(os.Getenv(specEnv)), &spec); err != nil {
+		childFail("decode spec", err)
+	}
+	if err := spec.apply(); err != nil {
+		childFail("setup", err)
+	}
+	// 错误管道在 exec 成功后自动关闭，父进程据此判断初始化成功
+	syscall.CloseOnExec(errFd)
+
+	path, err := lookPath(spec.Args[0], spec.Env)
+	if err != nil {
+		childFail("look path", err)
+	}
+	err = syscall.Exec(path, spec.Args, spec.Env)
+	childFail("exec", err)
+}
+
+func (s *childSpec) apply() error {
+	if s.Namespace {
+		// 挂载事件不传播回宿主机
+		if err := unix.Mount("", "/", "", unix.MS_REC|unix.MS_PRIVATE, ""); err != nil {
+			return fmt.Errorf("make mount private: %w", err)
+		}
+		// 工作目录只读，用户程序不能修改代码和可执行文件，也不能往宿主机磁盘写文件
+		if s.Dir != "" && !s.WritableDir {
+			if err := unix.Mount(s.Dir, s.Dir, "", unix.MS_BIND|unix.MS_REC, ""); err != nil {
+				return fmt.Errorf("bind work dir: %w", err)
+			}
+			flags := uintptr(unix.MS_BIND | unix.MS_REMOUNT | unix.MS_RDONLY | unix.MS_NOSUID | unix.MS_NODEV)
+			if err := unix.Mount("", s.Dir, "", flags, ""); err != nil {
+				return fmt.Errorf("remount work dir read-only: %w", err)
+			}
+		}
+		// 所有人可写的目录替换为私有的 tmpfs，限制临时文件大小
+		data := fmt.Sprintf("size=%dm,mode=1777", s.TmpfsSize)
+		for _, dir := range writableDirs {
+			if _, err := os.Stat(dir); err != nil {
+				continue
+			}
+			if err := unix.Mount("tmpfs", dir, "tmpfs", unix.MS_NOSUID|unix.MS_NODEV, data); err != nil {
+				return fmt.Errorf("mount tmpfs on %s: %w", dir, err)
+			}
+		}
+		// 新的 pid namespace 需要重新挂载 proc
+		if err := unix.Mount("proc", "/proc", "proc", unix.MS_NOSUID|unix.MS_NODEV|unix.MS_NOEXEC, ""); err != nil {
+			return fmt.Errorf("mount proc: %w", err)
+		}
+	}
+	if s.Dir != "" {
+		if err := os.Chdir(s.Dir); err != nil {
+			return err
+		}
+	}
+
+	limits := []struct {
+		resource int
+		value    uint64
+	}{
+		{unix.RLIMIT_CPU, s.CPUSeconds},
+		{unix.RLIMIT_FSIZE, s.FileSize},
+		{unix.RLIMIT_NPROC, s.ProcessLimit},
+		{unix.RLIMIT_CORE, 0},
+		{unix.RLIMIT_STACK, unix.RLIM_INFINITY},
+	}
+	for _, l := range limits {
+		if l.value == 0 && l.resource != unix.RLIMIT_CORE {
+			continue
+		}
+		rl := unix.Rlimit{Cur: l.value, Max: l.value}
+		if err := unix.Setrlimit(l.resource, &rl); err != nil {
+			return fmt.Errorf("setrlimit %d: %w", l.resource, err)
+		}
+	}
+
+	// 以 root 启动时降权到普通用户
+	if os.Getuid() == 0 && s.UID > 0 {
+		if err := syscall.Setgroups(nil); err != nil {
+			return fmt.Errorf("setgroups: %w", err)
+		}
+		if err := syscall.Setgid(s.GID); err != nil {
+			return fmt.Errorf("setgid: %w", err)
+		}
+		if err := syscall.Setuid(s.UID); err != nil {
+			return fmt.Errorf("setuid: %w", err)
+		}
+	}
+
+	if err := unix.Prctl(unix.PR_SET_NO_NEW_PRIVS, 1, 0, 0, 0); err != nil {
+		return fmt.Errorf("no new privs: %w", err)
+	}
+	if s.Seccomp {
+		if err := loadSeccomp(); err != nil {
+			return fmt.Errorf("seccomp: %w", err)
+		}
+	}
+	return nil
+}
+
+// lookPath 使用用户程序的 PATH 查找可执行文件
+func lookPath(file string, env []string) (string, error) {
+	if strings.Contains(file, "/") {
+		return file, nil
+	}
+	for _, kv := range env {
+		if strings.HasPrefix(kv, "PATH=") {
+			_ = os.Setenv("PATH", strings.TrimPrefix(kv, "PATH="))
+		}
+	}
+	return exec.LookPath(file)
+}
+
+func childFail(stage string, err error) {
+	f := os.NewFile(errFd, "sandbox-error")
+	_, _ = fmt.Fprintf(f, "sandbox %s: %v", stage, err)
+	os.Exit(1)
+}
@@ -0,0 +1,84 @@
+package sandbox
+
+import (
+	"errors"
+	"io"
+	"online_judge/setting"
+	"time"
+)
+
+// Status 沙箱中一次运行的结果
+type Status int
+
+const (
+	StatusOK Status = iota
+	StatusTimeLimitExceeded
+	StatusMemoryLimitExceeded
+	StatusOutputLimitExceeded
+	StatusRuntimeError
+	StatusSystemError
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusOK:
+		return "ok"
+	case StatusTimeLimitExceeded:
+		return "time limit exceeded"
+	case StatusMemoryLimitExceeded:
+		return "memory limit exceeded"
+	case StatusOutputLimitExceeded:
+		return "output limit exceeded"
+	case StatusRuntimeError:
+		return "runtime error"
+	default:
+		return "system error"
+	}
+}
+
+// ErrUnsupported 当前平台不支持沙箱
+var ErrUnsupported = errors.New("sandbox is only supported on linux")
+
+// Config 单次运行的参数
+type Config struct {
+	Args        []string      // 可执行文件及参数，Args[0] 会在 PATH 中查找
+	Env         []string      // 用户程序的环境变量
+	Dir         string        // 工作目录
+	Stdin       io.Reader     // 标准输入
+	Stdout      io.Writer     // 标准输出
+	Stderr      io.Writer     // 标准错误
+	TimeLimit   time.Duration // CPU 时间限制
+	MemoryLimit int64         // 内存限制，单位 KB
+	OutputLimit int64         // 标准输出和标准错误各自的字节数限制，为 0 时使用配置文件中的 output_limit
+	WritableDir bool          // 工作目录可写并在运行期间属于沙箱用户，用于编译
+}
+
+// Result 单次运行的资源占用和结果
+type Result struct {
+	Status   Status
+	ExitCode int    // 进程退出码，被信号杀死时为 -1
+	Signal   string // 杀死进程的信号
+	Time     int64  // CPU 时间，单位 ms
+	WallTime int64  // 墙上时间，单位 ms
+	Memory   int64  // 峰值内存，单位 KB
+	Error    string // 系统错误或违规系统调用的说明
+}
+
+// options 由配置文件初始化的全局沙箱参数
+var options = setting.SandboxConfig{
+	Namespace:    true,
+	Seccomp:      true,
+	UID:          65534,
+	GID:          65534,
+	TmpfsSize:    64,
+	OutputLimit:  64 * 1024,
+	ProcessLimit: 64,
+}
+
+// wallTimeLimit 墙上时间限制，防止用户程序 sleep 或阻塞在输入上
+func wallTimeLimit(cpu time.Duration) time.Duration {
+	if cpu*2 > cpu+time.Second {
+		return cpu * 2
+	}
+	return cpu + time.Second
+}
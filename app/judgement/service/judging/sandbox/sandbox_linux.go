@@ -0,0 +1,244 @@
+package sandbox
+
+import (
+	"encoding/json"
+	"errors"
+	"go.uber.org/zap"
+	"io"
+	"io/fs"
+	"online_judge/pkg/utils"
+	"online_judge/setting"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// Init 根据配置初始化沙箱，cgroup 不可用时退化为只使用 rlimit
+func Init(cfg *setting.SandboxConfig) error {
+	if cfg == nil {
+		return nil
+	}
+	options = *cfg
+	if options.CgroupRoot == "" {
+		return nil
+	}
+	if err := setupCgroupRoot(options.CgroupRoot); err != nil {
+		zap.L().Warn("sandbox-Init cgroup unavailable, fall back to rlimit", zap.Error(err))
+		options.CgroupRoot = ""
+	}
+	return nil
+}
+
+// Run 在沙箱中运行用户程序，阻塞直到程序结束
+func Run(cfg Config) (*Result, error) {
+	spec := childSpec{
+		Args:        cfg.Args,
+		Env:         cfg.Env,
+		Dir:         cfg.Dir,
+		CPUSeconds:  uint64((cfg.TimeLimit+time.Second-1)/time.Second) + 1,
+		FileSize:    uint64(options.OutputLimit) * 1024,
+		UID:         options.UID,
+		GID:         options.GID,
+		Namespace:   options.Namespace,
+		TmpfsSize:   options.TmpfsSize,
+		Seccomp:     options.Seccomp,
+		WritableDir: cfg.WritableDir,
+	}
+	// RLIMIT_NPROC 按用户统计，会影响同时运行的其他程序，只在没有 cgroup 时使用
+	if options.CgroupRoot == "" {
+		spec.ProcessLimit = uint64(options.ProcessLimit)
+	}
+	specData, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	// 子进程初始化失败时通过这个管道回传错误信息
+	errRead, errWrite, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	defer errRead.Close()
+
+	limit := cfg.OutputLimit
+	if limit <= 0 {
+		limit = int64(options.OutputLimit) * 1024
+	}
+	stdout := newLimitWriter(cfg.Stdout, limit)
+	// 管道不受 RLIMIT_FSIZE 限制，标准错误同样需要截断，同一个 writer 共用计数避免并发写入
+	stderr := stdout
+	if !sameWriter(cfg.Stdout, cfg.Stderr) {
+		stderr = newLimitWriter(cfg.Stderr, limit)
+	}
+
+	// 编译时把工作目录交给沙箱用户，结束后收回目录和新生成文件的所有权
+	if cfg.WritableDir && cfg.Dir != "" && os.Getuid() == 0 && options.UID > 0 {
+		if err = os.Chown(cfg.Dir, options.UID, options.GID); err != nil {
+			errWrite.Close()
+			return nil, err
+		}
+		defer reclaimDir(cfg.Dir)
+	}
+
+	cmd := exec.Command("/proc/self/exe")
+	cmd.Args = []string{initArg}
+	cmd.Env = []string{specEnv + "=" + string(specData)}
+	cmd.Stdin = cfg.Stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.ExtraFiles = []*os.File{errWrite}
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setpgid:   true,
+		Pdeathsig: syscall.SIGKILL,
+	}
+	if options.Namespace {
+		cmd.SysProcAttr.Cloneflags = syscall.CLONE_NEWNS | syscall.CLONE_NEWPID | syscall.CLONE_NEWNET |
+			syscall.CLONE_NEWIPC | syscall.CLONE_NEWUTS
+	}
+
+	var cg *cgroup
+	if options.CgroupRoot != "" {
+		cg, err = newCgroup(options.CgroupRoot, utils.GetUUID(), cfg.MemoryLimit, options.ProcessLimit)
+		if err != nil {
+			errWrite.Close()
+			return nil, err
+		}
+		defer cg.remove()
+		cmd.SysProcAttr.UseCgroupFD = true
+		cmd.SysProcAttr.CgroupFD = cg.fd()
+	}
+
+	start := time.Now()
+	if err = cmd.Start(); err != nil {
+		errWrite.Close()
+		return nil, err
+	}
+	errWrite.Close()
+
+	// 墙上时间超时后杀死整个进程组
+	var wallTimeout atomic.Bool
+	timer := time.AfterFunc(wallTimeLimit(cfg.TimeLimit), func() {
+		wallTimeout.Store(true)
+		_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		if cg != nil {
+			cg.kill()
+		}
+	})
+
+	initErr, _ := io.ReadAll(errRead)
+	waitErr := cmd.Wait()
+	timer.Stop()
+	// 确保残留的子进程被清理
+	_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+
+	res := &Result{WallTime: time.Since(start).Milliseconds(), ExitCode: -1}
+	if len(initErr) > 0 {
+		res.Status = StatusSystemError
+		res.Error = string(initErr)
+		return res, nil
+	}
+	if waitErr != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(waitErr, &exitErr) {
+			return nil, waitErr
+		}
+	}
+
+	state := cmd.ProcessState
+	if ru, ok := state.SysUsage().(*syscall.Rusage); ok {
+		res.Time = (time.Duration(ru.Utime.Nano()) + time.Duration(ru.Stime.Nano())).Milliseconds()
+		res.Memory = ru.Maxrss
+	}
+	var oomKilled bool
+	if cg != nil {
+		stat := cg.stat()
+		if stat.cpuUsec > 0 {
+			res.Time = stat.cpuUsec / 1000
+		}
+		if stat.memoryPeak > 0 {
+			res.Memory = stat.memoryPeak / 1024
+		}
+		oomKilled = stat.oomKill > 0
+	}
+
+	ws := state.Sys().(syscall.WaitStatus)
+	if ws.Exited() {
+		res.ExitCode = ws.ExitStatus()
+	}
+	if ws.Signaled() {
+		res.Signal = ws.Signal().String()
+	}
+
+	switch {
+	case wallTimeout.Load() || res.Time > cfg.TimeLimit.Milliseconds() ||
+		(ws.Signaled() && ws.Signal() == syscall.SIGXCPU):
+		res.Status = StatusTimeLimitExceeded
+	case oomKilled || (cfg.MemoryLimit > 0 && res.Memory > cfg.MemoryLimit):
+		res.Status = StatusMemoryLimitExceeded
+	case stdout.exceeded || (ws.Signaled() && ws.Signal() == syscall.SIGXFSZ):
+		res.Status = StatusOutputLimitExceeded
+	case ws.Signaled() && ws.Signal() == syscall.SIGSYS:
+		res.Status = StatusRuntimeError
+		res.Error = "forbidden system call"
+	case ws.Signaled() || res.ExitCode != 0:
+		res.Status = StatusRuntimeError
+	default:
+		res.Status = StatusOK
+	}
+	return res, nil
+}
+
+// reclaimDir 把目录和其中的文件交还给评测服务的用户
+func reclaimDir(dir string) {
+	uid, gid := os.Getuid(), os.Getgid()
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		return os.Lchown(path, uid, gid)
+	})
+	if err != nil {
+		zap.L().Error("sandbox-reclaimDir ", zap.String("dir", dir), zap.Error(err))
+	}
+}
+
+// sameWriter 和 os/exec 相同的判断方式，nil 和不可比较的类型视为不同
+func sameWriter(a, b io.Writer) (same bool) {
+	defer func() {
+		if recover() != nil {
+			same = false
+		}
+	}()
+	return a != nil && a == b
+}
+
+// limitWriter 超过输出限制后丢弃多余的输出
+type limitWriter struct {
+	w        io.Writer
+	limit    int64
+	written  int64
+	exceeded bool
+}
+
+func newLimitWriter(w io.Writer, limit int64) *limitWriter {
+	if w == nil {
+		w = io.Discard
+	}
+	return &limitWriter{w: w, limit: limit}
+}
+
+func (l *limitWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	if l.limit > 0 && l.written+int64(n) > l.limit {
+		l.exceeded = true
+		p = p[:max(l.limit-l.written, 0)]
+	}
+	l.written += int64(len(p))
+	if _, err := l.w.Write(p); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
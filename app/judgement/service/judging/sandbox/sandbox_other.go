@@ -0,0 +1,18 @@
+//go:build !linux
+
+package sandbox
+
+import "online_judge/setting"
+
+// Init 非 Linux 平台没有沙箱实现
+func Init(cfg *setting.SandboxConfig) error {
+	return ErrUnsupported
+}
+
+// ChildInit 非 Linux 平台没有沙箱子进程
+func ChildInit() {}
+
+// Run 非 Linux 平台没有沙箱实现
+func Run(cfg Config) (*Result, error) {
+	return nil, ErrUnsupported
+}
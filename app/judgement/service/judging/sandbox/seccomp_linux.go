@@ -0,0 +1,93 @@
+package sandbox
+
+import (
+	"errors"
+	"golang.org/x/sys/unix"
+	"runtime"
+	"unsafe"
+)
+
+// refusedSyscalls 返回 EPERM 的系统调用
+// 网络已经被 net namespace 隔离，部分运行时启动时会探测网络，不直接杀死进程
+var refusedSyscalls = []uintptr{
+	unix.SYS_SOCKET, unix.SYS_SOCKETPAIR, unix.SYS_CONNECT, unix.SYS_BIND,
+	unix.SYS_LISTEN, unix.SYS_ACCEPT, unix.SYS_ACCEPT4,
+}
+
+// deniedSyscalls 用户程序禁止使用的系统调用，命中后直接杀死进程 (SIGSYS)
+// 采用黑名单而不是白名单，保证 JVM、Go runtime、CPython 等运行时可以正常启动
+var deniedSyscalls = []uintptr{
+	// 调试和跨进程访问
+	unix.SYS_PTRACE, unix.SYS_PROCESS_VM_READV, unix.SYS_PROCESS_VM_WRITEV, unix.SYS_KCMP,
+	// 文件系统和 namespace
+	unix.SYS_MOUNT, unix.SYS_UMOUNT2, unix.SYS_PIVOT_ROOT, unix.SYS_CHROOT,
+	unix.SYS_SETNS, unix.SYS_UNSHARE, unix.SYS_OPEN_BY_HANDLE_AT, unix.SYS_NAME_TO_HANDLE_AT,
+	unix.SYS_FANOTIFY_INIT, unix.SYS_QUOTACTL, unix.SYS_SWAPON, unix.SYS_SWAPOFF,
+	// 内核和系统管理
+	unix.SYS_REBOOT, unix.SYS_KEXEC_LOAD, unix.SYS_INIT_MODULE, unix.SYS_FINIT_MODULE,
+	unix.SYS_DELETE_MODULE, unix.SYS_BPF, unix.SYS_PERF_EVENT_OPEN, unix.SYS_USERFAULTFD,
+	unix.SYS_KEYCTL, unix.SYS_ADD_KEY, unix.SYS_REQUEST_KEY, unix.SYS_ACCT,
+	unix.SYS_SETHOSTNAME, unix.SYS_SETDOMAINNAME, unix.SYS_SETTIMEOFDAY, unix.SYS_CLOCK_SETTIME,
+	unix.SYS_VHANGUP,
+}
+
+// auditArch 当前架构在 seccomp_data.arch 中的值
+var auditArch = map[string]uint32{
+	"amd64": unix.AUDIT_ARCH_X86_64,
+	"arm64": unix.AUDIT_ARCH_AARCH64,
+}
+
+// x32SyscallBit x86_64 上 x32 ABI 的系统调用号标记，需要一并拒绝以免绕过过滤
+const x32SyscallBit = 0x40000000
+
+// loadSeccomp 为当前线程安装 seccomp 过滤器，exec 之后对用户程序生效
+func loadSeccomp() error {
+	arch, ok := auditArch[runtime.GOARCH]
+	if !ok {
+		return errors.New("unsupported architecture " + runtime.GOARCH)
+	}
+
+	stmt := func(code uint16, k uint32) unix.SockFilter {
+		return unix.SockFilter{Code: code, K: k}
+	}
+	jump := func(code uint16, k uint32, jt, jf uint8) unix.SockFilter {
+		return unix.SockFilter{Code: code, Jt: jt, Jf: jf, K: k}
+	}
+	const (
+		offsetNr   = 0 // offsetof(struct seccomp_data, nr)
+		offsetArch = 4 // offsetof(struct seccomp_data, arch)
+	)
+
+	filter := []unix.SockFilter{
+		// 架构不一致直接杀死
+		stmt(unix.BPF_LD|unix.BPF_W|unix.BPF_ABS, offsetArch),
+		jump(unix.BPF_JMP|unix.BPF_JEQ|unix.BPF_K, arch, 1, 0),
+		stmt(unix.BPF_RET|unix.BPF_K, unix.SECCOMP_RET_KILL_PROCESS),
+		stmt(unix.BPF_LD|unix.BPF_W|unix.BPF_ABS, offsetNr),
+	}
+	if runtime.GOARCH == "amd64" {
+		filter = append(filter,
+			jump(unix.BPF_JMP|unix.BPF_JGE|unix.BPF_K, x32SyscallBit, 0, 1),
+			stmt(unix.BPF_RET|unix.BPF_K, unix.SECCOMP_RET_KILL_PROCESS),
+		)
+	}
+	for _, nr := range refusedSyscalls {
+		filter = append(filter,
+			jump(unix.BPF_JMP|unix.BPF_JEQ|unix.BPF_K, uint32(nr), 0, 1),
+			stmt(unix.BPF_RET|unix.BPF_K, unix.SECCOMP_RET_ERRNO|uint32(unix.EPERM)),
+		)
+	}
+	for _, nr := range deniedSyscalls {
+		filter = append(filter,
+			jump(unix.BPF_JMP|unix.BPF_JEQ|unix.BPF_K, uint32(nr), 0, 1),
+			stmt(unix.BPF_RET|unix.BPF_K, unix.SECCOMP_RET_KILL_PROCESS),
+		)
+	}
+	filter = append(filter, stmt(unix.BPF_RET|unix.BPF_K, unix.SECCOMP_RET_ALLOW))
+
+	prog := unix.SockFprog{
+		Len:    uint16(len(filter)),
+		Filter: &filter[0],
+	}
+	return unix.Prctl(unix.PR_SET_SECCOMP, unix.SECCOMP_MODE_FILTER, uintptr(unsafe.Pointer(&prog)), 0, 0)
+}
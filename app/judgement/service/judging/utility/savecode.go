@@ -1,31 +1,24 @@
 package utility
 
 import (
-	"fmt"
 	"os"
+	"path/filepath"
 )
 
-func CodeSave(code string, path string, language string) error {
-	//TODO:使用绝对路径存放代码路径
-	err := os.MkdirAll(path, 0777)
+// CodeSave 将代码保存到 path 目录下的 fileName 文件中
+func CodeSave(code string, path string, fileName string) error {
+	err := os.MkdirAll(path, 0755)
 	if err != nil {
-		fmt.Println(err)
-	} else {
-		fmt.Println("Successfully created directories")
+		return err
 	}
-	dirName := path + "/main" + language
-	//TODO:以dirName为文件名创建文件
-	problemFile, err := os.OpenFile(dirName, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.ModePerm)
+	dirName := filepath.Join(path, fileName)
+	problemFile, err := os.OpenFile(dirName, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
 	if err != nil {
 		return err
 	}
+	defer problemFile.Close()
 
-	// 写入CPP文件
+	// 写入代码文件
 	_, err = problemFile.WriteString(code)
-	if err != nil {
-		// 写入文件失败时，关闭文件并返回错误
-		problemFile.Close()
-		return err
-	}
 	return err
 }
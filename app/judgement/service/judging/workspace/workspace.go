@@ -0,0 +1,105 @@
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"online_judge/setting"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// defaultRoot 默认的工作目录根路径
+// 不能放在 /tmp、/var/tmp 下，沙箱会在这些目录挂载私有的 tmpfs
+const defaultRoot = "/var/lib/online_judge/workspace"
+
+// defaultDiskQuota 默认单次评测的磁盘配额：单位 MB
+const defaultDiskQuota = 256
+
+var ErrQuotaExceeded = errors.New("workspace disk quota exceeded")
+
+var (
+	root      = defaultRoot
+	diskQuota = int64(defaultDiskQuota) << 20
+)
+
+// unsafeChars 提交ID中不能用作目录名的字符
+var unsafeChars = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+
+// Workspace 一次评测独占的工作目录
+type Workspace struct {
+	Dir string
+}
+
+// Init 根据配置初始化工作目录根路径
+func Init(cfg *setting.JudgementConfig) error {
+	if cfg != nil {
+		if cfg.WorkRoot != "" {
+			root = cfg.WorkRoot
+		}
+		if cfg.DiskQuota > 0 {
+			diskQuota = int64(cfg.DiskQuota) << 20
+		}
+	}
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return err
+	}
+	root = abs
+	if err = os.MkdirAll(root, 0711); err != nil {
+		return err
+	}
+	// 沙箱中的用户程序需要能够进入工作目录，但不能列出其他评测的目录
+	return os.Chmod(root, 0711)
+}
+
+// New 为提交创建唯一的工作目录，目录名以提交ID开头
+func New(submissionID string) (*Workspace, error) {
+	prefix := unsafeChars.ReplaceAllString(submissionID, "")
+	if prefix == "" {
+		prefix = "submission"
+	}
+	dir, err := os.MkdirTemp(root, prefix+"-")
+	if err != nil {
+		return nil, err
+	}
+	if err = os.Chmod(dir, 0755); err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+	return &Workspace{Dir: dir}, nil
+}
+
+// Path 工作目录中的文件路径
+func (w *Workspace) Path(name string) string {
+	return filepath.Join(w.Dir, name)
+}
+
+// CheckQuota 检查工作目录占用的磁盘空间是否超过配额
+func (w *Workspace) CheckQuota() error {
+	var used int64
+	err := filepath.WalkDir(w.Dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		used += info.Size()
+		if used > diskQuota {
+			return ErrQuotaExceeded
+		}
+		return nil
+	})
+	if errors.Is(err, ErrQuotaExceeded) {
+		return fmt.Errorf("%w: more than %d MB", ErrQuotaExceeded, diskQuota>>20)
+	}
+	return err
+}
+
+// Close 删除工作目录
+func (w *Workspace) Close() error {
+	return os.RemoveAll(w.Dir)
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"go.uber.org/zap"
+	"online_judge/app/judgement/responses"
+	"online_judge/app/judgement/service/judging"
+	"online_judge/pkg/language"
 	pb "online_judge/proto"
 	"sync"
 )
@@ -23,25 +26,23 @@ func GetSubmitSrv() *SubmitSrv {
 }
 
 func (s SubmitSrv) SubmitCode(ctx context.Context, request *pb.SubmitRequest, response *pb.SubmitResponse) error {
-	//TODO implement me
-	//uid := request.UserId
-	//code := request.Code
-	//language := request.Language
-	input := request.Input
-	expected := request.Expected
-	//timeLimit := request.TimeLimit
-	//memoryLimit := request.MemoryLimit
-	//fmt.Println(uid)
-	//fmt.Println(code)
-	//fmt.Println(language)
-	fmt.Println(input)
-	fmt.Println(expected)
-	//fmt.Println(timeLimit)
-	//fmt.Println(memoryLimit)
-	err := LanguageCheck(request, response)
+	err := LanguageCheck(request, response, nil)
 	if err != nil {
 		zap.L().Error("judgement-service-language-check-failed", zap.Error(err))
 	}
-	fmt.Println("success\n\n")
+	return nil
+}
+
+// RunCode 使用自定义输入运行代码，同步返回运行结果，不写入数据库
+func (s SubmitSrv) RunCode(ctx context.Context, request *pb.RunRequest, response *pb.RunResponse) error {
+	lang, ok := language.Get(request.Language)
+	if !ok {
+		response.Status = responses.SystemError
+		response.Output = fmt.Sprintf("unsupported language %q", request.Language)
+		return nil
+	}
+	if err := judging.Run(lang, request, response); err != nil {
+		zap.L().Error("judgement-service-run-code-failed", zap.Error(err))
+	}
 	return nil
 }
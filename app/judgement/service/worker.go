@@ -0,0 +1,292 @@
+package service
+
+import (
+	"encoding/json"
+	"github.com/streadway/amqp"
+	"go.uber.org/zap"
+	"online_judge/app/judgement/service/judging"
+	"online_judge/consts"
+	"online_judge/consts/resp_code"
+	"online_judge/dao/mq"
+	"online_judge/dao/mysql"
+	"online_judge/dao/redis"
+	submission "online_judge/models/submission/response"
+	pb "online_judge/proto"
+	"online_judge/setting"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultWorkers 默认的 worker 数量
+	defaultWorkers = 2
+	// defaultMaxRetry 默认的重试次数
+	defaultMaxRetry = 3
+	// reconnectDelay 消费失败后重新连接的间隔
+	reconnectDelay = 5 * time.Second
+)
+
+// JudgeWorkers 从消息队列消费评测任务，评测后把结果写回 judgement 表
+// 每个 worker 使用单独的 channel，一次只处理一条消息
+type JudgeWorkers struct {
+	workers  int
+	maxRetry int
+	quit     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewJudgeWorkers 根据配置创建 worker 池，未配置时使用默认值
+func NewJudgeWorkers(cfg *setting.JudgementConfig) *JudgeWorkers {
+	w := &JudgeWorkers{
+		workers:  defaultWorkers,
+		maxRetry: defaultMaxRetry,
+		quit:     make(chan struct{}),
+	}
+	if cfg != nil && cfg.Workers > 0 {
+		w.workers = cfg.Workers
+	}
+	if cfg != nil && cfg.MaxRetry > 0 {
+		w.maxRetry = cfg.MaxRetry
+	}
+	return w
+}
+
+// Start 启动所有 worker
+func (w *JudgeWorkers) Start() {
+	for i := 0; i < w.workers; i++ {
+		w.wg.Add(1)
+		go func(id int) {
+			defer w.wg.Done()
+			w.run(id)
+		}(i)
+	}
+}
+
+// Stop 停止消费，等待正在评测的任务完成，未确认的消息由 broker 重新投递
+func (w *JudgeWorkers) Stop() {
+	close(w.quit)
+	w.wg.Wait()
+}
+
+func (w *JudgeWorkers) run(id int) {
+	for {
+		ch, msgs, err := mq.ConsumeMessage(1)
+		if err != nil {
+			zap.L().Error("judgement-worker-ConsumeMessage ", zap.Int("worker", id), zap.Error(err))
+		} else {
+			w.consume(msgs)
+			ch.Close()
+		}
+
+		select {
+		case <-w.quit:
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// consume 处理消息直到 channel 关闭或者收到停止信号
+func (w *JudgeWorkers) consume(msgs <-chan amqp.Delivery) {
+	for {
+		select {
+		case <-w.quit:
+			return
+		case d, ok := <-msgs:
+			if !ok {
+				return
+			}
+			w.handle(d)
+		}
+	}
+}
+
+func (w *JudgeWorkers) handle(d amqp.Delivery) {
+	var request pb.SubmitRequest
+	if err := json.Unmarshal(d.Body, &request); err != nil {
+		// 无法解析的消息重试也没有意义，直接转入死信队列
+		zap.L().Error("judgement-worker-Unmarshal ", zap.Error(err))
+		var head struct {
+			SubmissionId string `json:"submission_id"`
+			JudgementId  string `json:"judgement_id"`
+		}
+		_ = json.Unmarshal(d.Body, &head)
+		failJudgement(head.SubmissionId, head.JudgementId)
+		_ = d.Nack(false, false)
+		return
+	}
+
+	publishProgress(&submission.ProgressEvent{SubmissionID: request.SubmissionId, Stage: consts.ProgressReceived})
+	response := &pb.SubmitResponse{}
+	judgeErr := LanguageCheck(&request, response, func(p judging.Progress) {
+		event := &submission.ProgressEvent{
+			SubmissionID: request.SubmissionId,
+			Stage:        p.Stage,
+			Done:         p.Done,
+			Total:        p.Total,
+		}
+		// 测试组评测结束时先发布测试组的结果，例如预测试结果
+		if p.Group != nil {
+			event.Group = p.Group.Name
+			event.Verdict = groupVerdict(p.Group)
+			event.Done = int(p.Group.PassNum)
+			event.Total = int(p.Group.TotalNum)
+			event.Runtime = int(p.Group.Runtime)
+			event.MemoryUsage = int(p.Group.MemoryUsage)
+		}
+		publishProgress(event)
+	})
+	if judgeErr != nil {
+		zap.L().Error("judgement-worker-LanguageCheck ",
+			zap.String("submission_id", request.SubmissionId), zap.Error(judgeErr))
+	}
+
+	retry := mq.RetryCount(d)
+	// 评测环境出错时重试，最后一次仍然失败则写回系统错误
+	if judgeErr != nil && retry < w.maxRetry {
+		w.retry(d, retry)
+		return
+	}
+	if err := saveResult(&request, response); err != nil {
+		zap.L().Error("judgement-worker-saveResult ",
+			zap.String("submission_id", request.SubmissionId), zap.Error(err))
+		if retry < w.maxRetry {
+			w.retry(d, retry)
+			return
+		}
+		failJudgement(request.SubmissionId, request.JudgementId)
+		_ = d.Nack(false, false)
+		return
+	}
+	_ = d.Ack(false)
+	// 结果写回后再发布，订阅方收到后查询数据库可以得到一致的结果
+	publishProgress(&submission.ProgressEvent{
+		SubmissionID: request.SubmissionId,
+		Stage:        consts.ProgressFinished,
+		Done:         len(response.Cases),
+		Total:        int(request.TotalNum),
+		Verdict:      resp_code.Verdict(response.Status),
+		Runtime:      int(response.Runtime),
+		MemoryUsage:  int(response.MemoryUsage),
+	})
+}
+
+// publishProgress 发布评测进度，发布失败不影响评测
+func publishProgress(event *submission.ProgressEvent) {
+	if err := redis.PublishProgress(event); err != nil {
+		zap.L().Warn("judgement-worker-PublishProgress ", zap.Error(err))
+	}
+}
+
+// failJudgement 消息转入死信队列前把评测记录标记为系统错误，避免一直处于等待评测
+func failJudgement(submissionID, judgementID string) {
+	if judgementID == "" {
+		return
+	}
+	updated, err := mysql.UpdateJudgementResult(&mysql.Judgement{
+		JudgementID: judgementID,
+		Verdict:     resp_code.VerdictSystemError,
+	})
+	if err != nil {
+		zap.L().Error("judgement-worker-UpdateJudgementResult ",
+			zap.String("judgement_id", judgementID), zap.Error(err))
+		return
+	}
+	if updated && submissionID != "" {
+		publishProgress(&submission.ProgressEvent{
+			SubmissionID: submissionID,
+			Stage:        consts.ProgressFinished,
+			Verdict:      resp_code.VerdictSystemError,
+		})
+	}
+}
+
+// retry 重新发送消息并记录重试次数，发送失败时让 broker 重新投递原消息
+func (w *JudgeWorkers) retry(d amqp.Delivery, retry int) {
+	time.Sleep(time.Duration(retry+1) * time.Second)
+	if err := mq.SendMessage2MQ(d.Body, retry+1); err != nil {
+		zap.L().Error("judgement-worker-SendMessage2MQ ", zap.Error(err))
+		_ = d.Nack(false, true)
+		return
+	}
+	_ = d.Ack(false)
+}
+
+// saveResult 写回评测结果，消息被重复投递时不会重复统计
+func saveResult(request *pb.SubmitRequest, response *pb.SubmitResponse) error {
+	updated, firstAC, err := mysql.FinishJudgement(&mysql.Judgement{
+		UID:         request.UserId,
+		JudgementID: request.JudgementId,
+		ProblemID:   request.ProblemId,
+		Verdict:     resp_code.Verdict(response.Status),
+		MemoryUsage: int(response.MemoryUsage),
+		Runtime:     int(response.Runtime),
+		Output:      response.Output,
+		PassNum:     int(response.PassNum),
+		TotalNum:    int(request.TotalNum),
+		Groups:      judgementGroups(request.JudgementId, response.Groups),
+	}, request.Language)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		zap.L().Warn("judgement-worker-saveResult judgement already finished",
+			zap.String("judgement_id", request.JudgementId))
+		return nil
+	}
+	if err = redis.MarkProblemStatsDirty(request.ProblemId); err != nil {
+		zap.L().Warn("judgement-worker-MarkProblemStatsDirty ", zap.Error(err))
+	}
+	solved := resp_code.Verdict(response.Status) == resp_code.VerdictAccepted
+	if err = redis.UpdateProblemStatus(request.UserId, request.ProblemId, solved); err != nil {
+		zap.L().Warn("judgement-worker-UpdateProblemStatus ", zap.Error(err))
+	}
+	if err = redis.DeleteUserProfile(request.UserId); err != nil {
+		zap.L().Warn("judgement-worker-DeleteUserProfile ", zap.Error(err))
+	}
+	if firstAC {
+		updateLeaderboard(request.UserId)
+	}
+	return nil
+}
+
+// judgementGroups 把测试组结果转换为 judgement_group 表的记录
+func judgementGroups(judgementID string, groups []*pb.GroupResult) []*mysql.JudgementGroup {
+	if len(groups) == 0 {
+		return nil
+	}
+	rows := make([]*mysql.JudgementGroup, len(groups))
+	for i, g := range groups {
+		rows[i] = &mysql.JudgementGroup{
+			JudgementID: judgementID,
+			Seq:         i,
+			Name:        g.Name,
+			Verdict:     groupVerdict(g),
+			PassNum:     int(g.PassNum),
+			TotalNum:    int(g.TotalNum),
+			Runtime:     int(g.Runtime),
+			MemoryUsage: int(g.MemoryUsage),
+		}
+	}
+	return rows
+}
+
+func groupVerdict(g *pb.GroupResult) string {
+	if g.Skipped {
+		return resp_code.VerdictSkipped
+	}
+	return resp_code.Verdict(g.Status)
+}
+
+// updateLeaderboard 第一次通过题目后更新排行榜，失败时等待定期校正
+func updateLeaderboard(uid int64) {
+	entry, err := mysql.GetLeaderboardEntry(uid)
+	if err != nil {
+		zap.L().Error("judgement-worker-GetLeaderboardEntry ", zap.Error(err))
+		return
+	}
+	score := redis.LeaderboardScore(entry.FinishNum, entry.LastAcceptedAt)
+	if err = redis.SetLeaderboardScore(uid, score); err != nil {
+		zap.L().Error("judgement-worker-SetLeaderboardScore ", zap.Error(err))
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go.uber.org/zap"
+	"online_judge/consts/resp_code"
+	"online_judge/dao/mysql"
+	"online_judge/dao/redis"
+	"online_judge/dao/redis/cache"
+	"online_judge/logger"
+	"online_judge/models/admin/request"
+	"online_judge/pkg/language"
+	"online_judge/pkg/problempkg"
+	"online_judge/services"
+	"online_judge/setting"
+	"os"
+)
+
+// 题目包命令行工具，用于批量迁移题目：
+//
+//	packager import <package.zip>...
+//	packager export <problem_id> <package.zip>
+//
+// 导入会清理 redis 中的题目列表缓存，并通过 redis 通知正在运行的 API 服务把新题目加入布隆过滤器
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	args := flag.Args()
+	if len(args) < 2 || (args[0] != "import" && args[0] != "export") || (args[0] == "export" && len(args) != 3) {
+		usage()
+		os.Exit(2)
+	}
+	os.Exit(run(args))
+}
+
+// run 初始化依赖并执行子命令，返回进程的退出码
+func run(args []string) int {
+	// loading config files
+	if err := setting.Init(); err != nil {
+		fmt.Printf("init setting failed, err: %v\n", err)
+		return 1
+	}
+	if err := logger.Init(setting.Conf.LogConfig, setting.Conf.Mode); err != nil {
+		fmt.Printf("init logger failed, err: %v\n", err)
+		return 1
+	}
+	defer zap.L().Sync()
+	if err := mysql.Init(setting.Conf.MySQLConfig); err != nil {
+		fmt.Printf("init mysql failed, err: %v\n", err)
+		return 1
+	}
+	// 导入后需要清理题目列表缓存
+	if err := redis.Init(setting.Conf.RedisConfig); err != nil {
+		fmt.Printf("init redis failed, err: %v\n", err)
+		return 1
+	}
+	defer redis.Close()
+	// 检查题目包中检查器和交互器的语言
+	if err := language.Init(setting.Conf.Languages); err != nil {
+		fmt.Printf("init language failed, err: %v\n", err)
+		return 1
+	}
+
+	code := 0
+	if args[0] == "import" {
+		for _, path := range args[1:] {
+			pid, err := importPackage(path)
+			if err != nil {
+				code = 1
+				fmt.Printf("import %s failed, err: %v\n", path, err)
+				continue
+			}
+			fmt.Printf("import %s: %s\n", path, pid)
+		}
+	} else if err := exportPackage(args[1], args[2]); err != nil {
+		code = 1
+		fmt.Printf("export %s failed, err: %v\n", args[1], err)
+	}
+	return code
+}
+
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), "usage:\n"+
+		"  %[1]s import <package.zip>...\n"+
+		"  %[1]s export <problem_id> <package.zip>\n", os.Args[0])
+}
+
+// importPackage 导入一个题目包，返回新题目的ID
+func importPackage(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	resp := cache.CacheGroupApp.CacheAdmin.ImportProblemPackage(request.AdminImportProblemPackageReq{
+		Archive: file,
+		Size:    info.Size(),
+	})
+	switch resp.Code {
+	case resp_code.Success:
+		return resp.Data.(string), nil
+	case resp_code.InvalidProblemPackage:
+		return "", fmt.Errorf("invalid problem package: %v", resp.Data)
+	case resp_code.ProblemAlreadyExist:
+		return "", fmt.Errorf("problem title already exists")
+	default:
+		return "", fmt.Errorf("response code %d", resp.Code)
+	}
+}
+
+// exportPackage 把题目导出到 path
+func exportPackage(pid, path string) error {
+	resp := services.ServiceGroupApp.AdminService.ExportProblemPackage(pid)
+	switch resp.Code {
+	case resp_code.Success:
+	case resp_code.ProblemNotExist:
+		return fmt.Errorf("problem not found")
+	default:
+		return fmt.Errorf("response code %d", resp.Code)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err = problempkg.Write(file, resp.Data.(*problempkg.Package)); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
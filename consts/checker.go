@@ -0,0 +1,12 @@
+package consts
+
+// 答案检查方式
+const (
+	CheckerExact  = "exact"  // 输出与答案完全一致
+	CheckerToken  = "token"  // 忽略空白字符，逐个 token 比较
+	CheckerFloat  = "float"  // 逐个 token 比较，浮点数允许误差
+	CheckerCustom = "custom" // 管理员上传的检查器程序 (testlib 风格)
+)
+
+// DefaultCheckerEpsilon float 模式默认的误差，同时作为绝对误差和相对误差，满足其中一个即可
+const DefaultCheckerEpsilon = 1e-6
@@ -0,0 +1,12 @@
+package consts
+
+// 比赛赛制
+const (
+	ContestRuleICPC = "icpc" // 按通过题数排名，题数相同时按罚时排名
+	ContestRuleOI   = "oi"   // 按通过的测试样例比例计分
+)
+
+const (
+	DefaultContestPenalty = 20  // ICPC 赛制每次错误提交的罚时：分钟
+	DefaultContestScore   = 100 // OI 赛制每道题的默认满分
+)
@@ -1,8 +0,0 @@
-package consts
-
-const (
-	CPP = 2000 + iota
-	JAVA
-	PYTHON
-	GO
-)
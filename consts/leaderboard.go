@@ -0,0 +1,8 @@
+package consts
+
+// 排行榜的统计时间段
+const (
+	LeaderboardWindowAll   = "all"   // 全部时间
+	LeaderboardWindowWeek  = "week"  // 本周，从周一开始
+	LeaderboardWindowMonth = "month" // 本月
+)
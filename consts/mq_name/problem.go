@@ -1,3 +1,12 @@
 package mq_name
 
 const RabbitMQProblemQueueName = "rabbitmq-problem-queue"
+
+// 评测失败超过重试次数的消息转入死信队列
+const (
+	RabbitMQProblemDeadLetterExchange = "rabbitmq-problem-dlx"
+	RabbitMQProblemDeadLetterQueue    = "rabbitmq-problem-queue.dlq"
+)
+
+// RabbitMQRetryHeader 消息头中记录已经重试的次数
+const RabbitMQRetryHeader = "x-retry-count"
@@ -0,0 +1,8 @@
+package consts
+
+// 当前用户在题目上的状态
+const (
+	ProblemStatusSolved    = "solved"    // 已通过
+	ProblemStatusAttempted = "attempted" // 提交过但没有通过
+	ProblemStatusUntouched = "untouched" // 没有提交过
+)
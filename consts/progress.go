@@ -0,0 +1,10 @@
+package consts
+
+// 评测进度的阶段
+const (
+	ProgressReceived      = "received"       // 评测服务收到任务
+	ProgressCompiling     = "compiling"      // 正在编译
+	ProgressRunning       = "running"        // 正在运行测试样例
+	ProgressGroupFinished = "group_finished" // 一个测试组评测结束，附带测试组的结果
+	ProgressFinished      = "finished"       // 评测结束，附带最终结果
+)
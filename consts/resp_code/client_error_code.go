@@ -25,4 +25,16 @@ const (
 	NeedObtainVerificationCode
 	CategoryIsNotEmpty
 	UserAlreadyRoot
+	SubmissionNotExist
+	PermissionDenied
+	ContestNotExist
+	ContestNotRunning
+	ContestNotRegistered
+	ContestAlreadyEnded
+	ProblemNotInContest
+	InvalidContest
+	ContestNotEnded
+	InvalidTestCaseArchive
+	InvalidProblemPackage
+	JudgementPending
 )
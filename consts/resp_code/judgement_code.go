@@ -10,4 +10,59 @@ const (
 	SystemError
 )
 
-// 位置
+// judgement 表中保存的评测结果
+const (
+	VerdictPending       = "pending"
+	VerdictAccepted      = "accepted"
+	VerdictWrongAnswer   = "wrong answer"
+	VerdictCompilerError = "compiler error"
+	VerdictTimeLimited   = "time limited"
+	VerdictMemoryLimited = "memory limited"
+	VerdictRuntimeError  = "runtime error"
+	VerdictSystemError   = "system error"
+	VerdictUnknown       = "unknown"
+	VerdictSkipped       = "skipped" // 只用于测试组，前面的测试组未通过时没有评测
+)
+
+// Verdict 把评测服务返回的状态码转换为 judgement 表中的评测结果
+func Verdict(status int32) string {
+	switch status {
+	case Accepted:
+		return VerdictAccepted
+	case WrongAnswer:
+		return VerdictWrongAnswer
+	case ComplierError:
+		return VerdictCompilerError
+	case TimeLimited:
+		return VerdictTimeLimited
+	case MemoryLimited:
+		return VerdictMemoryLimited
+	case RuntimeError:
+		return VerdictRuntimeError
+	case SystemError:
+		return VerdictSystemError
+	default:
+		return VerdictUnknown
+	}
+}
+
+// ValidVerdict 检查是否为 judgement 表中的评测结果
+func ValidVerdict(verdict string) bool {
+	switch verdict {
+	case VerdictPending, VerdictAccepted, VerdictWrongAnswer, VerdictCompilerError,
+		VerdictTimeLimited, VerdictMemoryLimited, VerdictRuntimeError, VerdictSystemError:
+		return true
+	}
+	return false
+}
+
+// RunStatusOK 使用自定义输入运行时程序正常退出
+const RunStatusOK = "ok"
+
+// RunStatus 把自定义输入运行的状态码转换为运行结果，正常退出为 ok，其他和评测结果相同
+func RunStatus(status int32) string {
+	if status == Accepted {
+		return RunStatusOK
+	}
+	return Verdict(status)
+}
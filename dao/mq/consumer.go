@@ -1,17 +1,46 @@
 package mq
 
 import (
-	"context"
-
 	"github.com/streadway/amqp"
+	"online_judge/consts/mq_name"
 )
 
-func ConsumeMessage(ctx context.Context, queueName string) (msg <-chan amqp.Delivery, err error) {
-	ch, err := RabbitMq.Channel()
+const retryHeader = mq_name.RabbitMQRetryHeader
+
+// ConsumeMessage 消费评测队列，prefetch 为未确认消息数量的上限
+// 调用方负责关闭返回的 channel
+func ConsumeMessage(prefetch int) (ch *amqp.Channel, msg <-chan amqp.Delivery, err error) {
+	ch, err = Channel()
 	if err != nil {
 		return
 	}
-	q, _ := ch.QueueDeclare(queueName, true, false, false, false, nil)
-	err = ch.Qos(1, 0, false)
-	return ch.Consume(q.Name, "", false, false, false, false, nil)
+	q, err := DeclareProblemQueue(ch)
+	if err != nil {
+		ch.Close()
+		return nil, nil, err
+	}
+	if err = ch.Qos(prefetch, 0, false); err != nil {
+		ch.Close()
+		return nil, nil, err
+	}
+	msg, err = ch.Consume(q.Name, "", false, false, false, false, nil)
+	if err != nil {
+		ch.Close()
+		return nil, nil, err
+	}
+	return
+}
+
+// RetryCount 消息已经重试的次数
+func RetryCount(d amqp.Delivery) int {
+	switch v := d.Headers[retryHeader].(type) {
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case int:
+		return v
+	default:
+		return 0
+	}
 }
@@ -5,9 +5,14 @@ import (
 	"github.com/streadway/amqp"
 	"go.uber.org/zap"
 	"online_judge/setting"
+	"sync"
 )
 
-var RabbitMq *amqp.Connection
+var (
+	RabbitMq *amqp.Connection
+	connURL  string
+	connMu   sync.Mutex
+)
 
 func InitRabbitMQ(cfg *setting.RabbitMQConfig) error {
 	connString := fmt.Sprintf("%s://%s:%s@%s:%d/",
@@ -17,12 +22,41 @@ func InitRabbitMQ(cfg *setting.RabbitMQConfig) error {
 		cfg.Host,
 		cfg.Port,
 	)
-	fmt.Println(connString)
 	conn, err := amqp.Dial(connString)
 	if err != nil {
 		zap.L().Error("mq Dial", zap.Error(err))
 		return err
 	}
+	connMu.Lock()
 	RabbitMq = conn
+	connURL = connString
+	connMu.Unlock()
 	return nil
 }
+
+// Channel 打开一个新的 channel，连接断开时重新连接
+func Channel() (*amqp.Channel, error) {
+	connMu.Lock()
+	defer connMu.Unlock()
+	if RabbitMq == nil || RabbitMq.IsClosed() {
+		if connURL == "" {
+			return nil, amqp.ErrClosed
+		}
+		conn, err := amqp.Dial(connURL)
+		if err != nil {
+			return nil, err
+		}
+		zap.L().Info("mq-Channel reconnected to rabbitmq")
+		RabbitMq = conn
+	}
+	return RabbitMq.Channel()
+}
+
+// Close 关闭连接
+func Close() {
+	connMu.Lock()
+	defer connMu.Unlock()
+	if RabbitMq != nil && !RabbitMq.IsClosed() {
+		_ = RabbitMq.Close()
+	}
+}
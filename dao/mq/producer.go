@@ -1,26 +1,41 @@
 package mq
 
 import (
-	"go.uber.org/zap"
-	"online_judge/consts/mq_name"
-
+	"errors"
 	"github.com/streadway/amqp"
+	"go.uber.org/zap"
 )
 
-func SendMessage2MQ(body []byte) (err error) {
-	ch, err := RabbitMq.Channel()
+// SendMessage2MQ 发送评测任务，retry 为这条消息已经重试的次数
+func SendMessage2MQ(body []byte, retry int) (err error) {
+	ch, err := Channel()
 	if err != nil {
 		return
 	}
-	q, _ := ch.QueueDeclare(mq_name.RabbitMQProblemQueueName, true, false, false, false, nil)
+	defer ch.Close()
+
+	q, err := DeclareProblemQueue(ch)
+	if err != nil {
+		return
+	}
+	// 等待 broker 确认消息已经持久化
+	if err = ch.Confirm(false); err != nil {
+		return
+	}
+	confirms := ch.NotifyPublish(make(chan amqp.Confirmation, 1))
+
 	err = ch.Publish("", q.Name, false, false, amqp.Publishing{
 		DeliveryMode: amqp.Persistent,
 		ContentType:  "application/json",
+		Headers:      amqp.Table{retryHeader: int32(retry)},
 		Body:         body,
 	})
 	if err != nil {
 		return
 	}
+	if confirm, ok := <-confirms; !ok || !confirm.Ack {
+		return errors.New("mq-producer-Publish message was not confirmed by broker")
+	}
 	zap.L().Debug("mq-producer-Publish send msg to MQ successfully")
 	return
 }
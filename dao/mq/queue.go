@@ -0,0 +1,26 @@
+package mq
+
+import (
+	"github.com/streadway/amqp"
+	"online_judge/consts/mq_name"
+)
+
+// DeclareProblemQueue 声明评测队列，被拒绝的消息通过死信交换机转入死信队列
+func DeclareProblemQueue(ch *amqp.Channel) (amqp.Queue, error) {
+	err := ch.ExchangeDeclare(mq_name.RabbitMQProblemDeadLetterExchange, amqp.ExchangeDirect, true, false, false, false, nil)
+	if err != nil {
+		return amqp.Queue{}, err
+	}
+	dlq, err := ch.QueueDeclare(mq_name.RabbitMQProblemDeadLetterQueue, true, false, false, false, nil)
+	if err != nil {
+		return amqp.Queue{}, err
+	}
+	err = ch.QueueBind(dlq.Name, mq_name.RabbitMQProblemQueueName, mq_name.RabbitMQProblemDeadLetterExchange, false, nil)
+	if err != nil {
+		return amqp.Queue{}, err
+	}
+	return ch.QueueDeclare(mq_name.RabbitMQProblemQueueName, true, false, false, false, amqp.Table{
+		"x-dead-letter-exchange":    mq_name.RabbitMQProblemDeadLetterExchange,
+		"x-dead-letter-routing-key": mq_name.RabbitMQProblemQueueName,
+	})
+}
@@ -110,3 +110,17 @@ func UpdateProblemCategoryByProblemID(problemID, categoryID string) error {
 
 	return DB.Save(&tmp).Error
 }
+
+// GetOrCreateCategoryIDs 根据分类名称获取分类ID，分类不存在时创建
+func GetOrCreateCategoryIDs(names []string) ([]string, error) {
+	ids := make([]string, 0, len(names))
+	for _, name := range names {
+		category := Category{Name: name, CategoryID: utils.GetUUID()}
+		err := DB.Where(Category{Name: name}).FirstOrCreate(&category).Error
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, category.CategoryID)
+	}
+	return ids, nil
+}
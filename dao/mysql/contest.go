@@ -0,0 +1,274 @@
+package mysql
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"online_judge/consts/resp_code"
+	"time"
+)
+
+// ContestUser 比赛的报名用户
+type ContestUser struct {
+	UserID   int64
+	Username string
+}
+
+// CreateContest 创建比赛及比赛题目
+func CreateContest(contest *Contest) error {
+	return DB.Create(contest).Error
+}
+
+// UpdateContest 更新比赛信息，题目列表整体替换
+func UpdateContest(contest *Contest) error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&Contest{}).Where("contest_id = ?", contest.ContestID).
+			Select("title", "description", "rule", "start_time", "end_time", "penalty", "freeze_minutes").
+			Updates(contest)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			// 内容没有变化时也不会更新任何行，需要确认比赛是否存在
+			var count int64
+			if err := tx.Model(&Contest{}).Where("contest_id = ?", contest.ContestID).Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				return ErrContestNotFound
+			}
+		}
+		err := tx.Unscoped().Where("contest_id = ?", contest.ContestID).Delete(&ContestProblem{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(&contest.Problems).Error
+	})
+}
+
+// DeleteContest 删除比赛
+func DeleteContest(cid string) error {
+	res := DB.Where("contest_id = ?", cid).Delete(&Contest{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrContestNotFound
+	}
+	return nil
+}
+
+// frozenJudgementSQL 评测记录在比赛封榜之后提交并且封榜结果还没有揭晓，alias 为 judgement 表的别名
+// 这些记录的结果只对提交者和管理员可见，也不计入题目统计和用户的通过数量，揭晓时由 UnfreezeContest 统一计入
+func frozenJudgementSQL(alias string) string {
+	return fmt.Sprintf("EXISTS (SELECT 1 FROM contest AS fc WHERE fc.contest_id = %[1]s.contest_id "+
+		"AND fc.freeze_minutes > 0 AND fc.unfrozen = FALSE AND fc.deleted_at IS NULL "+
+		"AND %[1]s.created_at >= fc.end_time - INTERVAL fc.freeze_minutes MINUTE)", alias)
+}
+
+// notFrozenJudgementSQL 排除封榜期间的评测记录
+func notFrozenJudgementSQL(alias string) string {
+	return "NOT " + frozenJudgementSQL(alias)
+}
+
+// isJudgementFrozen 判断评测记录是否处于封榜期间
+func isJudgementFrozen(tx *gorm.DB, jid string) (frozen bool, err error) {
+	err = tx.Table("judgement AS j").
+		Select(frozenJudgementSQL("j")).
+		Where("j.judgement_id = ?", jid).
+		Row().Scan(&frozen)
+	return
+}
+
+// lockJudgementFrozen 给评测记录所属的比赛加共享锁后判断评测记录是否处于封榜期间
+// 和 UnfreezeContest 的排他锁互斥，写回结果和揭晓封榜结果同时进行时评测记录不会被漏掉或者重复统计
+func lockJudgementFrozen(tx *gorm.DB, jid string) (bool, error) {
+	var j Judgement
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("contest_id", "created_at").
+		Where("judgement_id = ?", jid).First(&j).Error
+	if err != nil || j.ContestID == "" {
+		return false, err
+	}
+	var contests []Contest
+	err = tx.Clauses(clause.Locking{Strength: "SHARE"}).
+		Where("contest_id = ?", j.ContestID).Limit(1).Find(&contests).Error
+	if err != nil || len(contests) == 0 {
+		return false, err
+	}
+	ct := contests[0]
+	if ct.FreezeMinutes <= 0 || ct.Unfrozen {
+		return false, nil
+	}
+	return !j.CreatedAt.Before(ct.EndTime.Add(-time.Duration(ct.FreezeMinutes) * time.Minute)), nil
+}
+
+// UnfreezeContest 揭晓比赛的封榜结果，把封榜期间已经评测完成的记录计入题目统计和用户的通过数量
+// 返回这些记录涉及的用户和题目，比赛已经揭晓过时不做任何修改
+func UnfreezeContest(cid string) (uids []int64, pids []string, err error) {
+	err = DB.Transaction(func(tx *gorm.DB) error {
+		var ct Contest
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("contest_id = ?", cid).First(&ct).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return ErrContestNotFound
+			}
+			return err
+		}
+		if ct.Unfrozen {
+			return nil
+		}
+
+		var rows []struct {
+			UserID    int64
+			ProblemID string
+			Verdict   string
+			Language  string
+		}
+		err = tx.Table("judgement AS j").
+			Select("j.user_id, j.problem_id, j.verdict, s.language").
+			Joins("JOIN submission AS s ON s.submission_id = j.submission_id").
+			Where("j.contest_id = ? AND j.verdict <> ? AND j.deleted_at IS NULL", cid, resp_code.VerdictPending).
+			Where(frozenJudgementSQL("j")).
+			Scan(&rows).Error
+		if err != nil {
+			return err
+		}
+
+		type problemDelta struct {
+			stat      ProblemStat
+			verdicts  map[string]int64
+			languages map[string]int64
+			accepted  map[int64]bool
+		}
+		deltas := make(map[string]*problemDelta)
+		users := make(map[int64]bool) // 值为 true 表示用户在封榜期间有通过的记录
+		for _, r := range rows {
+			d := deltas[r.ProblemID]
+			if d == nil {
+				d = &problemDelta{
+					verdicts:  make(map[string]int64),
+					languages: make(map[string]int64),
+					accepted:  make(map[int64]bool),
+				}
+				deltas[r.ProblemID] = d
+				pids = append(pids, r.ProblemID)
+			}
+			d.stat.SubmitNum++
+			d.verdicts[r.Verdict]++
+			d.languages[r.Language]++
+			if _, ok := users[r.UserID]; !ok {
+				users[r.UserID] = false
+				uids = append(uids, r.UserID)
+			}
+			if r.Verdict == resp_code.VerdictAccepted {
+				d.stat.AcceptedNum++
+				d.accepted[r.UserID] = true
+				users[r.UserID] = true
+			}
+		}
+
+		now := time.Now()
+		for pid, d := range deltas {
+			for uid := range d.accepted {
+				// 封榜期间之外已经通过这道题的用户已经计入通过人数
+				var counted int64
+				err = tx.Model(&Judgement{}).
+					Where("user_id = ? AND problem_id = ? AND verdict = ?", uid, pid, resp_code.VerdictAccepted).
+					Where(notFrozenJudgementSQL("judgement")).
+					Count(&counted).Error
+				if err != nil {
+					return err
+				}
+				if counted == 0 {
+					d.stat.SolverNum++
+				}
+			}
+			if err = AddProblemStats(tx, pid, d.stat, d.verdicts, d.languages); err != nil {
+				return err
+			}
+		}
+
+		if err = tx.Model(&Contest{}).Where("contest_id = ?", cid).Update("unfrozen", true).Error; err != nil {
+			return err
+		}
+		for uid, accepted := range users {
+			if !accepted {
+				continue
+			}
+			var before User
+			if err = tx.Select("finish_num").Where("user_id = ?", uid).First(&before).Error; err != nil {
+				return err
+			}
+			if err = RecountPassNum(tx, uid); err != nil {
+				return err
+			}
+			// 通过数量增加时和 AddPassNum 一样更新最后通过时间
+			err = tx.Model(&User{}).Where("user_id = ? AND finish_num > ?", uid, before.FinishProblemNum).
+				UpdateColumn("last_accepted_at", now).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return
+}
+
+// GetContest 获取比赛信息，题目按题号排序
+func GetContest(cid string) (*Contest, error) {
+	var contest Contest
+	err := DB.Model(&Contest{}).Where("contest_id = ?", cid).
+		Preload("Problems", func(db *gorm.DB) *gorm.DB {
+			return db.Order("label")
+		}).
+		First(&contest).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, ErrContestNotFound
+	}
+	return &contest, err
+}
+
+// GetContestList 按开始时间倒序获取比赛列表
+func GetContestList(page, size int, count *int64) (contests []Contest, err error) {
+	offset := (page - 1) * size
+	err = DB.Model(&Contest{}).Count(count).
+		Order("start_time DESC").
+		Offset(offset).Limit(size).Find(&contests).Error
+	return
+}
+
+// RegisterContest 报名比赛，重复报名不会报错
+func RegisterContest(cid string, uid int64) error {
+	return DB.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&ContestParticipant{ContestID: cid, UserID: uid}).Error
+}
+
+// IsContestParticipant 检查用户是否报名了比赛
+func IsContestParticipant(cid string, uid int64) (bool, error) {
+	var count int64
+	err := DB.Model(&ContestParticipant{}).
+		Where("contest_id = ? AND user_id = ?", cid, uid).Count(&count).Error
+	return count > 0, err
+}
+
+// GetContestUsers 获取比赛的全部报名用户
+func GetContestUsers(cid string) (users []ContestUser, err error) {
+	err = DB.Table("contest_participant AS p").
+		Select("p.user_id, u.username").
+		Joins("JOIN `user` AS u ON u.user_id = p.user_id").
+		Where("p.contest_id = ? AND p.deleted_at IS NULL", cid).
+		Order("p.created_at").
+		Scan(&users).Error
+	return
+}
+
+// GetContestJudgements 按提交顺序获取比赛时间内的评测记录
+func GetContestJudgements(cid string, start, end time.Time) (judgements []Judgement, err error) {
+	err = DB.Model(&Judgement{}).
+		Where("contest_id = ? AND created_at >= ? AND created_at < ?", cid, start, end).
+		Order("created_at, judgement_id").
+		Find(&judgements).Error
+	return
+}
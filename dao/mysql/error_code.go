@@ -31,6 +31,12 @@ var ErrTitleAlreadyExist = errors.New("title already exist")
 // ErrProblemIDNotExist 题目ID不存在
 var ErrProblemIDNotExist = errors.New("problem id does not exist")
 
+// ErrContestNotFound 比赛不存在
+var ErrContestNotFound = errors.New("contest not found")
+
+// ErrJudgementPending 评测尚未完成
+var ErrJudgementPending = errors.New("judgement is pending")
+
 // IsUniqueConstraintError 检查是否为唯一约束错误
 func IsUniqueConstraintError(err error) bool {
 	mysqlErr, ok := err.(*mysql.MySQLError)
@@ -0,0 +1,103 @@
+package mysql
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+// JudgementFilter 评测记录的查询条件，零值表示不过滤
+type JudgementFilter struct {
+	UserID     int64
+	ProblemID  string
+	Verdict    string
+	Language   string
+	From       time.Time // 提交时间下界（包含）
+	To         time.Time // 提交时间上界（不包含）
+	HideFrozen bool      // 排除封榜期间除 ViewerID 以外用户的记录
+	ViewerID   int64
+	CursorTime time.Time // 上一页最后一条记录的创建时间
+	CursorID   string    // 上一页最后一条记录的评测ID
+	Limit      int
+}
+
+// JudgementRecord 评测记录和对应的提交信息
+type JudgementRecord struct {
+	JudgementID    string
+	SubmissionID   string
+	UserID         int64
+	ProblemID      string
+	Verdict        string
+	MemoryUsage    int
+	Runtime        int
+	Output         string
+	Language       string
+	Code           string
+	SubmissionTime time.Time
+	CreatedAt      time.Time
+	ProblemVersion int
+	Frozen         bool // 封榜期间的比赛提交
+}
+
+var judgementRecordFields = "j.judgement_id, j.submission_id, j.user_id, j.problem_id, j.verdict, " +
+	"j.memory_usage, j.runtime, j.output, s.language, s.code, s.submission_time, j.created_at, j.problem_version, " +
+	frozenJudgementSQL("j") + " AS frozen"
+
+func judgementRecordQuery() *gorm.DB {
+	return DB.Table("judgement AS j").
+		Select(judgementRecordFields).
+		Joins("JOIN submission AS s ON s.submission_id = j.submission_id").
+		Where("j.deleted_at IS NULL AND s.deleted_at IS NULL")
+}
+
+// ListJudgements 按创建时间倒序分页查询评测记录，使用游标代替 offset
+func ListJudgements(f JudgementFilter) (records []JudgementRecord, err error) {
+	db := judgementRecordQuery()
+	if f.UserID != 0 {
+		db = db.Where("j.user_id = ?", f.UserID)
+	}
+	if f.ProblemID != "" {
+		db = db.Where("j.problem_id = ?", f.ProblemID)
+	}
+	if f.Verdict != "" {
+		db = db.Where("j.verdict = ?", f.Verdict)
+	}
+	if f.Language != "" {
+		db = db.Where("s.language = ?", f.Language)
+	}
+	if f.HideFrozen {
+		db = db.Where("(j.user_id = ? OR "+notFrozenJudgementSQL("j")+")", f.ViewerID)
+	}
+	if !f.From.IsZero() {
+		db = db.Where("s.submission_time >= ?", f.From)
+	}
+	if !f.To.IsZero() {
+		db = db.Where("s.submission_time < ?", f.To)
+	}
+	if f.CursorID != "" {
+		db = db.Where("(j.created_at < ? OR (j.created_at = ? AND j.judgement_id < ?))",
+			f.CursorTime, f.CursorTime, f.CursorID)
+	}
+	err = db.Order("j.created_at DESC, j.judgement_id DESC").Limit(f.Limit).Scan(&records).Error
+	return
+}
+
+// GetJudgementRecord 根据评测ID获取评测记录
+func GetJudgementRecord(jid string) (*JudgementRecord, error) {
+	return firstJudgementRecord(judgementRecordQuery().Where("j.judgement_id = ?", jid))
+}
+
+// GetJudgementRecordBySubmissionID 根据提交ID获取评测记录
+func GetJudgementRecordBySubmissionID(sid string) (*JudgementRecord, error) {
+	return firstJudgementRecord(judgementRecordQuery().Where("j.submission_id = ?", sid))
+}
+
+func firstJudgementRecord(db *gorm.DB) (*JudgementRecord, error) {
+	var records []JudgementRecord
+	if err := db.Limit(1).Scan(&records).Error; err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &records[0], nil
+}
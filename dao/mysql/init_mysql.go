@@ -106,6 +106,14 @@ func CreateTables() (err error) {
 		&ProblemCategory{},
 		&Submission{},
 		&Judgement{},
+		&JudgementGroup{},
+		&VerdictOverride{},
+		&Contest{},
+		&ContestProblem{},
+		&ContestParticipant{},
+		&ProblemStat{},
+		&ProblemStatCount{},
+		&ProblemVersion{},
 	}
 
 	if err = DB.AutoMigrate(models...); err != nil {
@@ -2,6 +2,9 @@ package mysql
 
 import (
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"online_judge/consts/resp_code"
+	"time"
 )
 
 // InsertNewSubmission 添加提交记录
@@ -9,6 +12,84 @@ func InsertNewSubmission(sub *Judgement) error {
 	return DB.Model(sub).Create(sub).Error
 }
 
+// UpdateJudgementResult 写回评测结果，只更新仍在等待评测的记录
+// 返回 false 表示记录已经被写回过，消息被重复投递时不会重复统计
+func UpdateJudgementResult(j *Judgement) (bool, error) {
+	res := DB.Model(&Judgement{}).
+		Where("judgement_id = ? AND verdict = ?", j.JudgementID, resp_code.VerdictPending).
+		Updates(map[string]interface{}{
+			"verdict":      j.Verdict,
+			"memory_usage": j.MemoryUsage,
+			"runtime":      j.Runtime,
+			"output":       j.Output,
+		})
+	return res.RowsAffected > 0, res.Error
+}
+
+// FinishJudgement 在事务中写回评测结果和测试组结果并更新题目统计，第一次通过题目时增加用户的通过数量
+// 封榜期间的评测记录只写回结果，不更新统计
+// updated 为 false 表示记录已经被写回过，firstAC 表示用户第一次通过这道题
+func FinishJudgement(j *Judgement, language string) (updated, firstAC bool, err error) {
+	err = DB.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&Judgement{}).
+			Where("judgement_id = ? AND verdict = ?", j.JudgementID, resp_code.VerdictPending).
+			Updates(map[string]interface{}{
+				"verdict":      j.Verdict,
+				"memory_usage": j.MemoryUsage,
+				"runtime":      j.Runtime,
+				"output":       j.Output,
+				"pass_num":     j.PassNum,
+				"total_num":    j.TotalNum,
+			})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		updated = true
+		if len(j.Groups) > 0 {
+			if err := tx.Create(j.Groups).Error; err != nil {
+				return err
+			}
+		}
+		// 封榜期间的评测记录在揭晓结果时再计入统计
+		frozen, err := lockJudgementFrozen(tx, j.JudgementID)
+		if err != nil || frozen {
+			return err
+		}
+		if j.Verdict == resp_code.VerdictAccepted {
+			// 先锁住用户记录，同一用户并发写回的通过记录依次判断是否第一次通过
+			// 锁之后的计数是事务中第一次一致性读，可以看到先提交的通过记录
+			var locked []int64
+			err = tx.Model(&User{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("user_id = ?", j.UID).Pluck("user_id", &locked).Error
+			if err != nil {
+				return err
+			}
+			var accepted int64
+			err = tx.Model(&Judgement{}).
+				Where("user_id = ? AND problem_id = ? AND verdict = ?", j.UID, j.ProblemID, resp_code.VerdictAccepted).
+				Where(notFrozenJudgementSQL("judgement")).
+				Count(&accepted).Error
+			if err != nil {
+				return err
+			}
+			if accepted == 1 {
+				if err = AddPassNum(tx, j.UID, time.Now()); err != nil {
+					return err
+				}
+				firstAC = true
+			}
+		}
+		return AddProblemStat(tx, j.ProblemID, j.Verdict, language, firstAC)
+	})
+	return
+}
+
+// GetJudgementGroups 按评测顺序获取评测记录的测试组结果，不区分测试组的评测返回空
+func GetJudgementGroups(jid string) (groups []JudgementGroup, err error) {
+	err = DB.Where("judgement_id = ?", jid).Order("seq").Find(&groups).Error
+	return
+}
+
 // CheckIfAlreadyFinished 检查这个题目是否已经被解决
 func CheckIfAlreadyFinished(uid int64, pid string) (finished bool, err error) {
 	var tmp []Judgement
@@ -24,7 +105,7 @@ func CheckIfAlreadyFinished(uid int64, pid string) (finished bool, err error) {
 	}
 	var count int
 	for _, v := range tmp {
-		if v.Verdict == "accepted" {
+		if v.Verdict == resp_code.VerdictAccepted {
 			count++
 		}
 	}
@@ -35,3 +116,30 @@ func CheckIfAlreadyFinished(uid int64, pid string) (finished bool, err error) {
 	}
 
 }
+
+// GetJudgementBySubmissionID 获取提交记录对应的评测结果
+func GetJudgementBySubmissionID(sid string) (judgement *Judgement, err error) {
+	err = DB.Model(&Judgement{}).Where("submission_id = ?", sid).First(&judgement).Error
+	return
+}
+
+// GetUserProblemStatus 获取用户提交过的所有题目，值为 true 表示已经通过
+func GetUserProblemStatus(uid int64) (map[string]bool, error) {
+	var rows []struct {
+		ProblemID string
+		Solved    bool
+	}
+	err := DB.Model(&Judgement{}).
+		Select("problem_id, MAX(verdict = ?) AS solved", resp_code.VerdictAccepted).
+		Where("user_id = ?", uid).
+		Group("problem_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	status := make(map[string]bool, len(rows))
+	for _, r := range rows {
+		status[r.ProblemID] = r.Solved
+	}
+	return status, nil
+}
@@ -1,22 +1,120 @@
 package mysql
 
-type UserRank struct {
-	FinishProblemNum int64  `json:"finish_num"`
-	UserName         string `json:"username"`
+import (
+	"online_judge/consts/resp_code"
+	"time"
+)
+
+// LeaderboardEntry 排行榜中用户的通过数量和最后通过时间
+type LeaderboardEntry struct {
+	UserID         int64
+	Username       string
+	FinishNum      int64
+	LastAcceptedAt *time.Time
+}
+
+// GetLeaderboardEntries 获取所有通过过题目的用户，用于校正 redis 排行榜
+func GetLeaderboardEntries() (entries []LeaderboardEntry, err error) {
+	err = DB.Model(&User{}).
+		Select("user_id, username, finish_num, last_accepted_at").
+		Where("finish_num > 0").
+		Scan(&entries).Error
+	return
 }
 
-func GetUserLeaderboard() (data *[]UserRank, err error) {
-	var userSlice []User
-	err = DB.Model(&User{}).Select("finish_num, username").Order("finish_num desc").Find(&userSlice).Error
+// GetLeaderboardEntry 获取单个用户的通过数量和最后通过时间
+func GetLeaderboardEntry(uid int64) (*LeaderboardEntry, error) {
+	var entries []LeaderboardEntry
+	err := DB.Model(&User{}).
+		Select("user_id, username, finish_num, last_accepted_at").
+		Where("user_id = ?", uid).
+		Limit(1).Scan(&entries).Error
 	if err != nil {
 		return nil, err
 	}
-	var retSlice []UserRank
-	for _, user := range userSlice {
-		retSlice = append(retSlice, UserRank{
-			FinishProblemNum: user.FinishProblemNum,
-			UserName:         user.UserName,
-		})
+	if len(entries) == 0 {
+		return nil, ErrUserNotFound
+	}
+	return &entries[0], nil
+}
+
+// GetUsernames 批量获取用户名
+func GetUsernames(uids []int64) (map[int64]string, error) {
+	var users []User
+	if len(uids) == 0 {
+		return map[int64]string{}, nil
+	}
+	err := DB.Model(&User{}).Select("user_id, username").Where("user_id IN ?", uids).Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[int64]string, len(users))
+	for _, u := range users {
+		names[u.UserID] = u.UserName
+	}
+	return names, nil
+}
+
+// GetWindowLeaderboardEntries 统计 from 之后第一次通过的题目数量，categoryID 不为空时只统计该分类下的题目
+// from 为零值时统计全部时间，封榜期间的评测记录不参与统计
+func GetWindowLeaderboardEntries(from time.Time, categoryID string) (entries []LeaderboardEntry, err error) {
+	firstAC := DB.Table("judgement AS j").
+		Select("j.user_id, j.problem_id, MIN(j.created_at) AS first_ac").
+		Where("j.verdict = ? AND j.deleted_at IS NULL", resp_code.VerdictAccepted).
+		Where(notFrozenJudgementSQL("j"))
+	if categoryID != "" {
+		firstAC = firstAC.
+			Joins("JOIN problem_category AS pc ON pc.problem_id = j.problem_id AND pc.deleted_at IS NULL").
+			Where("pc.category_id = ?", categoryID)
+	}
+	firstAC = firstAC.Group("j.user_id, j.problem_id")
+
+	db := DB.Table("(?) AS t", firstAC).
+		Select("t.user_id, u.username, COUNT(*) AS finish_num, MAX(t.first_ac) AS last_accepted_at").
+		Joins("JOIN `user` AS u ON u.user_id = t.user_id")
+	if !from.IsZero() {
+		db = db.Where("t.first_ac >= ?", from)
+	}
+	err = db.Group("t.user_id, u.username").Scan(&entries).Error
+	return
+}
+
+// ProblemSolution 用户在某道题目上的最优通过记录
+type ProblemSolution struct {
+	UserID         int64
+	Username       string
+	SubmissionID   string
+	Language       string
+	Runtime        int
+	MemoryUsage    int
+	SubmissionTime time.Time
+}
+
+// GetFastestSolutions 按运行时间、内存、提交时间排序获取题目的通过记录，每个用户只保留最优的一次
+// 封榜期间的评测记录不参与排序
+// language 不为空时只统计该语言的提交
+func GetFastestSolutions(pid, language string, offset, limit int, total *int64) (solutions []ProblemSolution, err error) {
+	ranked := DB.Table("judgement AS j").
+		Select("j.user_id, j.submission_id, s.language, j.runtime, j.memory_usage, s.submission_time, "+
+			"ROW_NUMBER() OVER (PARTITION BY j.user_id ORDER BY j.runtime, j.memory_usage, s.submission_time) AS rn").
+		Joins("JOIN submission AS s ON s.submission_id = j.submission_id").
+		Where("j.problem_id = ? AND j.verdict = ?", pid, resp_code.VerdictAccepted).
+		Where("j.deleted_at IS NULL AND s.deleted_at IS NULL").
+		Where(notFrozenJudgementSQL("j"))
+	if language != "" {
+		ranked = ranked.Where("s.language = ?", language)
+	}
+
+	db := DB.Table("(?) AS t", ranked).Where("t.rn = 1")
+	if err = db.Count(total).Error; err != nil {
+		return
 	}
-	return &retSlice, nil
+	err = DB.Table("(?) AS t", ranked).
+		Select("t.user_id, u.username, t.submission_id, t.language, t.runtime, t.memory_usage, t.submission_time").
+		Joins("JOIN `user` AS u ON u.user_id = t.user_id").
+		Where("t.rn = 1").
+		Order("t.runtime, t.memory_usage, t.submission_time").
+		Offset(offset).Limit(limit).
+		Scan(&solutions).Error
+	return
 }
@@ -1,6 +1,7 @@
 package mysql
 
 import (
+	"errors"
 	"gorm.io/gorm"
 	"math/rand"
 )
@@ -48,7 +49,7 @@ func GetProblemList(page, size int, count *int64) ([]Problems, error) {
 func GetProblemDetail(pid string) (problem *Problems, err error) {
 	err = DB.Where("problem_id = ?", pid).
 		Preload("TestCases", func(db *gorm.DB) *gorm.DB {
-			return db.Limit(2) // 在这里使用 Limit 方法限制 TestCases 的数量
+			return db.Where("is_sample = ?", true) // 只返回公开的样例
 		}).
 		Preload("ProblemCategories.Category").
 		First(&problem).Error
@@ -73,7 +74,7 @@ func GetProblemRandom() (problem *Problems, err error) {
 
 	err = DB.Where("id = ?", problemIdx).
 		Preload("TestCases", func(db *gorm.DB) *gorm.DB {
-			return db.Limit(2) // 在这里使用 Limit 方法限制 TestCases 的数量
+			return db.Where("is_sample = ?", true) // 只返回公开的样例
 		}).
 		Preload("ProblemCategories.Category").
 		First(&problem).Error
@@ -96,6 +97,12 @@ func GetEntireProblem(pid string) (problem *Problems, err error) {
 	return problem, nil
 }
 
+// GetProblemTestCases 获取题目的全部测试数据，包括隐藏的测试数据
+func GetProblemTestCases(pid string) (testCases []TestCase, err error) {
+	err = DB.Where("pid = ?", pid).Order("created_at").Find(&testCases).Error
+	return
+}
+
 // CreateProblem 创建题目
 func CreateProblem(problem *Problems) error {
 	return DB.Create(problem).Error
@@ -119,6 +126,12 @@ func UpdateProblem(problem *Problems, oldProblemID string, category []string) er
 	if err != nil {
 		return err
 	}
+	// Updates 会忽略零值，单独更新布尔字段
+	err = ts.Model(&Problems{}).Where("problem_id = ?", problem.ProblemID).
+		Update("stop_on_pretest_failure", problem.StopOnPretestFailure).Error
+	if err != nil {
+		return err
+	}
 
 	//TODO:更新关联的问题分类
 	//浅复制即可
@@ -247,7 +260,14 @@ func CheckProblemIDWithFile(id string, num *int64) error {
 
 // UpdateProblemWithFile 更新题目
 func UpdateProblemWithFile(problem *ProblemWithFile) error {
-	return DB.Model(&ProblemWithFile{}).Where("problem_id = ?", problem.ProblemID).Updates(&problem).Error
+	return DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&ProblemWithFile{}).Where("problem_id = ?", problem.ProblemID).Updates(&problem).Error; err != nil {
+			return err
+		}
+		// Updates 会忽略零值，单独更新布尔字段
+		return tx.Model(&ProblemWithFile{}).Where("problem_id = ?", problem.ProblemID).
+			Update("stop_on_pretest_failure", problem.StopOnPretestFailure).Error
+	})
 }
 
 // CheckProblemTitleWithFile 检查题目标题是否已经存在
@@ -315,3 +335,41 @@ func GetProblemsByCategoryName(categoryName string) ([]Problems, error) {
 
 	return problems, nil
 }
+
+// ReplaceTestCases 在事务中用新的测试数据替换题目原有的测试数据
+func ReplaceTestCases(pid string, testCases []*TestCase) error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("pid = ?", pid).Delete(&TestCase{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&testCases).Error
+	})
+}
+
+// GetProblemPackage 获取导出题目包需要的题目信息，包括全部测试数据和分类
+func GetProblemPackage(pid string) (problem *Problems, err error) {
+	err = DB.Where("problem_id = ?", pid).
+		Preload("TestCases", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at")
+		}).
+		Preload("ProblemCategories.Category").
+		First(&problem).Error
+	return
+}
+
+// ProblemLimits 题目的时间和内存限制
+type ProblemLimits struct {
+	MaxRuntime int
+	MaxMemory  int
+}
+
+// GetProblemLimits 获取题目的时间和内存限制，输入输出为文件的题目也会查找
+func GetProblemLimits(pid string) (limits ProblemLimits, err error) {
+	err = DB.Model(&Problems{}).Select("max_runtime, max_memory").
+		Where("problem_id = ?", pid).Take(&limits).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = DB.Model(&ProblemWithFile{}).Select("max_runtime, max_memory").
+			Where("problem_id = ?", pid).Take(&limits).Error
+	}
+	return
+}
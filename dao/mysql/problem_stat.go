@@ -0,0 +1,128 @@
+package mysql
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"online_judge/consts/resp_code"
+)
+
+// 题目统计的分组方式
+const (
+	ProblemStatVerdict  = "verdict"  // 按评测结果分组
+	ProblemStatLanguage = "language" // 按编程语言分组
+)
+
+// AddProblemStat 评测结束后更新题目的提交统计，solved 表示用户第一次通过这道题
+func AddProblemStat(tx *gorm.DB, pid, verdict, language string, solved bool) error {
+	stat := ProblemStat{SubmitNum: 1}
+	if verdict == resp_code.VerdictAccepted {
+		stat.AcceptedNum = 1
+	}
+	if solved {
+		stat.SolverNum = 1
+	}
+	return AddProblemStats(tx, pid, stat, map[string]int64{verdict: 1}, map[string]int64{language: 1})
+}
+
+// AddProblemStats 把多次提交计入题目的统计，verdicts 和 languages 为各分组要增加的次数
+func AddProblemStats(tx *gorm.DB, pid string, stat ProblemStat, verdicts, languages map[string]int64) error {
+	stat.ProblemID = pid
+	err := tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"submit_num":   gorm.Expr("submit_num + ?", stat.SubmitNum),
+			"accepted_num": gorm.Expr("accepted_num + ?", stat.AcceptedNum),
+			"solver_num":   gorm.Expr("solver_num + ?", stat.SolverNum),
+		}),
+	}).Create(&stat).Error
+	if err != nil {
+		return err
+	}
+
+	var counts []ProblemStatCount
+	for kind, names := range map[string]map[string]int64{ProblemStatVerdict: verdicts, ProblemStatLanguage: languages} {
+		for name, n := range names {
+			counts = append(counts, ProblemStatCount{ProblemID: pid, Kind: kind, Name: name, Count: n})
+		}
+	}
+	if len(counts) == 0 {
+		return nil
+	}
+	return tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{"count": gorm.Expr("`count` + VALUES(`count`)")}),
+	}).Create(&counts).Error
+}
+
+// SubtractProblemStat 重新评测前扣除评测记录在题目统计中的计数，verdicts 和 languages 为各分组要扣除的次数
+func SubtractProblemStat(tx *gorm.DB, pid string, stat ProblemStat, verdicts, languages map[string]int64) error {
+	err := tx.Model(&ProblemStat{}).Where("problem_id = ?", pid).
+		UpdateColumns(map[string]interface{}{
+			"submit_num":   gorm.Expr("GREATEST(submit_num - ?, 0)", stat.SubmitNum),
+			"accepted_num": gorm.Expr("GREATEST(accepted_num - ?, 0)", stat.AcceptedNum),
+			"solver_num":   gorm.Expr("GREATEST(solver_num - ?, 0)", stat.SolverNum),
+		}).Error
+	if err != nil {
+		return err
+	}
+
+	for kind, counts := range map[string]map[string]int64{ProblemStatVerdict: verdicts, ProblemStatLanguage: languages} {
+		for name, n := range counts {
+			err = tx.Model(&ProblemStatCount{}).
+				Where("problem_id = ? AND kind = ? AND name = ?", pid, kind, name).
+				UpdateColumn("count", gorm.Expr("GREATEST(`count` - ?, 0)", n)).Error
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ChangeProblemStatVerdict 手动修改评测结果后把一次提交从 from 分组移动到 to 分组，solverDelta 为通过人数的变化
+func ChangeProblemStatVerdict(tx *gorm.DB, pid, from, to string, solverDelta int64) error {
+	var acceptedDelta int64
+	if from == resp_code.VerdictAccepted {
+		acceptedDelta--
+	}
+	if to == resp_code.VerdictAccepted {
+		acceptedDelta++
+	}
+	err := tx.Model(&ProblemStat{}).Where("problem_id = ?", pid).
+		UpdateColumns(map[string]interface{}{
+			"accepted_num": gorm.Expr("GREATEST(accepted_num + ?, 0)", acceptedDelta),
+			"solver_num":   gorm.Expr("GREATEST(solver_num + ?, 0)", solverDelta),
+		}).Error
+	if err != nil {
+		return err
+	}
+	err = tx.Model(&ProblemStatCount{}).
+		Where("problem_id = ? AND kind = ? AND name = ?", pid, ProblemStatVerdict, from).
+		UpdateColumn("count", gorm.Expr("GREATEST(`count` - 1, 0)")).Error
+	if err != nil {
+		return err
+	}
+	return tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{"count": gorm.Expr("`count` + 1")}),
+	}).Create(&ProblemStatCount{ProblemID: pid, Kind: ProblemStatVerdict, Name: to, Count: 1}).Error
+}
+
+// GetProblemStats 批量获取题目的提交统计，没有提交记录的题目不在结果中
+func GetProblemStats(pids []string) (map[string]ProblemStat, error) {
+	stats := make(map[string]ProblemStat, len(pids))
+	if len(pids) == 0 {
+		return stats, nil
+	}
+	var list []ProblemStat
+	if err := DB.Where("problem_id IN ?", pids).Find(&list).Error; err != nil {
+		return nil, err
+	}
+	for _, s := range list {
+		stats[s.ProblemID] = s
+	}
+	return stats, nil
+}
+
+// GetProblemStatCounts 获取题目按评测结果和编程语言分组的提交次数
+func GetProblemStatCounts(pid string) (counts []ProblemStatCount, err error) {
+	err = DB.Where("problem_id = ?", pid).Order("kind, `count` DESC").Find(&counts).Error
+	return
+}
@@ -0,0 +1,150 @@
+package mysql
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"online_judge/pkg/testcase"
+	"sort"
+)
+
+// versionCase 计算测试数据摘要时使用的内容，样例和预测试标记变化也会生成新版本
+type versionCase struct {
+	Input     string
+	Expected  string
+	IsSample  bool
+	IsPretest bool
+}
+
+// GetLatestProblemVersion 获取题目最新的版本号，还没有保存过版本时返回 0
+// 提交评测时只读取版本号，版本在管理员修改或导入题目时保存
+func GetLatestProblemVersion(pid string) (version int, err error) {
+	err = DB.Model(&ProblemVersion{}).Where("problem_id = ?", pid).
+		Select("COALESCE(MAX(version), 0)").Scan(&version).Error
+	return
+}
+
+// SaveProblemVersion 保存题目当前的版本，problem 需要包含全部测试数据，返回当前的版本号
+func SaveProblemVersion(problem *Problems) (int, error) {
+	cases := make([]versionCase, len(problem.TestCases))
+	for i, tc := range problem.TestCases {
+		cases[i] = versionCase{Input: tc.Input, Expected: tc.Expected, IsSample: tc.IsSample, IsPretest: tc.IsPretest}
+	}
+	return saveProblemVersion(&ProblemVersion{
+		ProblemID:  problem.ProblemID,
+		Title:      problem.Title,
+		Content:    problem.Content,
+		Difficulty: problem.Difficulty,
+		MaxRuntime: problem.MaxRuntime,
+		MaxMemory:  problem.MaxMemory,
+		Checker:    problem.Checker,
+		Interactor: problem.Interactor,
+	}, problem.StopOnPretestFailure, cases)
+}
+
+// SaveProblemWithFileVersion 保存测试数据为文件的题目的当前版本，testCases 为从文件中读取的测试数据
+func SaveProblemWithFileVersion(problem *ProblemWithFile, testCases []testcase.Case) (int, error) {
+	cases := make([]versionCase, len(testCases))
+	for i, tc := range testCases {
+		cases[i] = versionCase{Input: tc.Input, Expected: tc.Expected, IsSample: tc.IsSample, IsPretest: tc.IsPretest}
+	}
+	return saveProblemVersion(&ProblemVersion{
+		ProblemID:  problem.ProblemID,
+		Title:      problem.Title,
+		Content:    problem.Content,
+		Difficulty: problem.Difficulty,
+		MaxRuntime: problem.MaxRuntime,
+		MaxMemory:  problem.MaxMemory,
+		Checker:    problem.Checker,
+		Interactor: problem.Interactor,
+	}, problem.StopOnPretestFailure, cases)
+}
+
+// saveProblemVersion 和最新版本相同时不会生成新版本，版本号和摘要由题目设置和测试数据计算
+func saveProblemVersion(v *ProblemVersion, stopOnPretestFailure bool, cases []versionCase) (version int, err error) {
+	v.TestNum = len(cases)
+	v.TestHash = testCasesHash(cases)
+	v.Hash, err = problemVersionHash(v, stopOnPretestFailure)
+	if err != nil {
+		return 0, err
+	}
+
+	err = DB.Transaction(func(tx *gorm.DB) error {
+		var latest ProblemVersion
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("problem_id = ?", v.ProblemID).
+			Order("version DESC").
+			First(&latest).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if err == nil && latest.Hash == v.Hash {
+			version = latest.Version
+			return nil
+		}
+		v.Version = latest.Version + 1
+		version = v.Version
+		return tx.Create(v).Error
+	})
+	return
+}
+
+// testCasesHash 计算测试数据和样例、预测试标记的摘要，与测试数据的顺序无关
+func testCasesHash(cases []versionCase) string {
+	sorted := append([]versionCase{}, cases...)
+	sort.Slice(sorted, func(a, b int) bool {
+		i, j := sorted[a], sorted[b]
+		switch {
+		case i.Input != j.Input:
+			return i.Input < j.Input
+		case i.Expected != j.Expected:
+			return i.Expected < j.Expected
+		case i.IsSample != j.IsSample:
+			return !i.IsSample
+		default:
+			return !i.IsPretest && j.IsPretest
+		}
+	})
+
+	h := sha256.New()
+	var size [8]byte
+	write := func(s string) {
+		// 写入长度避免不同的拼接方式得到相同的摘要
+		binary.BigEndian.PutUint64(size[:], uint64(len(s)))
+		h.Write(size[:])
+		h.Write([]byte(s))
+	}
+	flag := func(b bool) {
+		if b {
+			h.Write([]byte{1})
+		} else {
+			h.Write([]byte{0})
+		}
+	}
+	for _, c := range sorted {
+		write(c.Input)
+		write(c.Expected)
+		flag(c.IsSample)
+		flag(c.IsPretest)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// problemVersionHash 计算题目描述、限制、检查器、预测试设置和测试数据的摘要
+func problemVersionHash(v *ProblemVersion, stopOnPretestFailure bool) (string, error) {
+	// 检查器和交互器的源代码在 json 中被忽略，需要单独写入
+	data, err := json.Marshal([]interface{}{
+		v.Title, v.Content, v.Difficulty, v.MaxRuntime, v.MaxMemory,
+		v.CheckerMode, v.CheckerEpsilon, v.CheckerCode, v.CheckerLanguage,
+		v.InteractorCode, v.InteractorLanguage, v.TestHash, stopOnPretestFailure,
+	})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
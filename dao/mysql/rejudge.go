@@ -0,0 +1,222 @@
+package mysql
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"online_judge/consts/resp_code"
+	"time"
+)
+
+// RejudgeFilter 选择需要重新评测的提交，零值的字段不参与过滤，正在等待评测的提交总是被排除
+type RejudgeFilter struct {
+	ProblemID     string
+	SubmissionIDs []string
+	UserID        int64
+	Verdict       string
+	From          time.Time // 提交时间的范围 [From, To)
+	To            time.Time
+}
+
+// RejudgeItem 需要重新评测的提交
+type RejudgeItem struct {
+	JudgementID  string
+	SubmissionID string
+	UserID       int64
+	ProblemID    string
+	ContestID    string
+	Verdict      string // 重新评测之前的评测结果
+	Language     string
+	Code         string
+	Frozen       bool // 封榜期间的评测记录，没有计入题目统计
+}
+
+// query 在 judgement AS j JOIN submission AS s 上添加过滤条件
+func (f *RejudgeFilter) query(db *gorm.DB) *gorm.DB {
+	db = db.Table("judgement AS j").
+		Joins("JOIN submission AS s ON s.submission_id = j.submission_id").
+		Where("j.verdict <> ? AND j.deleted_at IS NULL", resp_code.VerdictPending)
+	if f.ProblemID != "" {
+		db = db.Where("j.problem_id = ?", f.ProblemID)
+	}
+	if len(f.SubmissionIDs) > 0 {
+		db = db.Where("j.submission_id IN ?", f.SubmissionIDs)
+	}
+	if f.UserID != 0 {
+		db = db.Where("j.user_id = ?", f.UserID)
+	}
+	if f.Verdict != "" {
+		db = db.Where("j.verdict = ?", f.Verdict)
+	}
+	if !f.From.IsZero() {
+		db = db.Where("s.submission_time >= ?", f.From)
+	}
+	if !f.To.IsZero() {
+		db = db.Where("s.submission_time < ?", f.To)
+	}
+	return db
+}
+
+// GetRejudgeProblemIDs 获取满足条件的提交所属的题目
+func GetRejudgeProblemIDs(f RejudgeFilter) (pids []string, err error) {
+	err = f.query(DB).Distinct("j.problem_id").Pluck("j.problem_id", &pids).Error
+	return
+}
+
+// ResetJudgements 把题目 pid 中满足条件的评测记录恢复为等待评测，并记录评测使用的题目版本
+// 同时扣除这些记录在题目统计中的计数并重新计算受影响用户的通过数量，评测完成后由 FinishJudgement 重新统计
+func ResetJudgements(pid string, version int, f RejudgeFilter) (items []RejudgeItem, err error) {
+	f.ProblemID = pid
+	err = DB.Transaction(func(tx *gorm.DB) error {
+		query := f.query(tx).
+			Select("j.judgement_id, j.submission_id, j.user_id, j.problem_id, j.contest_id, j.verdict, s.language, s.code, " +
+				frozenJudgementSQL("j") + " AS frozen")
+		if err := query.Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: "j"}}).
+			Scan(&items).Error; err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return nil
+		}
+
+		ids := make([]string, len(items))
+		var stat ProblemStat
+		verdicts := make(map[string]int64)
+		languages := make(map[string]int64)
+		users := make(map[int64]bool) // 值为 true 表示重置前在这道题上有通过的记录
+		for i, item := range items {
+			ids[i] = item.JudgementID
+			// 封榜期间的记录没有计入统计，不需要扣除
+			if item.Frozen {
+				continue
+			}
+			stat.SubmitNum++
+			verdicts[item.Verdict]++
+			languages[item.Language]++
+			if item.Verdict == resp_code.VerdictAccepted {
+				stat.AcceptedNum++
+				users[item.UserID] = true
+			} else if !users[item.UserID] {
+				users[item.UserID] = false
+			}
+		}
+
+		err := tx.Model(&Judgement{}).Where("judgement_id IN ?", ids).
+			Updates(map[string]interface{}{
+				"verdict":         resp_code.VerdictPending,
+				"problem_version": version,
+				"memory_usage":    0,
+				"runtime":         0,
+				"output":          "",
+				"pass_num":        0,
+				"total_num":       0,
+			}).Error
+		if err != nil {
+			return err
+		}
+		// 重新评测不区分测试组，删除之前的测试组结果
+		if err = tx.Unscoped().Where("judgement_id IN ?", ids).Delete(&JudgementGroup{}).Error; err != nil {
+			return err
+		}
+
+		for uid, accepted := range users {
+			if accepted {
+				var remain int64
+				err = tx.Model(&Judgement{}).
+					Where("user_id = ? AND problem_id = ? AND verdict = ?", uid, pid, resp_code.VerdictAccepted).
+					Where(notFrozenJudgementSQL("judgement")).
+					Count(&remain).Error
+				if err != nil {
+					return err
+				}
+				// 用户在这道题上已经没有通过的记录
+				if remain == 0 {
+					stat.SolverNum++
+				}
+			}
+			if err = RecountPassNum(tx, uid); err != nil {
+				return err
+			}
+		}
+		return SubtractProblemStat(tx, pid, stat, verdicts, languages)
+	})
+	return
+}
+
+// RecountPassNum 根据评测记录重新计算用户通过的题目数量，不包括封榜期间的评测记录
+func RecountPassNum(tx *gorm.DB, uid int64) error {
+	return tx.Model(&User{}).Where("user_id = ?", uid).
+		UpdateColumn("finish_num", tx.Model(&Judgement{}).
+			Select("COUNT(DISTINCT problem_id)").
+			Where("user_id = ? AND verdict = ?", uid, resp_code.VerdictAccepted).
+			Where(notFrozenJudgementSQL("judgement"))).Error
+}
+
+// OverrideVerdict 手动修改提交的评测结果并记录审计日志，同时更新题目统计和用户的通过数量
+// 返回修改前的评测记录，提交不存在时返回 gorm.ErrRecordNotFound，等待评测时返回 ErrJudgementPending
+func OverrideVerdict(sid, verdict string, adminID int64, note string) (old *Judgement, err error) {
+	err = DB.Transaction(func(tx *gorm.DB) error {
+		var j Judgement
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("submission_id = ?", sid).First(&j).Error
+		if err != nil {
+			return err
+		}
+		if j.Verdict == resp_code.VerdictPending {
+			return ErrJudgementPending
+		}
+		old = &j
+
+		frozen, err := isJudgementFrozen(tx, j.JudgementID)
+		if err != nil {
+			return err
+		}
+		countAccepted := func() (n int64, err error) {
+			err = tx.Model(&Judgement{}).
+				Where("user_id = ? AND problem_id = ? AND verdict = ?", j.UID, j.ProblemID, resp_code.VerdictAccepted).
+				Where(notFrozenJudgementSQL("judgement")).
+				Count(&n).Error
+			return
+		}
+		before, err := countAccepted()
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&Judgement{}).Where("judgement_id = ?", j.JudgementID).
+			UpdateColumn("verdict", verdict).Error
+		if err != nil {
+			return err
+		}
+		after, err := countAccepted()
+		if err != nil {
+			return err
+		}
+
+		var solverDelta int64
+		switch {
+		case before == 0 && after > 0:
+			solverDelta = 1
+			err = AddPassNum(tx, j.UID, time.Now())
+		case before > 0 && after == 0:
+			solverDelta = -1
+			err = RecountPassNum(tx, j.UID)
+		}
+		if err != nil {
+			return err
+		}
+		// 封榜期间的评测记录不在统计中，揭晓时按修改后的结果计入
+		if !frozen {
+			if err = ChangeProblemStatVerdict(tx, j.ProblemID, j.Verdict, verdict, solverDelta); err != nil {
+				return err
+			}
+		}
+		return tx.Create(&VerdictOverride{
+			JudgementID:  j.JudgementID,
+			SubmissionID: sid,
+			AdminID:      adminID,
+			OldVerdict:   j.Verdict,
+			NewVerdict:   verdict,
+			Note:         note,
+		}).Error
+	})
+	return
+}
@@ -1,14 +1,56 @@
 package mysql
 
-import "gorm.io/gorm"
+import (
+	"gorm.io/gorm"
+	"online_judge/consts/resp_code"
+	"time"
+)
 
 // SaveSubmitCode 将提交记录保存在数据库
 func SaveSubmitCode(submission *Submission) error {
 	return DB.Create(submission).Error
 }
 
-// AddPassNum 题目AC，增加通过题目的数量
-func AddPassNum(uid int64) error {
-	return DB.Model(&User{}).Where("user_id = ?", uid).
-		UpdateColumn("finish_num", gorm.Expr("finish_num + ?", 1)).Error
+// AddPassNum 题目AC，增加通过题目的数量并记录通过时间
+func AddPassNum(db *gorm.DB, uid int64, at time.Time) error {
+	return db.Model(&User{}).Where("user_id = ?", uid).
+		UpdateColumns(map[string]interface{}{
+			"finish_num":       gorm.Expr("finish_num + ?", 1),
+			"last_accepted_at": at,
+		}).Error
+}
+
+// GetSubmission 获取提交记录
+func GetSubmission(sid string) (submission *Submission, err error) {
+	err = DB.Model(&Submission{}).Where("submission_id = ?", sid).First(&submission).Error
+	return
+}
+
+// AcceptedCode 用户在某道题目上最近一次通过的代码
+type AcceptedCode struct {
+	UserID         int64
+	Username       string
+	SubmissionID   string
+	Language       string
+	Code           string
+	SubmissionTime time.Time
+}
+
+// GetLatestAcceptedCodes 获取题目每个用户最近一次通过的代码，按提交时间从新到旧最多返回 limit 条
+func GetLatestAcceptedCodes(pid string, limit int) (codes []AcceptedCode, err error) {
+	ranked := DB.Table("judgement AS j").
+		Select("j.user_id, j.submission_id, s.language, s.code, s.submission_time, "+
+			"ROW_NUMBER() OVER (PARTITION BY j.user_id ORDER BY s.submission_time DESC) AS rn").
+		Joins("JOIN submission AS s ON s.submission_id = j.submission_id").
+		Where("j.problem_id = ? AND j.verdict = ?", pid, resp_code.VerdictAccepted).
+		Where("j.deleted_at IS NULL AND s.deleted_at IS NULL")
+
+	err = DB.Table("(?) AS t", ranked).
+		Select("t.user_id, u.username, t.submission_id, t.language, t.code, t.submission_time").
+		Joins("JOIN `user` AS u ON u.user_id = t.user_id").
+		Where("t.rn = 1").
+		Order("t.submission_time DESC").
+		Limit(limit).
+		Scan(&codes).Error
+	return
 }
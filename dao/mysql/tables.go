@@ -23,6 +23,7 @@ type User struct {
 	Email            string `gorm:"type:varchar(255);not null;column:email;uniqueIndex" json:"email"`
 	Role             bool   `gorm:"type:boolean;not null;column:role" json:"role"`
 	// true is Admin, false is user
+	LastAcceptedAt *time.Time `gorm:"type:timestamp NULL;column:last_accepted_at" json:"last_accepted_at"` // 最后一次通过新题目的时间，排名相同时较早者在前
 }
 
 //// Admin 管理员表 从用户表定位到ID，再来这里找
@@ -44,6 +45,9 @@ type ProblemWithFile struct {
 	MaxMemory    int    `gorm:"type:bigint;not null;column:max_memory" json:"max_memory"`             // 内存限制
 	InputPath    string `gorm:"type:varchar(255);not null;column:input_path" json:"input_path"`       // 输入文件路径
 	ExpectedPath string `gorm:"type:varchar(255);not null;column:expected_path" json:"expected_path"` // 期望输出文件路径
+	Checker
+	Interactor
+	StopOnPretestFailure bool `gorm:"type:boolean;default:false;column:stop_on_pretest_failure" json:"stop_on_pretest_failure"` // 预测试模式下预测试未通过时不再评测其余测试数据
 
 	TestCases []*TestCaseWithFile `gorm:"foreignKey:PID;references:ProblemID" json:"test_cases"` // 测试样例集
 }
@@ -57,6 +61,20 @@ type TestCaseWithFile struct {
 	ExpectedPath string `gorm:"type:text;not null;column:expected_path" json:"expected_path"` // 期望输出文件名
 }
 
+// Checker 题目的答案检查方式
+type Checker struct {
+	CheckerMode     string  `gorm:"type:varchar(16);not null;default:exact;column:checker_mode" json:"checker_mode"` // exact token float custom
+	CheckerEpsilon  float64 `gorm:"type:double;default:0;column:checker_epsilon" json:"checker_epsilon"`             // float 模式的误差，同时用作绝对误差和相对误差
+	CheckerCode     string  `gorm:"type:mediumtext;column:checker_code" json:"-"`                                    // custom 模式的检查器源代码
+	CheckerLanguage string  `gorm:"type:varchar(16);column:checker_language" json:"checker_language"`                // custom 模式的检查器语言
+}
+
+// Interactor 交互题的交互器，InteractorCode 为空表示普通题目
+type Interactor struct {
+	InteractorCode     string `gorm:"type:mediumtext;column:interactor_code" json:"-"`                        // 交互器源代码
+	InteractorLanguage string `gorm:"type:varchar(16);column:interactor_language" json:"interactor_language"` // 交互器语言
+}
+
 // Problems 题目信息
 type Problems struct {
 	Model
@@ -68,6 +86,9 @@ type Problems struct {
 	Difficulty        string             `gorm:"type:char(4);not null;column:difficulty" json:"difficulty"`                 // easy mid hard
 	MaxRuntime        int                `gorm:"type:bigint;not null;column:max_runtime" json:"max_runtime"`                // 时间限制
 	MaxMemory         int                `gorm:"type:bigint;not null;column:max_memory" json:"max_memory"`                  // 内存限制
+	Checker
+	Interactor
+	StopOnPretestFailure bool `gorm:"type:boolean;default:false;column:stop_on_pretest_failure" json:"stop_on_pretest_failure"` // 预测试模式下预测试未通过时不再评测其余测试数据
 
 	TestCases []*TestCase `gorm:"foreignKey:PID;references:ProblemID" json:"test_cases"` // 测试样例集
 }
@@ -75,10 +96,12 @@ type Problems struct {
 // TestCase 测试样例
 type TestCase struct {
 	Model
-	TID      string `gorm:"type:char(36);column:tid" json:"tid"`
-	PID      string `gorm:"type:char(36);not null;column:pid" json:"pid"` // 对应的题目ID
-	Input    string `gorm:"type:text;column:input" json:"input"`          // 输入
-	Expected string `gorm:"type:text;column:expected" json:"expected"`    // 期望输出
+	TID       string `gorm:"type:char(36);column:tid" json:"tid"`
+	PID       string `gorm:"type:char(36);not null;column:pid" json:"pid"`                   // 对应的题目ID
+	Input     string `gorm:"type:text;column:input" json:"input"`                            // 输入
+	Expected  string `gorm:"type:text;column:expected" json:"expected"`                      // 期望输出
+	IsSample  bool   `gorm:"type:boolean;default:false;column:is_sample" json:"is_sample"`   // 是否为公开的样例，其余测试数据只有管理员可见
+	IsPretest bool   `gorm:"type:boolean;default:false;column:is_pretest" json:"is_pretest"` // 是否为预测试，预测试模式下先单独评测
 }
 
 // Submission 提交记录
@@ -95,14 +118,112 @@ type Submission struct {
 // Judgement 评测结果
 type Judgement struct {
 	Model
-	UID          int64  `gorm:"type:bigint;foreignKey:references:UID;references:UserID;column:user_id" json:"user_id"`
-	JudgementID  string `gorm:"type:char(36);primaryKey;column:judgement_id" json:"judgement_id"`                                        // 评测ID
-	SubmissionID string `gorm:"type:char(36);foreignKey:SubmissionID;references:SubmissionID;column:submission_id" json:"submission_id"` //提交记录
-	ProblemID    string `gorm:"type:char(36);foreignKey:ProblemID;references:ProblemID;column:problem_id" json:"problem_id"`
-	Verdict      string `gorm:"type:varchar(20);column:verdict" json:"verdict"`      // 评测结果
-	MemoryUsage  int    `gorm:"type:bigint;column:memory_usage" json:"memory_usage"` // 内存用量
-	Runtime      int    `gorm:"type:bigint;not null;column:runtime" json:"runtime"`  // 运行时间
-	Output       string `gorm:"type:text;column:output" json:"output"`               // 错误信息比对输出
+	UID            int64  `gorm:"type:bigint;foreignKey:references:UID;references:UserID;column:user_id" json:"user_id"`
+	JudgementID    string `gorm:"type:char(36);primaryKey;column:judgement_id" json:"judgement_id"`                                        // 评测ID
+	SubmissionID   string `gorm:"type:char(36);foreignKey:SubmissionID;references:SubmissionID;column:submission_id" json:"submission_id"` //提交记录
+	ProblemID      string `gorm:"type:char(36);foreignKey:ProblemID;references:ProblemID;column:problem_id;index:idx_judgement_fastest,priority:1" json:"problem_id"`
+	Verdict        string `gorm:"type:varchar(20);column:verdict;index:idx_judgement_fastest,priority:2" json:"verdict"`      // 评测结果
+	MemoryUsage    int    `gorm:"type:bigint;column:memory_usage;index:idx_judgement_fastest,priority:4" json:"memory_usage"` // 内存用量
+	Runtime        int    `gorm:"type:bigint;not null;column:runtime;index:idx_judgement_fastest,priority:3" json:"runtime"`  // 运行时间
+	Output         string `gorm:"type:text;column:output" json:"output"`                                                      // 错误信息比对输出
+	PassNum        int    `gorm:"type:int;default:0;column:pass_num" json:"pass_num"`                                         // 通过的测试样例数量
+	TotalNum       int    `gorm:"type:int;default:0;column:total_num" json:"total_num"`                                       // 测试样例总数
+	ContestID      string `gorm:"type:char(36);index;column:contest_id" json:"contest_id"`                                    // 所属比赛，为空表示不在比赛中提交
+	ProblemVersion int    `gorm:"type:int;default:0;column:problem_version" json:"problem_version"`                           // 评测时题目的版本，0 表示记录版本之前的评测
+
+	Groups []*JudgementGroup `gorm:"foreignKey:JudgementID;references:JudgementID" json:"groups,omitempty"` // 按测试组评测时每组的结果
+}
+
+// JudgementGroup 按测试组评测时每个测试组的结果，例如预测试模式下的预测试和其余测试
+type JudgementGroup struct {
+	Model
+	ID          int64  `gorm:"primaryKey;autoIncrement;column:id" json:"-"`
+	JudgementID string `gorm:"type:char(36);not null;index;column:judgement_id" json:"-"`
+	Seq         int    `gorm:"type:int;not null;column:seq" json:"-"`                         // 测试组的评测顺序
+	Name        string `gorm:"type:varchar(32);not null;column:name" json:"name"`             // pretest main
+	Verdict     string `gorm:"type:varchar(20);not null;column:verdict" json:"verdict"`       // 评测结果，没有评测的测试组为 skipped
+	PassNum     int    `gorm:"type:int;default:0;column:pass_num" json:"pass_num"`            // 通过的测试样例数量
+	TotalNum    int    `gorm:"type:int;default:0;column:total_num" json:"total_num"`          // 测试组中的测试样例数量
+	Runtime     int    `gorm:"type:bigint;default:0;column:runtime" json:"runtime"`           // 运行时间
+	MemoryUsage int    `gorm:"type:bigint;default:0;column:memory_usage" json:"memory_usage"` // 内存用量
+}
+
+// VerdictOverride 管理员手动修改评测结果的审计记录
+type VerdictOverride struct {
+	Model
+	ID           int64  `gorm:"primaryKey;autoIncrement;column:id" json:"id"`
+	JudgementID  string `gorm:"type:char(36);not null;index;column:judgement_id" json:"judgement_id"`
+	SubmissionID string `gorm:"type:char(36);not null;column:submission_id" json:"submission_id"`
+	AdminID      int64  `gorm:"type:bigint;not null;column:admin_id" json:"admin_id"`            // 操作的管理员
+	OldVerdict   string `gorm:"type:varchar(20);not null;column:old_verdict" json:"old_verdict"` // 修改前的评测结果
+	NewVerdict   string `gorm:"type:varchar(20);not null;column:new_verdict" json:"new_verdict"` // 修改后的评测结果
+	Note         string `gorm:"type:varchar(1024);not null;column:note" json:"note"`             // 修改原因
+}
+
+// Contest 比赛
+type Contest struct {
+	Model
+	ContestID     string           `gorm:"type:char(36);primaryKey;column:contest_id" json:"contest_id"`
+	Title         string           `gorm:"type:varchar(255);not null;column:title" json:"title"`
+	Description   string           `gorm:"type:text;column:description" json:"description"`
+	Rule          string           `gorm:"type:varchar(8);not null;column:rule" json:"rule"`               // 赛制 icpc / oi
+	StartTime     time.Time        `gorm:"type:timestamp;column:start_time" json:"start_time"`             // 开始时间
+	EndTime       time.Time        `gorm:"type:timestamp;column:end_time" json:"end_time"`                 // 结束时间
+	Penalty       int              `gorm:"type:int;default:20;column:penalty" json:"penalty"`              // ICPC 每次错误提交的罚时：分钟
+	FreezeMinutes int              `gorm:"type:int;default:0;column:freeze_minutes" json:"freeze_minutes"` // 比赛结束前多少分钟封榜，0 表示不封榜
+	Unfrozen      bool             `gorm:"type:boolean;default:false;column:unfrozen" json:"unfrozen"`     // 管理员是否已经揭晓封榜结果
+	Problems      []ContestProblem `gorm:"foreignKey:ContestID;references:ContestID" json:"problems"`
+}
+
+// ContestProblem 比赛中的题目
+type ContestProblem struct {
+	Model
+	ContestID string `gorm:"type:char(36);primaryKey;column:contest_id" json:"contest_id"`
+	ProblemID string `gorm:"type:char(36);primaryKey;column:problem_id" json:"problem_id"`
+	Label     string `gorm:"type:varchar(8);not null;column:label" json:"label"` // 题号 A B C
+	Score     int    `gorm:"type:int;default:100;column:score" json:"score"`     // OI 赛制的满分
+}
+
+// ContestParticipant 比赛报名记录
+type ContestParticipant struct {
+	Model
+	ContestID string `gorm:"type:char(36);primaryKey;column:contest_id" json:"contest_id"`
+	UserID    int64  `gorm:"type:bigint;primaryKey;column:user_id" json:"user_id"`
+}
+
+// ProblemStat 题目的提交统计，评测结束时更新
+type ProblemStat struct {
+	Model
+	ProblemID   string `gorm:"type:char(36);primaryKey;column:problem_id" json:"problem_id"`
+	SubmitNum   int64  `gorm:"type:bigint;default:0;column:submit_num" json:"submit_num"`     // 提交次数
+	AcceptedNum int64  `gorm:"type:bigint;default:0;column:accepted_num" json:"accepted_num"` // 通过次数
+	SolverNum   int64  `gorm:"type:bigint;default:0;column:solver_num" json:"solver_num"`     // 通过的用户数量
+}
+
+// ProblemStatCount 题目按评测结果或编程语言分组的提交次数
+type ProblemStatCount struct {
+	Model
+	ProblemID string `gorm:"type:char(36);primaryKey;column:problem_id" json:"problem_id"`
+	Kind      string `gorm:"type:varchar(16);primaryKey;column:kind" json:"kind"` // verdict language
+	Name      string `gorm:"type:varchar(32);primaryKey;column:name" json:"name"` // 评测结果或语言名称
+	Count     int64  `gorm:"type:bigint;default:0;column:count" json:"count"`
+}
+
+// ProblemVersion 题目的不可变版本，题目描述、限制或测试数据变化后生成新版本
+type ProblemVersion struct {
+	Model
+	ProblemID  string `gorm:"type:char(36);primaryKey;column:problem_id" json:"problem_id"`
+	Version    int    `gorm:"type:int;primaryKey;autoIncrement:false;column:version" json:"version"`
+	Title      string `gorm:"type:varchar(255);not null;column:title" json:"title"`
+	Content    string `gorm:"type:text;not null;column:content" json:"content"`
+	Difficulty string `gorm:"type:char(4);not null;column:difficulty" json:"difficulty"`
+	MaxRuntime int    `gorm:"type:bigint;not null;column:max_runtime" json:"max_runtime"`
+	MaxMemory  int    `gorm:"type:bigint;not null;column:max_memory" json:"max_memory"`
+	Checker
+	Interactor
+	TestNum  int    `gorm:"type:int;not null;column:test_num" json:"test_num"`        // 测试数据数量
+	TestHash string `gorm:"type:char(64);not null;column:test_hash" json:"test_hash"` // 测试数据的 sha256
+	Hash     string `gorm:"type:char(64);not null;column:hash" json:"hash"`           // 整个版本的 sha256，用于判断题目是否变化
 }
 
 type ProblemCategory struct {
@@ -149,6 +270,38 @@ func (j *Judgement) TableName() string {
 	return "judgement"
 }
 
+func (g *JudgementGroup) TableName() string {
+	return "judgement_group"
+}
+
+func (v *VerdictOverride) TableName() string {
+	return "verdict_override"
+}
+
+func (c *Contest) TableName() string {
+	return "contest"
+}
+
+func (c *ContestProblem) TableName() string {
+	return "contest_problem"
+}
+
+func (c *ContestParticipant) TableName() string {
+	return "contest_participant"
+}
+
+func (p *ProblemStat) TableName() string {
+	return "problem_stat"
+}
+
+func (p *ProblemStatCount) TableName() string {
+	return "problem_stat_count"
+}
+
+func (p *ProblemVersion) TableName() string {
+	return "problem_version"
+}
+
 func (p *ProblemCategory) TableName() string {
 	return "problem_category"
 }
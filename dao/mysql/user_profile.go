@@ -0,0 +1,82 @@
+package mysql
+
+import (
+	"online_judge/consts/resp_code"
+	"time"
+)
+
+// NameCount 按名称分组的数量
+type NameCount struct {
+	ID    string
+	Name  string
+	Count int64
+}
+
+// DayCount 某一天的提交次数，Day 的格式为 2006-01-02
+type DayCount struct {
+	Day   string
+	Count int64
+}
+
+// GetSolvedByDifficulty 按难度统计用户通过的题目数量，不包括封榜期间的评测记录
+func GetSolvedByDifficulty(uid int64) (counts []NameCount, err error) {
+	err = DB.Table("judgement AS j").
+		Select("p.difficulty AS name, COUNT(DISTINCT j.problem_id) AS count").
+		Joins("JOIN problems AS p ON p.problem_id = j.problem_id AND p.deleted_at IS NULL").
+		Where("j.user_id = ? AND j.verdict = ? AND j.deleted_at IS NULL", uid, resp_code.VerdictAccepted).
+		Where(notFrozenJudgementSQL("j")).
+		Group("p.difficulty").
+		Scan(&counts).Error
+	return
+}
+
+// GetSolvedByCategory 按分类统计用户通过的题目数量，不包括封榜期间的评测记录
+func GetSolvedByCategory(uid int64) (counts []NameCount, err error) {
+	err = DB.Table("judgement AS j").
+		Select("c.category_id AS id, c.name AS name, COUNT(DISTINCT j.problem_id) AS count").
+		Joins("JOIN problem_category AS pc ON pc.problem_id = j.problem_id AND pc.deleted_at IS NULL").
+		Joins("JOIN category AS c ON c.category_id = pc.category_id AND c.deleted_at IS NULL").
+		Where("j.user_id = ? AND j.verdict = ? AND j.deleted_at IS NULL", uid, resp_code.VerdictAccepted).
+		Where(notFrozenJudgementSQL("j")).
+		Group("c.category_id, c.name").
+		Order("count DESC").
+		Scan(&counts).Error
+	return
+}
+
+// GetUserVerdictCount 统计用户的评测次数和通过次数，不包括封榜期间的评测记录
+func GetUserVerdictCount(uid int64) (total, accepted int64, err error) {
+	var row struct {
+		Total    int64
+		Accepted int64
+	}
+	err = DB.Model(&Judgement{}).
+		Select("COUNT(*) AS total, COALESCE(SUM(verdict = ?), 0) AS accepted", resp_code.VerdictAccepted).
+		Where("user_id = ?", uid).
+		Where(notFrozenJudgementSQL("judgement")).
+		Scan(&row).Error
+	return row.Total, row.Accepted, err
+}
+
+// GetUserLanguageCount 按编程语言统计用户的提交次数，次数多的在前
+func GetUserLanguageCount(uid int64) (counts []NameCount, err error) {
+	err = DB.Model(&Submission{}).
+		Select("language AS name, COUNT(*) AS count").
+		Where("user_id = ?", uid).
+		Group("language").
+		Order("count DESC").
+		Scan(&counts).Error
+	return
+}
+
+// GetUserDailySubmissions 按天统计用户在 from 之后的提交次数，按日期升序，from 为零值时统计全部时间
+func GetUserDailySubmissions(uid int64, from time.Time) (days []DayCount, err error) {
+	db := DB.Model(&Submission{}).
+		Select("DATE_FORMAT(submission_time, '%Y-%m-%d') AS day, COUNT(*) AS count").
+		Where("user_id = ?", uid)
+	if !from.IsZero() {
+		db = db.Where("submission_time >= ?", from)
+	}
+	err = db.Group("day").Order("day").Scan(&days).Error
+	return
+}
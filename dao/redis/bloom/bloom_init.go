@@ -1,10 +1,12 @@
 package bloom
 
 import (
+	"context"
 	"fmt"
 	"github.com/bits-and-blooms/bloom/v3"
 	"go.uber.org/zap"
 	"online_judge/dao/mysql"
+	"online_judge/dao/redis"
 	"online_judge/pkg/define"
 	"strconv"
 )
@@ -20,12 +22,13 @@ func InitBloomFilters() {
 	ReBuildBloomFilters()
 }
 
+// ReBuildBloomFilters 从数据库重新加载布隆过滤器，加载完成后再替换，加载期间仍然使用旧的过滤器
 func ReBuildBloomFilters() {
 	// 初始化题目详细信息的布隆过滤器
-	ProblemDetailBloomFilter = bloom.NewWithEstimates(nDetail, fpDetail)
+	detailFilter := bloom.NewWithEstimates(nDetail, fpDetail)
 
 	// 初始化题目列表的布隆过滤器
-	ProblemListBloomFilter = bloom.NewWithEstimates(nList, fpList)
+	listFilter := bloom.NewWithEstimates(nList, fpList)
 
 	// 从数据库加载所有题目到题目详细信息布隆过滤器
 	problemList, err := mysql.GetAllProblem()
@@ -40,7 +43,7 @@ func ReBuildBloomFilters() {
 			problem.ProblemID)
 		//cacheKeyDetail := fmt.Sprintf("%s", problem.ProblemID)
 
-		ProblemDetailBloomFilter.AddString(cacheKeyDetail)
+		detailFilter.AddString(cacheKeyDetail)
 	}
 
 	// 从数据库加载所有分页信息并生成缓存键到题目列表布隆过滤器
@@ -53,8 +56,35 @@ func ReBuildBloomFilters() {
 		cacheKey := fmt.Sprintf("%s:page-%d:size-%d",
 			define.GlobalCacheKeyMap.ProblemListPrefix,
 			page, pageSize)
-		ProblemListBloomFilter.AddString(cacheKey)
+		listFilter.AddString(cacheKey)
 	}
+	ProblemDetailBloomFilter = detailFilter
+	ProblemListBloomFilter = listFilter
+}
+
+// WatchProblemAdded 订阅其他进程新增题目的通知并重建布隆过滤器，ctx 取消后停止
+func WatchProblemAdded(ctx context.Context) error {
+	ps, err := redis.SubscribeProblemAdded(ctx)
+	if err != nil {
+		return err
+	}
+	go func() {
+		defer ps.Close()
+		ch := ps.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-ch:
+				if !ok {
+					return
+				}
+				zap.L().Info("bloom-WatchProblemAdded ", zap.String("problem_id", msg.Payload))
+				ReBuildBloomFilters()
+			}
+		}
+	}()
+	return nil
 }
 
 //
@@ -111,3 +111,37 @@ func (p *CacheGroup) DeleteProblemDetailCacheByPrefix(cacheKey string) error {
 	}
 	return nil
 }
+
+// ImportTestCases 导入测试数据，公开的样例可能变化，删除题目详情的缓存
+func (p *CacheGroup) ImportTestCases(req request.AdminImportTestCasesReq) response.ResponseWithData {
+	resp := AdminService.ImportTestCases(req)
+	if resp.Code != resp_code.Success {
+		return resp
+	}
+
+	cacheKey := fmt.Sprintf("%s:%s", define.GlobalCacheKeyMap.ProblemDetailPrefix, req.ProblemID)
+	if err := p.DeleteProblemDetailCacheByPrefix(cacheKey); err != nil {
+		zap.L().Error("services-ImportTestCases-DeleteProblemDetailCacheByPrefix ", zap.Error(err))
+		resp.Code = resp_code.DeleteCacheError
+	}
+	return resp
+}
+
+// ImportProblemPackage 从题目包创建题目，和 CreateProblem 一样刷新布隆过滤器和题目列表的缓存
+// 命令行工具导入时本进程的布隆过滤器没有用处，通过 redis 通知正在运行的 API 服务把新题目加入布隆过滤器
+func (p *CacheGroup) ImportProblemPackage(req request.AdminImportProblemPackageReq) response.ResponseWithData {
+	resp := AdminService.ImportProblemPackage(req)
+	if resp.Code != resp_code.Success {
+		return resp
+	}
+	bloom.ReBuildBloomFilters()
+	if err := redis.PublishProblemAdded(resp.Data.(string)); err != nil {
+		zap.L().Error("services-ImportProblemPackage-PublishProblemAdded ", zap.Error(err))
+	}
+
+	if err := p.DeleteProblemListCacheByPrefix(define.GlobalCacheKeyMap.ProblemListPrefix); err != nil {
+		zap.L().Error("services-ImportProblemPackage-DeleteProblemListCacheByPrefix ", zap.Error(err))
+		resp.Code = resp_code.DeleteCacheError
+	}
+	return resp
+}
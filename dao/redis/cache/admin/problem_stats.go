@@ -0,0 +1,49 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"online_judge/dao/redis"
+	"online_judge/pkg/define"
+	"time"
+)
+
+// defaultProblemStatsRefreshInterval 刷新题目统计缓存的间隔
+const defaultProblemStatsRefreshInterval = 30 * time.Second
+
+// RefreshProblemStats 删除统计数据有变化的题目的详情缓存和题目列表缓存，下次读取时重新统计
+func (p *CacheGroup) RefreshProblemStats() error {
+	pids, err := redis.PopDirtyProblemStats()
+	if err != nil || len(pids) == 0 {
+		return err
+	}
+	for _, pid := range pids {
+		cacheKey := fmt.Sprintf("%s:%s", define.GlobalCacheKeyMap.ProblemDetailPrefix, pid)
+		if err = p.DeleteProblemDetailCacheByPrefix(cacheKey); err != nil {
+			return err
+		}
+	}
+	return p.DeleteProblemListCacheByPrefix(define.GlobalCacheKeyMap.ProblemListPrefix)
+}
+
+// StartProblemStatsRefresh 定期刷新题目统计的缓存，评测频繁时合并多次刷新，ctx 取消后退出
+func (p *CacheGroup) StartProblemStatsRefresh(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultProblemStatsRefreshInterval
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := p.RefreshProblemStats(); err != nil {
+					zap.L().Error("cache-RefreshProblemStats ", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
@@ -3,6 +3,7 @@ package cache
 import (
 	"online_judge/dao/redis/cache/admin"
 	"online_judge/dao/redis/cache/auth"
+	"online_judge/dao/redis/cache/evaluation"
 	"online_judge/dao/redis/cache/leaderboard"
 	"online_judge/dao/redis/cache/problem"
 	"online_judge/dao/redis/cache/submission"
@@ -13,6 +14,7 @@ import (
 type CacheGroup struct {
 	CacheAdmin       admin.CacheGroup
 	CacheAuth        auth.CacheGroup
+	CacheEvaluation  evaluation.CacheGroup
 	CacheLeaderboard leaderboard.CacheGroup
 	CacheProblem     problem.CacheGroup
 	CacheSubmission  submission.CacheGroup
@@ -0,0 +1,11 @@
+package evaluation
+
+import "online_judge/services"
+
+type CacheGroup struct {
+	CacheEvaluation
+}
+
+var (
+	EvaluationService = services.ServiceGroupApp.EvaluationService
+)
@@ -0,0 +1,70 @@
+package evaluation
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"math/rand"
+	"online_judge/consts/resp_code"
+	redis2 "online_judge/dao/redis"
+	"online_judge/models/evaluation/request"
+	"online_judge/models/evaluation/response"
+	"online_judge/pkg/define"
+	"time"
+)
+
+type CacheEvaluation struct{}
+
+// GetEvaluationDetailWithCache 获取单条评测记录，只缓存已经评测完成的记录
+// 缓存中保存完整内容，返回前按当前用户隐藏代码
+func (e *CacheEvaluation) GetEvaluationDetailWithCache(req request.EvaluationDetailReq) (*response.EvaluationDetail, error) {
+	cacheKey := fmt.Sprintf("%s:%s", define.GlobalCacheKeyMap.EvaluationPrefix, req.ID)
+	detail, err := GetDetailWithCache(cacheKey, req.ID, func() (*response.EvaluationDetail, error) {
+		return EvaluationService.GetEvaluationResult(req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	detail.HidePrivate(req.ViewerID, req.IsAdmin)
+	return detail, nil
+}
+
+// GetDetailWithCache 缓存未命中时调用 load 从数据库获取评测记录
+func GetDetailWithCache(cacheKey, field string, load func() (*response.EvaluationDetail, error)) (*response.EvaluationDetail, error) {
+	var detail *response.EvaluationDetail
+	cachedData, err := redis2.Client.HGet(redis2.Ctx, cacheKey, field).Result()
+	if err == nil {
+		// 缓存命中，反序列化数据
+		if err = json.Unmarshal([]byte(cachedData), &detail); err == nil {
+			return detail, nil
+		}
+		zap.L().Error("cache-GetDetailWithCache-Unmarshal", zap.Error(err))
+	} else if err != redis.Nil {
+		// Redis 出错时直接查询数据库
+		zap.L().Error("cache-GetDetailWithCache-HGet", zap.Error(err))
+	}
+
+	detail, err = load()
+	if err != nil {
+		return nil, err
+	}
+	// 等待评测的记录还会变化，封榜期间的记录在揭晓后不再隐藏，都不缓存
+	if detail.Verdict == resp_code.VerdictPending || detail.Frozen {
+		return detail, nil
+	}
+
+	encodeData, err := json.Marshal(detail)
+	if err != nil {
+		zap.L().Error("cache-GetDetailWithCache-Marshal", zap.Error(err))
+		return detail, nil
+	}
+	if err = redis2.Client.HSet(redis2.Ctx, cacheKey, field, string(encodeData)).Err(); err != nil {
+		zap.L().Error("cache-GetDetailWithCache-HSet", zap.Error(err))
+		return detail, nil
+	}
+	// 设置随机的过期时间，防止缓存雪崩
+	expiration := time.Duration(5+rand.Intn(5)) * time.Hour
+	redis2.Client.Expire(redis2.Ctx, cacheKey, expiration)
+	return detail, nil
+}
@@ -0,0 +1,62 @@
+package leaderboard
+
+import (
+	"encoding/json"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"online_judge/consts/resp_code"
+	redis2 "online_judge/dao/redis"
+	"online_judge/models/common/response"
+	"online_judge/models/contest/request"
+	contest "online_judge/models/contest/response"
+	"time"
+)
+
+// scoreboardExpiration 排行榜快照的过期时间，比赛进行中排行榜不断变化，只做短时间缓存
+const scoreboardExpiration = 10 * time.Second
+
+type CacheLeaderboard struct{}
+
+// GetScoreboardWithCache 获取比赛排行榜，管理员和普通用户分别缓存
+func (l *CacheLeaderboard) GetScoreboardWithCache(req request.ContestReq) (resp response.ResponseWithData) {
+	view := redis2.ScoreboardPublic
+	if req.IsAdmin {
+		view = redis2.ScoreboardAdmin
+	}
+
+	cachedData, err := redis2.GetScoreboardSnapshot(req.ContestID, view)
+	if err == nil {
+		// 缓存命中，反序列化数据
+		var board contest.Scoreboard
+		if err = json.Unmarshal([]byte(cachedData), &board); err == nil {
+			resp.Code = resp_code.Success
+			resp.Data = &board
+			return
+		}
+		zap.L().Error("cache-GetScoreboardWithCache-Unmarshal", zap.Error(err))
+	} else if err != redis.Nil {
+		zap.L().Error("cache-GetScoreboardWithCache-GetScoreboardSnapshot", zap.Error(err))
+	}
+
+	resp = ContestService.GetScoreboard(req)
+	board, ok := resp.Data.(*contest.Scoreboard)
+	if resp.Code != resp_code.Success || !ok {
+		return
+	}
+	encodeData, err := json.Marshal(board)
+	if err != nil {
+		zap.L().Error("cache-GetScoreboardWithCache-Marshal", zap.Error(err))
+		return
+	}
+	// 快照不能跨过封榜时间，否则封榜后仍会返回实时结果
+	expiration := scoreboardExpiration
+	if board.FreezeTime != nil {
+		if untilFreeze := time.Until(*board.FreezeTime); untilFreeze > 0 && untilFreeze < expiration {
+			expiration = untilFreeze
+		}
+	}
+	if err = redis2.SetScoreboardSnapshot(req.ContestID, view, string(encodeData), expiration); err != nil {
+		zap.L().Error("cache-GetScoreboardWithCache-SetScoreboardSnapshot", zap.Error(err))
+	}
+	return
+}
@@ -1,4 +1,11 @@
 package leaderboard
 
+import "online_judge/services"
+
 type CacheGroup struct {
+	CacheLeaderboard
 }
+
+var (
+	ContestService = services.ServiceGroupApp.ContestService
+)
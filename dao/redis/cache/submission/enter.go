@@ -1,4 +1,11 @@
 package submission
 
+import "online_judge/services"
+
 type CacheGroup struct {
+	CacheSubmission
 }
+
+var (
+	EvaluationService = services.ServiceGroupApp.EvaluationService
+)
@@ -0,0 +1,24 @@
+package submission
+
+import (
+	"fmt"
+	"online_judge/dao/redis/cache/evaluation"
+	"online_judge/models/evaluation/request"
+	"online_judge/models/evaluation/response"
+	"online_judge/pkg/define"
+)
+
+type CacheSubmission struct{}
+
+// GetSubmissionDetailWithCache 根据提交ID获取评测记录，只缓存已经评测完成的记录
+func (s *CacheSubmission) GetSubmissionDetailWithCache(req request.EvaluationDetailReq) (*response.EvaluationDetail, error) {
+	cacheKey := fmt.Sprintf("%s:%s", define.GlobalCacheKeyMap.SubmissionPrefix, req.ID)
+	detail, err := evaluation.GetDetailWithCache(cacheKey, req.ID, func() (*response.EvaluationDetail, error) {
+		return EvaluationService.GetSubmissionResult(req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	detail.HidePrivate(req.ViewerID, req.IsAdmin)
+	return detail, nil
+}
@@ -1,4 +1,11 @@
 package user
 
+import "online_judge/services"
+
 type CacheGroup struct {
+	CacheUser
 }
+
+var (
+	UserService = services.ServiceGroupApp.UserService
+)
@@ -1,5 +1,49 @@
 package user
 
-// 登录或注册之后将缓存数据保存在 redis
+import (
+	"encoding/json"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"math/rand"
+	"online_judge/consts/resp_code"
+	redis2 "online_judge/dao/redis"
+	"online_judge/models/common/response"
+	"online_judge/models/user/request"
+	profile "online_judge/models/user/response"
+	"time"
+)
 
-// 第二次登录时直接从 redis 中获取即可
+type CacheUser struct{}
+
+// GetUserProfileWithCache 获取用户主页统计，用户有新的评测结果时缓存会被删除
+func (u *CacheUser) GetUserProfileWithCache(req request.GetUserDetailReq) (resp response.ResponseWithData) {
+	cachedData, err := redis2.GetUserProfile(req.UserID)
+	if err == nil {
+		// 缓存命中，反序列化数据
+		var data profile.UserProfile
+		if err = json.Unmarshal([]byte(cachedData), &data); err == nil {
+			resp.Code = resp_code.Success
+			resp.Data = &data
+			return
+		}
+		zap.L().Error("cache-GetUserProfileWithCache-Unmarshal", zap.Error(err))
+	} else if err != redis.Nil {
+		zap.L().Error("cache-GetUserProfileWithCache-GetUserProfile", zap.Error(err))
+	}
+
+	resp = UserService.GetUserProfile(req)
+	if resp.Code != resp_code.Success {
+		return
+	}
+	encodeData, err := json.Marshal(resp.Data)
+	if err != nil {
+		zap.L().Error("cache-GetUserProfileWithCache-Marshal", zap.Error(err))
+		return
+	}
+	// 随机过期时间，防止缓存雪崩
+	expiration := time.Duration(30+rand.Intn(30)) * time.Minute
+	if err = redis2.SetUserProfile(req.UserID, string(encodeData), expiration); err != nil {
+		zap.L().Error("cache-GetUserProfileWithCache-SetUserProfile", zap.Error(err))
+	}
+	return
+}
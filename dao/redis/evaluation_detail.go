@@ -0,0 +1,21 @@
+package redis
+
+import (
+	"fmt"
+	"online_judge/pkg/define"
+)
+
+// DeleteEvaluationDetails 删除评测记录的缓存，按评测ID和提交ID缓存的记录都会被删除
+func DeleteEvaluationDetails(judgementIDs, submissionIDs []string) error {
+	keys := make([]string, 0, len(judgementIDs)+len(submissionIDs))
+	for _, id := range judgementIDs {
+		keys = append(keys, fmt.Sprintf("%s:%s", define.GlobalCacheKeyMap.EvaluationPrefix, id))
+	}
+	for _, id := range submissionIDs {
+		keys = append(keys, fmt.Sprintf("%s:%s", define.GlobalCacheKeyMap.SubmissionPrefix, id))
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	return Client.Del(Ctx, keys...).Err()
+}
@@ -0,0 +1,116 @@
+package redis
+
+import (
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"math"
+	"online_judge/pkg/define"
+	"strconv"
+	"time"
+)
+
+// leaderboardTimeRange 分数中用于比较最后通过时间的部分，单位秒
+// 分数 = 通过数量 * leaderboardTimeRange + (leaderboardTimeRange - 1 - 最后通过时间)
+// 通过数量相同时最后通过时间较早者分数更高，float64 能精确表示通过数量不超过 90 万的分数
+const leaderboardTimeRange = 1e10
+
+// GlobalLeaderboardKey 全站排行榜，由评测 worker 增量更新
+func GlobalLeaderboardKey() string {
+	return fmt.Sprintf("%s:global", define.GlobalCacheKeyMap.LeaderboardPrefix)
+}
+
+// WindowLeaderboardKey 按时间段和分类统计的排行榜，period 为时间段的标识，例如 2024-W10、2024-03
+// 新的时间段使用新的 key，旧的 key 过期后自动删除
+func WindowLeaderboardKey(window, period, categoryID string) string {
+	if categoryID == "" {
+		categoryID = "all"
+	}
+	return fmt.Sprintf("%s:%s:%s:%s", define.GlobalCacheKeyMap.LeaderboardPrefix, window, period, categoryID)
+}
+
+func leaderboardLockKey() string {
+	return fmt.Sprintf("%s:reconcile", define.GlobalCacheKeyMap.LeaderboardPrefix)
+}
+
+// LeaderboardScore 根据通过数量和最后通过时间计算排行榜分数
+func LeaderboardScore(finishNum int64, lastAcceptedAt *time.Time) float64 {
+	var last int64
+	if lastAcceptedAt != nil {
+		last = lastAcceptedAt.Unix()
+	}
+	last = min(max(last, 0), leaderboardTimeRange-1)
+	return float64(finishNum)*leaderboardTimeRange + float64(leaderboardTimeRange-1-last)
+}
+
+// ParseLeaderboardScore 从排行榜分数还原通过数量和最后通过时间
+func ParseLeaderboardScore(score float64) (finishNum int64, lastAcceptedAt time.Time) {
+	finishNum = int64(math.Floor(score / leaderboardTimeRange))
+	last := leaderboardTimeRange - 1 - (int64(score) - finishNum*leaderboardTimeRange)
+	return finishNum, time.Unix(last, 0)
+}
+
+// SetLeaderboardScore 更新用户在全站排行榜中的分数
+func SetLeaderboardScore(uid int64, score float64) error {
+	return Client.ZAdd(Ctx, GlobalLeaderboardKey(), redis.Z{Score: score, Member: uid}).Err()
+}
+
+// RemoveLeaderboardScore 把没有通过题目的用户移出全站排行榜
+func RemoveLeaderboardScore(uid int64) error {
+	return Client.ZRem(Ctx, GlobalLeaderboardKey(), uid).Err()
+}
+
+// GetLeaderboardRange 按排名获取 [start, stop] 范围内的用户，排名从 0 开始
+func GetLeaderboardRange(key string, start, stop int64) ([]redis.Z, error) {
+	return Client.ZRevRangeWithScores(Ctx, key, start, stop).Result()
+}
+
+// GetLeaderboardSize 排行榜中的用户数量
+func GetLeaderboardSize(key string) (int64, error) {
+	return Client.ZCard(Ctx, key).Result()
+}
+
+// GetLeaderboardRank 获取用户的排名和分数，排名从 0 开始，不在排行榜中时返回 redis.Nil
+func GetLeaderboardRank(key string, uid int64) (rank int64, score float64, err error) {
+	member := strconv.FormatInt(uid, 10)
+	pipe := Client.Pipeline()
+	rankCmd := pipe.ZRevRank(Ctx, key, member)
+	scoreCmd := pipe.ZScore(Ctx, key, member)
+	if _, err = pipe.Exec(Ctx); err != nil {
+		return
+	}
+	return rankCmd.Val(), scoreCmd.Val(), nil
+}
+
+// ReplaceLeaderboard 用新的成员替换整个排行榜，先写入临时 key 再重命名，读取方不会看到不完整的排行榜
+// expiration 为 0 时不过期
+func ReplaceLeaderboard(key string, members []redis.Z, expiration time.Duration) error {
+	pipe := Client.TxPipeline()
+	if len(members) == 0 {
+		pipe.Del(Ctx, key)
+	} else {
+		tmp := key + ":tmp"
+		pipe.Del(Ctx, tmp)
+		pipe.ZAdd(Ctx, tmp, members...)
+		pipe.Rename(Ctx, tmp, key)
+		if expiration > 0 {
+			pipe.Expire(Ctx, key, expiration)
+		}
+	}
+	// 空的排行榜在 redis 中不存在，另外记录一个标记表示已经统计过
+	if expiration > 0 {
+		pipe.Set(Ctx, key+":ready", 1, expiration)
+	}
+	_, err := pipe.Exec(Ctx)
+	return err
+}
+
+// LeaderboardReady 按时间段统计的排行榜是否已经缓存
+func LeaderboardReady(key string) (bool, error) {
+	n, err := Client.Exists(Ctx, key+":ready").Result()
+	return n > 0, err
+}
+
+// TryLockLeaderboardReconcile 多个实例同时运行时只有一个实例执行校正
+func TryLockLeaderboardReconcile(expiration time.Duration) (bool, error) {
+	return Client.SetNX(Ctx, leaderboardLockKey(), 1, expiration).Result()
+}
@@ -0,0 +1,22 @@
+package redis
+
+import (
+	"context"
+	"github.com/redis/go-redis/v9"
+	"online_judge/pkg/define"
+)
+
+// PublishProblemAdded 通知所有 API 服务新增了题目，例如 packager 命令行工具导入的题目
+func PublishProblemAdded(pid string) error {
+	return Client.Publish(Ctx, define.GlobalCacheKeyMap.ProblemAddedPrefix, pid).Err()
+}
+
+// SubscribeProblemAdded 订阅新增题目的通知，返回时订阅已经生效，调用方负责关闭
+func SubscribeProblemAdded(ctx context.Context) (*redis.PubSub, error) {
+	ps := Client.Subscribe(ctx, define.GlobalCacheKeyMap.ProblemAddedPrefix)
+	if _, err := ps.Receive(ctx); err != nil {
+		_ = ps.Close()
+		return nil, err
+	}
+	return ps, nil
+}
@@ -0,0 +1,27 @@
+package redis
+
+import (
+	"fmt"
+	"online_judge/pkg/define"
+)
+
+// problemStatsDirtyKey 统计数据有变化、题目缓存需要刷新的题目集合
+func problemStatsDirtyKey() string {
+	return fmt.Sprintf("%s:dirty", define.GlobalCacheKeyMap.ProblemStatsPrefix)
+}
+
+// MarkProblemStatsDirty 记录统计数据有变化的题目，由 API 服务定期刷新题目缓存
+func MarkProblemStatsDirty(pid string) error {
+	return Client.SAdd(Ctx, problemStatsDirtyKey(), pid).Err()
+}
+
+// PopDirtyProblemStats 取出并清空统计数据有变化的题目
+func PopDirtyProblemStats() ([]string, error) {
+	pipe := Client.TxPipeline()
+	members := pipe.SMembers(Ctx, problemStatsDirtyKey())
+	pipe.Del(Ctx, problemStatsDirtyKey())
+	if _, err := pipe.Exec(Ctx); err != nil {
+		return nil, err
+	}
+	return members.Val(), nil
+}
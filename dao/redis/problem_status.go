@@ -0,0 +1,80 @@
+package redis
+
+import (
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"online_judge/consts"
+	"online_judge/pkg/define"
+	"strconv"
+	"time"
+)
+
+// problemStatusExpiration 用户题目状态缓存的过期时间，过期后从 MySQL 重新加载
+const problemStatusExpiration = 24 * time.Hour
+
+// problemStatusLoaded 标记缓存已经加载过的字段，没有提交记录的用户也会缓存
+const problemStatusLoaded = "_loaded"
+
+// updateProblemStatusScript 只在缓存存在时更新，已通过的题目不会被改回未通过
+var updateProblemStatusScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+if ARGV[2] == ARGV[3] then
+	redis.call('HSET', KEYS[1], ARGV[1], ARGV[2])
+else
+	redis.call('HSETNX', KEYS[1], ARGV[1], ARGV[2])
+end
+return 1
+`)
+
+// problemStatusKey 用户在各题目上的状态，field 为题目ID，value 为 solved 或 attempted
+func problemStatusKey(uid int64) string {
+	return fmt.Sprintf("%s:%s", define.GlobalCacheKeyMap.ProblemStatusPrefix, strconv.FormatInt(uid, 10))
+}
+
+// GetProblemStatus 获取用户在指定题目上的状态，loaded 为 false 表示缓存不存在
+func GetProblemStatus(uid int64, pids []string) (status map[string]string, loaded bool, err error) {
+	fields := append([]string{problemStatusLoaded}, pids...)
+	values, err := Client.HMGet(Ctx, problemStatusKey(uid), fields...).Result()
+	if err != nil || values[0] == nil {
+		return nil, false, err
+	}
+	status = make(map[string]string, len(pids))
+	for i, pid := range pids {
+		if v, ok := values[i+1].(string); ok {
+			status[pid] = v
+		}
+	}
+	return status, true, nil
+}
+
+// SetProblemStatus 用 MySQL 中的记录重建用户的题目状态缓存
+func SetProblemStatus(uid int64, status map[string]string) error {
+	values := make(map[string]interface{}, len(status)+1)
+	values[problemStatusLoaded] = 1
+	for pid, s := range status {
+		values[pid] = s
+	}
+	pipe := Client.TxPipeline()
+	pipe.Del(Ctx, problemStatusKey(uid))
+	pipe.HSet(Ctx, problemStatusKey(uid), values)
+	pipe.Expire(Ctx, problemStatusKey(uid), problemStatusExpiration)
+	_, err := pipe.Exec(Ctx)
+	return err
+}
+
+// UpdateProblemStatus 评测结束后更新用户的题目状态，缓存不存在时等待下次读取时加载
+func UpdateProblemStatus(uid int64, pid string, solved bool) error {
+	status := consts.ProblemStatusAttempted
+	if solved {
+		status = consts.ProblemStatusSolved
+	}
+	return updateProblemStatusScript.Run(Ctx, Client, []string{problemStatusKey(uid)},
+		pid, status, consts.ProblemStatusSolved).Err()
+}
+
+// DeleteProblemStatus 删除用户的题目状态缓存，重新评测后已通过的题目可能变为未通过
+func DeleteProblemStatus(uid int64) error {
+	return Client.Del(Ctx, problemStatusKey(uid)).Err()
+}
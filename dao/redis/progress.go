@@ -0,0 +1,34 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"online_judge/models/submission/response"
+	"online_judge/pkg/define"
+)
+
+// progressChannel 提交的评测进度频道
+func progressChannel(submissionID string) string {
+	return fmt.Sprintf("%s:%s", define.GlobalCacheKeyMap.ProgressPrefix, submissionID)
+}
+
+// PublishProgress 发布评测进度
+func PublishProgress(event *response.ProgressEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return Client.Publish(Ctx, progressChannel(event.SubmissionID), data).Err()
+}
+
+// SubscribeProgress 订阅提交的评测进度，返回时订阅已经生效，调用方负责关闭
+func SubscribeProgress(ctx context.Context, submissionID string) (*redis.PubSub, error) {
+	ps := Client.Subscribe(ctx, progressChannel(submissionID))
+	if _, err := ps.Receive(ctx); err != nil {
+		_ = ps.Close()
+		return nil, err
+	}
+	return ps, nil
+}
@@ -0,0 +1,32 @@
+package redis
+
+import (
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"online_judge/pkg/define"
+	"strconv"
+	"time"
+)
+
+// rateLimitScript 固定窗口计数，窗口内第一次请求时设置过期时间，返回窗口内的请求次数
+var rateLimitScript = redis.NewScript(`
+local count = redis.call('INCR', KEYS[1])
+if count == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return count
+`)
+
+// rateLimitKey 用户在某个接口上当前窗口的请求次数
+func rateLimitKey(name string, uid int64) string {
+	return fmt.Sprintf("%s:%s:%s", define.GlobalCacheKeyMap.RateLimitPrefix, name, strconv.FormatInt(uid, 10))
+}
+
+// AllowRequest 记录一次请求，窗口内的请求次数不超过 limit 时返回 true
+func AllowRequest(name string, uid int64, limit int64, window time.Duration) (bool, error) {
+	count, err := rateLimitScript.Run(Ctx, Client, []string{rateLimitKey(name, uid)}, window.Milliseconds()).Int64()
+	if err != nil {
+		return false, err
+	}
+	return count <= limit, nil
+}
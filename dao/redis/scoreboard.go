@@ -0,0 +1,37 @@
+package redis
+
+import (
+	"fmt"
+	"online_judge/pkg/define"
+	"time"
+)
+
+// 排行榜快照的两种视图
+const (
+	ScoreboardPublic = "public" // 封榜期间隐藏封榜后的结果
+	ScoreboardAdmin  = "admin"  // 管理员看到实时结果
+)
+
+func scoreboardKey(contestID string) string {
+	return fmt.Sprintf("%s:%s", define.GlobalCacheKeyMap.ScoreboardPrefix, contestID)
+}
+
+// GetScoreboardSnapshot 获取比赛排行榜快照，不存在时返回 redis.Nil
+func GetScoreboardSnapshot(contestID, view string) (string, error) {
+	return Client.HGet(Ctx, scoreboardKey(contestID), view).Result()
+}
+
+// SetScoreboardSnapshot 保存比赛排行榜快照，两种视图共用一个过期时间
+func SetScoreboardSnapshot(contestID, view, data string, expiration time.Duration) error {
+	key := scoreboardKey(contestID)
+	pipe := Client.TxPipeline()
+	pipe.HSet(Ctx, key, view, data)
+	pipe.Expire(Ctx, key, expiration)
+	_, err := pipe.Exec(Ctx)
+	return err
+}
+
+// DeleteScoreboardSnapshot 删除比赛排行榜快照，比赛信息变化或揭晓封榜结果时调用
+func DeleteScoreboardSnapshot(contestID string) error {
+	return Client.Del(Ctx, scoreboardKey(contestID)).Err()
+}
@@ -0,0 +1,26 @@
+package redis
+
+import (
+	"fmt"
+	"online_judge/pkg/define"
+	"time"
+)
+
+func userProfileKey(uid int64) string {
+	return fmt.Sprintf("%s:%d", define.GlobalCacheKeyMap.UserProfilePrefix, uid)
+}
+
+// GetUserProfile 获取缓存的用户主页统计，不存在时返回 redis.Nil
+func GetUserProfile(uid int64) (string, error) {
+	return Client.Get(Ctx, userProfileKey(uid)).Result()
+}
+
+// SetUserProfile 缓存用户主页统计
+func SetUserProfile(uid int64, data string, expiration time.Duration) error {
+	return Client.Set(Ctx, userProfileKey(uid), data, expiration).Err()
+}
+
+// DeleteUserProfile 用户有新的评测结果后删除主页统计的缓存
+func DeleteUserProfile(uid int64) error {
+	return Client.Del(Ctx, userProfileKey(uid)).Err()
+}
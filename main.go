@@ -7,13 +7,16 @@ import (
 	"go.uber.org/zap"
 	"log"
 	"net/http"
+	"online_judge/dao/mq"
 	"online_judge/dao/mysql"
 	"online_judge/dao/redis"
 	"online_judge/dao/redis/bloom"
 	"online_judge/dao/redis/cache"
 	"online_judge/logger"
+	"online_judge/pkg/language"
 	"online_judge/pkg/snowflake"
 	"online_judge/router"
+	"online_judge/services"
 	"online_judge/setting"
 	"os"
 	"os/signal"
@@ -63,17 +66,35 @@ func main() {
 	}
 	defer redis.Close()
 
+	// 初始化语言注册表
+	if err := language.Init(setting.Conf.Languages); err != nil {
+		fmt.Printf("init language failed, err: %v\n", err)
+		return
+	}
+
 	cache.Init()
 	// 雪花算法生成分布式ID
 	snowflake.Init()
 
 	// 初始化布隆过滤器
 	bloom.InitBloomFilters()
-	//// 5. init rabbitmq connection
-	//if err := mq.InitRabbitMQ(setting.Conf.RabbitMQConfig); err != nil {
-	//	fmt.Printf("init rabbitmq failed, err: %v\n", err)
-	//	return
-	//}
+	// 5. init rabbitmq connection
+	if err := mq.InitRabbitMQ(setting.Conf.RabbitMQConfig); err != nil {
+		fmt.Printf("init rabbitmq failed, err: %v\n", err)
+		return
+	}
+	defer mq.Close()
+	// 定期用 MySQL 校正 redis 排行榜
+	reconcileCtx, stopReconcile := context.WithCancel(context.Background())
+	defer stopReconcile()
+	// 其他进程导入题目后更新布隆过滤器
+	if err := bloom.WatchProblemAdded(reconcileCtx); err != nil {
+		fmt.Printf("watch problem added failed, err: %v\n", err)
+		return
+	}
+	services.ServiceGroupApp.LeaderboardService.StartReconcile(reconcileCtx, leaderboardReconcileInterval())
+	// 定期刷新提交统计有变化的题目缓存
+	cache.CacheGroupApp.CacheAdmin.StartProblemStatsRefresh(reconcileCtx, 0)
 	// 6. register route
 	r := router.SetUpRouter(setting.Conf.Mode)
 
@@ -110,3 +131,11 @@ func main() {
 	}
 	zap.L().Info("server exiting")
 }
+
+// leaderboardReconcileInterval 排行榜校正间隔，未配置时使用默认值
+func leaderboardReconcileInterval() time.Duration {
+	if setting.Conf.LeaderboardConfig == nil {
+		return 0
+	}
+	return time.Duration(setting.Conf.LeaderboardConfig.ReconcileInterval) * time.Second
+}
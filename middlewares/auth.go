@@ -4,6 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"online_judge/models/common/response"
 	"online_judge/pkg/jwt"
+	"strings"
 )
 
 // JWTUserAuthMiddleware 基于JWT的用户身份认证中间件
@@ -40,6 +41,7 @@ func JWTUserAuthMiddleware() func(c *gin.Context) {
 		// 将当前请求的useID信息和username保存到请求的上下文c上
 		c.Set(response.CtxUserIDKey, mc.UserID)
 		c.Set(response.CtxUserNameKey, mc.Username)
+		c.Set(response.CtxUserIsAdminKey, mc.UserIsAdmin)
 		c.Next() // 后续的处理请求的函数可以用过c.Get(CtxUserIDKey)来获取当前请求的用户信息
 	}
 }
@@ -86,6 +88,25 @@ func JWTAdminAuthMiddleware() func(c *gin.Context) {
 		// 将当前请求的useID信息和username保存到请求的上下文c上
 		c.Set(response.CtxUserIDKey, mc.UserID)
 		c.Set(response.CtxUserNameKey, mc.Username)
+		c.Set(response.CtxUserIsAdminKey, mc.UserIsAdmin)
 		c.Next() // 后续的处理请求的函数可以用过c.Get(CtxUserIDKey)来获取当前请求的用户信息
 	}
 }
+
+// JWTOptionalAuthMiddleware 可选的身份认证中间件，携带有效 token 时保存用户信息，否则按游客处理
+func JWTOptionalAuthMiddleware() func(c *gin.Context) {
+	return func(c *gin.Context) {
+		authHeader := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if authHeader == "" {
+			c.Next()
+			return
+		}
+		mc, err := jwt.ParseToken(authHeader)
+		if err == nil {
+			c.Set(response.CtxUserIDKey, mc.UserID)
+			c.Set(response.CtxUserNameKey, mc.Username)
+			c.Set(response.CtxUserIsAdminKey, mc.UserIsAdmin)
+		}
+		c.Next()
+	}
+}
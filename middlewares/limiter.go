@@ -3,7 +3,10 @@ package middlewares
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/juju/ratelimit"
+	"go.uber.org/zap"
 	"net/http"
+	"online_judge/dao/redis"
+	"online_judge/models/common/response"
 	"time"
 )
 
@@ -22,3 +25,22 @@ func RateLimiterMiddleWare(fillInterval time.Duration, cap, quantum int64) gin.H
 		c.Next()
 	}
 }
+
+// UserRateLimiterMiddleWare 按用户限流，每个用户在 window 内最多请求 limit 次，需要放在登录鉴权之后
+// 计数保存在 redis 中，多个 API 实例共享，redis 不可用时不限流
+func UserRateLimiterMiddleWare(name string, limit int64, window time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		uid := c.GetInt64(response.CtxUserIDKey)
+		allowed, err := redis.AllowRequest(name, uid, limit, window)
+		if err != nil {
+			zap.L().Error("middlewares-UserRateLimiterMiddleWare-AllowRequest ", zap.Error(err))
+			allowed = true
+		}
+		if !allowed {
+			response.ResponseError(c, response.CodeTooManyRequests)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
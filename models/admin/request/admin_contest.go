@@ -0,0 +1,23 @@
+package request
+
+import "time"
+
+// AdminContestReq 创建或更新比赛，题目列表整体替换
+type AdminContestReq struct {
+	ContestID     string                   `json:"contest_id"`
+	Title         string                   `json:"title" binding:"required"`
+	Description   string                   `json:"description"`
+	Rule          string                   `json:"rule" binding:"required"` // 赛制 icpc / oi
+	StartTime     time.Time                `json:"start_time" binding:"required"`
+	EndTime       time.Time                `json:"end_time" binding:"required"`
+	Penalty       int                      `json:"penalty"`        // ICPC 每次错误提交的罚时：分钟，为 0 时使用默认值
+	FreezeMinutes int                      `json:"freeze_minutes"` // 比赛结束前多少分钟封榜，0 表示不封榜
+	Problems      []AdminContestProblemReq `json:"problems" binding:"required,min=1,dive"`
+}
+
+// AdminContestProblemReq 比赛中的题目
+type AdminContestProblemReq struct {
+	ProblemID string `json:"problem_id" binding:"required"`
+	Label     string `json:"label"` // 题号，为空时按顺序生成 A B C
+	Score     int    `json:"score"` // OI 赛制的满分，为 0 时使用默认值
+}
@@ -15,17 +15,36 @@ type AdminCreateProblemReq struct {
 	MaxMemory  int         `form:"max_memory" json:"max_memory" order:"5"`   // 内存限制
 	Category   []string    `form:"category" json:"category" order:"6"`       // 题目分类
 	TestCases  []*TestCase `form:"test_cases" json:"test_cases" order:"7"`   // 测试样例集
+	ProblemChecker
+	ProblemInteractor
+	StopOnPretestFailure bool `form:"stop_on_pretest_failure" json:"stop_on_pretest_failure"` // 预测试模式下预测试未通过时不再评测其余测试数据
 
 	//RedisClient *redis.Client   `json:"-"`
 	//Ctx         context.Context `json:"-"`
 }
 
+// ProblemChecker 题目的答案检查方式，更新题目时 checker_mode 为空表示不修改
+type ProblemChecker struct {
+	CheckerMode     string  `form:"checker_mode" json:"checker_mode"`         // exact token float custom，默认 exact
+	CheckerEpsilon  float64 `form:"checker_epsilon" json:"checker_epsilon"`   // float 模式的误差
+	CheckerCode     string  `form:"checker_code" json:"checker_code"`         // custom 模式的检查器源代码
+	CheckerLanguage string  `form:"checker_language" json:"checker_language"` // custom 模式的检查器语言
+}
+
+// ProblemInteractor 交互题的交互器，更新题目时 interactor_code 为空表示不修改
+type ProblemInteractor struct {
+	InteractorCode     string `form:"interactor_code" json:"interactor_code"`         // 交互器源代码
+	InteractorLanguage string `form:"interactor_language" json:"interactor_language"` // 交互器语言
+}
+
 // TestCase 测试样例
 type TestCase struct {
-	TID      string `json:"-"`                                  // testCase ID
-	PID      string `json:"-"`                                  // 对应的题目ID
-	Input    string `form:"input" json:"input" order:"1"`       // 输入
-	Expected string `form:"expected" json:"expected" order:"2"` // 期望输出
+	TID       string `json:"-"`                                      // testCase ID
+	PID       string `json:"-"`                                      // 对应的题目ID
+	Input     string `form:"input" json:"input" order:"1"`           // 输入
+	Expected  string `form:"expected" json:"expected" order:"2"`     // 期望输出
+	IsSample  bool   `form:"is_sample" json:"is_sample" order:"3"`   // 是否为公开的样例
+	IsPretest bool   `form:"is_pretest" json:"is_pretest" order:"4"` // 是否为预测试
 }
 
 // AdminUpdateProblemReq 更新题目
@@ -38,6 +57,9 @@ type AdminUpdateProblemReq struct {
 	MaxMemory  int         `form:"max_memory" json:"max_memory" order:"5"`   // 内存限制
 	Category   []string    `form:"category" json:"category" order:"6"`       // 题目分类
 	TestCases  []*TestCase `form:"test_cases" json:"test_cases" order:"7"`   // 测试样例集
+	ProblemChecker
+	ProblemInteractor
+	StopOnPretestFailure *bool `form:"stop_on_pretest_failure" json:"stop_on_pretest_failure"` // 为空表示不修改
 	//
 	//RedisClient *redis.Client   `json:"-"`
 	//Ctx         context.Context `json:"-"`
@@ -62,6 +84,9 @@ type AdminCreateProblemWithFileReq struct {
 	InputDst          string              `form:"input_dst" json:"input_dst"`                       // 输入文件保存的地址
 	ExpectedDst       string              `form:"expected_dst" json:"expected_dst"`                 // 输出文件保存的地址
 	TestCasesWithFile []*TestCaseWithFile `form:"test_cases_with_file" json:"test_cases_with_file"` // 测试样例集(file)
+	ProblemChecker
+	ProblemInteractor
+	StopOnPretestFailure bool `form:"stop_on_pretest_failure" json:"stop_on_pretest_failure"` // 预测试模式下预测试未通过时不再评测其余测试数据
 
 	RedisClient *redis.Client   `form:"redis_client" json:"redis_client"`
 	Ctx         context.Context `form:"context" json:"context"`
@@ -104,6 +129,9 @@ type AdminUpdateProblemWithFileReq struct {
 	InputDst          string              `form:"input_dst" json:"input_dst"`                       // 输入文件保存的地址
 	ExpectedDst       string              `form:"expected_dst" json:"expected_dst"`                 // 输出文件保存的地址
 	TestCasesWithFile []*TestCaseWithFile `form:"test_cases_with_file" json:"test_cases_with_file"` // 测试样例集(file)
+	ProblemChecker
+	ProblemInteractor
+	StopOnPretestFailure *bool `form:"stop_on_pretest_failure" json:"stop_on_pretest_failure"` // 为空表示不修改
 
 	RedisClient *redis.Client   `form:"redis_client" json:"redis_client"`
 	Ctx         context.Context `form:"context" json:"context"`
@@ -0,0 +1,8 @@
+package request
+
+// AdminSimilarityReq 获取题目的代码相似度报告
+type AdminSimilarityReq struct {
+	ProblemID string  `form:"-" json:"problem_id"`
+	Threshold float64 `form:"threshold" json:"threshold" binding:"omitempty,gt=0,lte=1"` // 相似度阈值，默认 0.6
+	Limit     int     `form:"limit" json:"limit" binding:"omitempty,gt=0,lte=500"`       // 最多比较的通过代码数量，默认 500
+}
@@ -0,0 +1,16 @@
+package request
+
+import "io"
+
+// AdminImportTestCasesReq 从压缩包导入测试数据
+type AdminImportTestCasesReq struct {
+	ProblemID string
+	Archive   io.ReaderAt // 上传的 zip 文件
+	Size      int64
+}
+
+// AdminImportProblemPackageReq 从题目包导入题目
+type AdminImportProblemPackageReq struct {
+	Archive io.ReaderAt // 上传的题目包
+	Size    int64
+}
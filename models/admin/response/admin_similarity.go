@@ -0,0 +1,41 @@
+package response
+
+import "time"
+
+// SimilarityReport 题目的代码相似度报告
+type SimilarityReport struct {
+	ProblemID string           `json:"problem_id"`
+	Threshold float64          `json:"threshold"`
+	Compared  int              `json:"compared"` // 参与比较的通过代码数量，每个用户只取最近一次通过的代码
+	Pairs     []SimilarityPair `json:"pairs"`    // 按相似度从高到低排序
+}
+
+// SimilarityPair 一对可疑的代码
+type SimilarityPair struct {
+	Score     float64              `json:"score"`   // score_a 和 score_b 中较大的一个
+	ScoreA    float64              `json:"score_a"` // A 的指纹中出现在 B 中的比例
+	ScoreB    float64              `json:"score_b"` // B 的指纹中出现在 A 中的比例
+	A         SimilaritySubmission `json:"a"`
+	B         SimilaritySubmission `json:"b"`
+	Fragments []SimilarityFragment `json:"fragments"` // 两份代码中相同的片段
+}
+
+// SimilaritySubmission 参与比较的提交记录
+type SimilaritySubmission struct {
+	SubmissionID   string    `json:"submission_id"`
+	UserID         int64     `json:"user_id"`
+	Username       string    `json:"username"`
+	Language       string    `json:"language"`
+	SubmissionTime time.Time `json:"submission_time"`
+}
+
+// SimilarityFragment 相同的片段，行号从 1 开始，包含两端
+type SimilarityFragment struct {
+	AStartLine int    `json:"a_start_line"`
+	AEndLine   int    `json:"a_end_line"`
+	BStartLine int    `json:"b_start_line"`
+	BEndLine   int    `json:"b_end_line"`
+	ACode      string `json:"a_code"`
+	BCode      string `json:"b_code"`
+	Tokens     int    `json:"tokens"` // 片段包含的词法单元数量
+}
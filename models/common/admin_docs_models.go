@@ -48,3 +48,45 @@ type DeleteProblemResponse struct {
 	Msg  interface{} `json:"msg"`
 	Data interface{} `json:"data"`
 }
+
+type GetProblemTestCasesResponse struct {
+	Code int `json:"code"` // "1000 获取成功" "1021 题目ID不存在" "1008 需要登录" "1014 服务器内部错误"
+
+	Msg  interface{} `json:"msg"`
+	Data interface{} `json:"data"` // omitempty 字段为空就忽略
+}
+
+type ImportTestCasesResponse struct {
+	Code int `json:"code"` // "1000 导入成功" "1001 参数错误" "1021 题目ID不存在" "1049 测试数据压缩包格式错误" "1014 服务器内部错误"
+
+	Msg  interface{} `json:"msg"`
+	Data interface{} `json:"data"` // omitempty 字段为空就忽略
+}
+
+type ImportProblemPackageResponse struct {
+	Code int `json:"code"` // "1000 导入成功" "1001 参数错误" "1019 题目标题已存在" "1050 题目包格式错误" "1014 服务器内部错误"
+
+	Msg  interface{} `json:"msg"`
+	Data interface{} `json:"data"` // omitempty 字段为空就忽略
+}
+
+type SimilarityReportResponse struct {
+	Code int `json:"code"` // "1000 获取成功" "1001 参数错误" "1021 题目ID不存在" "1014 服务器内部错误"
+
+	Msg  interface{} `json:"msg"`
+	Data interface{} `json:"data"` // omitempty 字段为空就忽略
+}
+
+type RejudgeResponse struct {
+	Code int `json:"code"` // "1000 已重新加入评测队列" "1001 参数错误" "1021 题目ID不存在" "1014 服务器内部错误"
+
+	Msg  interface{} `json:"msg"`
+	Data interface{} `json:"data"` // omitempty 字段为空就忽略
+}
+
+type OverrideVerdictResponse struct {
+	Code int `json:"code"` // "1000 修改成功" "1001 参数错误" "1040 提交记录不存在" "1051 评测尚未完成" "1014 服务器内部错误"
+
+	Msg  interface{} `json:"msg"`
+	Data interface{} `json:"data"` // omitempty 字段为空就忽略
+}
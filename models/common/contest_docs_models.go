@@ -0,0 +1,8 @@
+package common
+
+type ContestResponse struct {
+	Code int `json:"code"` // "1000 获取成功" "1042 比赛不存在" "1045 比赛已经结束" "1008 需要登录" "1014 服务器内部错误"
+
+	Msg  interface{} `json:"msg"`
+	Data interface{} `json:"data"` // omitempty 字段为空就忽略
+}
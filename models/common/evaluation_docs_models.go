@@ -0,0 +1,8 @@
+package common
+
+type GetEvaluationResponse struct {
+	Code int `json:"code"` // "1000 获取成功" "1001 请求参数错误" "1040 提交记录不存在" "1041 评测记录不存在" "1014 服务器内部错误"
+
+	Msg  interface{} `json:"msg"`
+	Data interface{} `json:"data"` // omitempty 字段为空就忽略
+}
@@ -0,0 +1,8 @@
+package common
+
+type GetLanguageListResponse struct {
+	Code int `json:"code"` // "1000 获取语言列表成功"
+
+	Msg  interface{} `json:"msg"`
+	Data interface{} `json:"data"` // omitempty 字段为空就忽略
+}
@@ -7,8 +7,9 @@ import (
 )
 
 const (
-	CtxUserIDKey   = "userID"
-	CtxUserNameKey = "username"
+	CtxUserIDKey      = "userID"
+	CtxUserNameKey    = "username"
+	CtxUserIsAdminKey = "userIsAdmin"
 )
 
 type ResponseData struct {
@@ -33,6 +34,15 @@ func ResponseErrorWithMsg(c *gin.Context, code ResCode, msg interface{}) {
 	})
 }
 
+// ResponseErrorWithData 返回错误码和帮助定位错误的数据
+func ResponseErrorWithData(c *gin.Context, code ResCode, data interface{}) {
+	c.JSON(http.StatusOK, &ResponseData{
+		Code: code,
+		Msg:  code.Msg(),
+		Data: data,
+	})
+}
+
 func ResponseSuccess(c *gin.Context, data interface{}) {
 	//responseData := make(map[string]interface{})
 	//val := reflect.ValueOf(data)
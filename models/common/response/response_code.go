@@ -52,6 +52,20 @@ const (
 	CodeCategoryIDNotExist
 	CodeCategoryTypeAlreadyExist
 	CodeProblemListNotFound
+	CodeInvalidChecker
+	CodeSubmissionNotExist
+	CodeJudgementNotExist
+	CodeContestNotExist
+	CodeContestNotRunning
+	CodeContestNotRegistered
+	CodeContestAlreadyEnded
+	CodeProblemNotInContest
+	CodeInvalidContest
+	CodeContestNotEnded
+	CodeInvalidTestCaseArchive
+	CodeInvalidProblemPackage
+	CodeJudgementPending
+	CodeTooManyRequests
 )
 
 var codeMsgMap = map[ResCode]string{
@@ -93,6 +107,20 @@ var codeMsgMap = map[ResCode]string{
 	CodeCategoryIDNotExist:       "分类ID不存在",
 	CodeCategoryTypeAlreadyExist: "分类已经存在",
 	CodeProblemListNotFound:      "找不到题目列表",
+	CodeInvalidChecker:           "检查器参数错误",
+	CodeSubmissionNotExist:       "提交记录不存在",
+	CodeJudgementNotExist:        "评测记录不存在",
+	CodeContestNotExist:          "比赛不存在",
+	CodeContestNotRunning:        "不在比赛时间内",
+	CodeContestNotRegistered:     "未报名该比赛",
+	CodeContestAlreadyEnded:      "比赛已经结束",
+	CodeProblemNotInContest:      "题目不在该比赛中",
+	CodeInvalidContest:           "比赛参数错误",
+	CodeContestNotEnded:          "比赛尚未结束",
+	CodeInvalidTestCaseArchive:   "测试数据压缩包格式错误",
+	CodeInvalidProblemPackage:    "题目包格式错误",
+	CodeJudgementPending:         "评测尚未完成",
+	CodeTooManyRequests:          "请求过于频繁，请稍后再试",
 }
 
 func (c ResCode) Msg() string {
@@ -6,3 +6,10 @@ type SubmitCodeResponse struct {
 	Msg  interface{} `json:"msg"`
 	Data interface{} `json:"data"` // omitempty 字段为空就忽略
 }
+
+type RunCodeResponse struct {
+	Code int `json:"code"` // "1000 运行完成" "1001 参数错误" "1021 题目ID不存在" "1024 不支持的语言类型" "1052 请求过于频繁" "1014 服务器内部错误"
+
+	Msg  interface{} `json:"msg"`
+	Data interface{} `json:"data"` // omitempty 字段为空就忽略
+}
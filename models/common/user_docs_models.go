@@ -34,3 +34,10 @@ type GetUserIDResponse struct {
 	Msg  interface{} `json:"msg"`
 	Data interface{} `json:"data"` // omitempty 字段为空就忽略
 }
+
+type GetUserProfileResponse struct {
+	Code int `json:"code"` //"1000 获取用户信息成功" "1001 参数错误" "1004 没有此用户ID" "1014 服务器内部错误"
+
+	Msg  interface{} `json:"msg"`
+	Data interface{} `json:"data"` // omitempty 字段为空就忽略
+}
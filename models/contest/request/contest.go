@@ -0,0 +1,14 @@
+package request
+
+// ContestListReq 分页获取比赛列表
+type ContestListReq struct {
+	Page int `json:"page" form:"page"`
+	Size int `json:"size" form:"size"`
+}
+
+// ContestReq 获取单个比赛
+type ContestReq struct {
+	ContestID string `uri:"contest_id" json:"contest_id"`
+	UserID    int64  `json:"-"` // 当前用户ID，未登录为 0
+	IsAdmin   bool   `json:"-"` // 当前用户是否为管理员
+}
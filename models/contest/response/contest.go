@@ -0,0 +1,86 @@
+package response
+
+import "time"
+
+// ContestListItem 比赛列表中的比赛
+type ContestListItem struct {
+	ContestID string    `json:"contest_id"`
+	Title     string    `json:"title"`
+	Rule      string    `json:"rule"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+}
+
+// ContestList 比赛列表
+type ContestList struct {
+	Total int64             `json:"total"`
+	List  []ContestListItem `json:"list"`
+}
+
+// ContestDetail 比赛详细，比赛开始前只有管理员能看到题目
+type ContestDetail struct {
+	ContestListItem
+	Description string           `json:"description"`
+	Penalty     int              `json:"penalty"`
+	Registered  bool             `json:"registered"` // 当前用户是否已经报名
+	Problems    []ContestProblem `json:"problems"`
+}
+
+// ContestProblem 比赛中的题目
+type ContestProblem struct {
+	ProblemID string `json:"problem_id"`
+	Label     string `json:"label"`
+	Score     int    `json:"score,omitempty"`
+}
+
+// Scoreboard 比赛排行榜
+type Scoreboard struct {
+	ContestID  string           `json:"contest_id"`
+	Rule       string           `json:"rule"`
+	Frozen     bool             `json:"frozen"`                // 是否封榜，封榜后的提交显示为待揭晓
+	FreezeTime *time.Time       `json:"freeze_time,omitempty"` // 封榜开始时间，不封榜时为空
+	Problems   []ContestProblem `json:"problems"`
+	Rows       []ScoreboardRow  `json:"rows"`
+}
+
+// ScoreboardRow 排行榜中的一行
+type ScoreboardRow struct {
+	Rank     int             `json:"rank"`
+	UserID   int64           `json:"user_id"`
+	Username string          `json:"username"`
+	Solved   int             `json:"solved"`  // 通过的题目数量
+	Penalty  int64           `json:"penalty"` // ICPC 总罚时：分钟
+	Score    int             `json:"score"`   // OI 总分
+	Results  []ProblemResult `json:"results"` // 与 Problems 顺序一致
+}
+
+// ProblemResult 用户在一道题上的结果
+type ProblemResult struct {
+	Label    string `json:"label"`
+	Solved   bool   `json:"solved"`
+	Attempts int    `json:"attempts"`            // 计入罚时的提交次数，包含通过的那一次
+	SolvedAt int64  `json:"solved_at,omitempty"` // ICPC 通过时距离比赛开始的分钟数
+	Score    int    `json:"score"`               // OI 得分
+	Pending  int    `json:"pending,omitempty"`   // 封榜后待揭晓的提交次数
+}
+
+// Resolver 封榜结果的揭晓顺序
+type Resolver struct {
+	ContestID string         `json:"contest_id"`
+	Rule      string         `json:"rule"`
+	Initial   *Scoreboard    `json:"initial"` // 揭晓前的排行榜
+	Steps     []ResolverStep `json:"steps"`
+}
+
+// ResolverStep 揭晓一个用户在一道题上的结果
+type ResolverStep struct {
+	UserID     int64         `json:"user_id"`
+	Username   string        `json:"username"`
+	Label      string        `json:"label"`
+	Result     ProblemResult `json:"result"` // 揭晓后的结果
+	RankBefore int           `json:"rank_before"`
+	RankAfter  int           `json:"rank_after"`
+	Solved     int           `json:"solved"`  // 揭晓后的通过题目数量
+	Penalty    int64         `json:"penalty"` // 揭晓后的总罚时
+	Score      int           `json:"score"`   // 揭晓后的总分
+}
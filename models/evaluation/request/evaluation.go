@@ -0,0 +1,22 @@
+package request
+
+// EvaluationDetailReq 获取单条评测记录
+type EvaluationDetailReq struct {
+	ID       string `uri:"id" json:"id"` // 评测ID或提交ID
+	ViewerID int64  `json:"-"`           // 当前用户ID，未登录为 0
+	IsAdmin  bool   `json:"-"`           // 当前用户是否为管理员
+}
+
+// EvaluationListReq 分页获取评测记录
+type EvaluationListReq struct {
+	UserID    int64  `form:"-" json:"user_id"`
+	ProblemID string `form:"-" json:"problem_id"`
+	Verdict   string `form:"verdict" json:"verdict"`   // 评测结果
+	Language  string `form:"language" json:"language"` // 编程语言
+	From      string `form:"from" json:"from"`         // 起始日期，2006-01-02 或 RFC3339
+	To        string `form:"to" json:"to"`             // 结束日期，只有日期时包含当天
+	Cursor    string `form:"cursor" json:"cursor"`     // 上一页返回的 next_cursor
+	Size      int    `form:"size" json:"size"`         // 每页数量
+	ViewerID  int64  `form:"-" json:"-"`
+	IsAdmin   bool   `form:"-" json:"-"`
+}
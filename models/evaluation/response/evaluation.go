@@ -0,0 +1,56 @@
+package response
+
+import (
+	"online_judge/consts/resp_code"
+	"time"
+)
+
+// EvaluationDetail 评测记录
+type EvaluationDetail struct {
+	JudgementID    string    `json:"judgement_id"`
+	SubmissionID   string    `json:"submission_id"`
+	UserID         int64     `json:"user_id"`
+	ProblemID      string    `json:"problem_id"`
+	Language       string    `json:"language"`
+	Verdict        string    `json:"verdict"`
+	Runtime        int       `json:"runtime"`
+	MemoryUsage    int       `json:"memory_usage"`
+	Output         string    `json:"output,omitempty"` // 错误信息，只对提交者和管理员可见
+	Code           string    `json:"code,omitempty"`   // 代码，只对提交者和管理员可见
+	SubmissionTime time.Time `json:"submission_time"`
+	CreatedAt      time.Time `json:"created_at"`
+	ProblemVersion int       `json:"problem_version"`  // 评测时题目的版本，0 表示记录版本之前的评测
+	Groups         []Group   `json:"groups,omitempty"` // 按测试组评测时每组的结果，只在查询单条记录时返回
+	Frozen         bool      `json:"frozen,omitempty"` // 封榜期间的比赛提交，结果只对提交者和管理员可见
+}
+
+// Group 测试组的评测结果，前面的测试组未通过而没有评测时 verdict 为 skipped
+type Group struct {
+	Name        string `json:"name"` // pretest main
+	Verdict     string `json:"verdict"`
+	PassNum     int    `json:"pass_num"`
+	TotalNum    int    `json:"total_num"`
+	Runtime     int    `json:"runtime"`
+	MemoryUsage int    `json:"memory_usage"`
+}
+
+// HidePrivate 非提交者和管理员看不到代码和错误信息，封榜期间的提交显示为等待评测
+func (e *EvaluationDetail) HidePrivate(viewerID int64, isAdmin bool) {
+	if isAdmin || (viewerID != 0 && viewerID == e.UserID) {
+		return
+	}
+	e.Code = ""
+	e.Output = ""
+	if e.Frozen {
+		e.Verdict = resp_code.VerdictPending
+		e.Runtime = 0
+		e.MemoryUsage = 0
+		e.Groups = nil
+	}
+}
+
+// EvaluationList 评测记录列表
+type EvaluationList struct {
+	List       []EvaluationDetail `json:"list"`
+	NextCursor string             `json:"next_cursor,omitempty"` // 为空表示没有下一页
+}
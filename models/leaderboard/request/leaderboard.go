@@ -0,0 +1,18 @@
+package request
+
+// LeaderboardReq 分页获取排行榜
+type LeaderboardReq struct {
+	Page       int    `json:"page" form:"page"`
+	Size       int    `json:"size" form:"size"`
+	Window     string `json:"window" form:"window"`           // 统计时间段 week month all，默认 all
+	CategoryID string `json:"category_id" form:"category_id"` // 只统计该分类下的题目
+	UserID     int64  `json:"-" form:"-"`                     // 查询自己的排名时为当前用户ID
+}
+
+// ProblemLeaderboardReq 分页获取题目的最快通过排行
+type ProblemLeaderboardReq struct {
+	ProblemID string `json:"problem_id" form:"problem_id"`
+	Language  string `json:"language" form:"language"` // 只统计该语言的提交，为空统计全部语言
+	Page      int    `json:"page" form:"page"`
+	Size      int    `json:"size" form:"size"`
+}
@@ -0,0 +1,36 @@
+package response
+
+import "time"
+
+// LeaderboardItem 排行榜中的用户
+type LeaderboardItem struct {
+	Rank           int64      `json:"rank"` // 从 1 开始，未通过任何题目时为 0
+	UserID         int64      `json:"user_id"`
+	Username       string     `json:"username"`
+	FinishNum      int64      `json:"finish_num"`
+	LastAcceptedAt *time.Time `json:"last_accepted_at,omitempty"`
+}
+
+// LeaderboardPage 排行榜的一页
+type LeaderboardPage struct {
+	Total int64             `json:"total"`
+	List  []LeaderboardItem `json:"list"`
+}
+
+// ProblemLeaderboardItem 用户在题目上的最优通过记录
+type ProblemLeaderboardItem struct {
+	Rank           int64     `json:"rank"` // 从 1 开始
+	UserID         int64     `json:"user_id"`
+	Username       string    `json:"username"`
+	SubmissionID   string    `json:"submission_id"`
+	Language       string    `json:"language"`
+	Runtime        int       `json:"runtime"`
+	MemoryUsage    int       `json:"memory_usage"`
+	SubmissionTime time.Time `json:"submission_time"`
+}
+
+// ProblemLeaderboardPage 题目最快通过排行的一页
+type ProblemLeaderboardPage struct {
+	Total int64                    `json:"total"`
+	List  []ProblemLeaderboardItem `json:"list"`
+}
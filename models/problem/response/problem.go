@@ -11,6 +11,8 @@ type ProblemResponse struct {
 	Difficulty string             `json:"difficulty"`
 	Categories []CategoryResponse `json:"categories"`
 	TestCases  []TestCaseResponse `json:"test_cases"`
+	Stats      ProblemStats       `json:"stats"`            // 提交统计，列表中不包含分组统计
+	Status     string             `json:"status,omitempty"` // 当前用户的状态 solved attempted untouched，未登录时为空
 }
 
 type ProblemDetailResponse struct {
@@ -23,6 +25,18 @@ type ProblemDetailResponse struct {
 	MaxMemory  int                `json:"max_memory"`  // 内存限制
 	Categories []CategoryResponse `json:"categories"`
 	TestCases  []TestCaseResponse `json:"test_cases"`
+	Stats      ProblemStats       `json:"stats"`            // 提交统计
+	Status     string             `json:"status,omitempty"` // 当前用户的状态 solved attempted untouched，未登录时为空
+}
+
+// ProblemStats 题目的提交统计
+type ProblemStats struct {
+	SubmitNum      int64            `json:"submit_num"`          // 提交次数
+	AcceptedNum    int64            `json:"accepted_num"`        // 通过次数
+	SolverNum      int64            `json:"solver_num"`          // 通过的用户数量
+	AcceptanceRate float64          `json:"acceptance_rate"`     // 通过率，通过次数 / 提交次数
+	Verdicts       map[string]int64 `json:"verdicts,omitempty"`  // 各评测结果的次数
+	Languages      map[string]int64 `json:"languages,omitempty"` // 各编程语言的提交次数
 }
 
 type CategoryResponse struct {
@@ -32,10 +46,12 @@ type CategoryResponse struct {
 }
 
 type TestCaseResponse struct {
-	TID      string `json:"tid"`
-	PID      string `json:"pid"`
-	Input    string `json:"input"`
-	Expected string `json:"expected"`
+	TID       string `json:"tid"`
+	PID       string `json:"pid"`
+	Input     string `json:"input"`
+	Expected  string `json:"expected"`
+	IsSample  bool   `json:"is_sample"`
+	IsPretest bool   `json:"is_pretest,omitempty"` // 只在管理员查看测试数据时返回
 }
 
 type GetProblemListResp struct {
@@ -0,0 +1,21 @@
+package request
+
+import "time"
+
+// RejudgeReq 重新评测提交，零值的条件不参与过滤，至少需要一个条件
+type RejudgeReq struct {
+	ProblemID     string    `json:"problem_id"`     // 题目ID
+	SubmissionIDs []string  `json:"submission_ids"` // 提交ID
+	UserID        int64     `json:"user_id"`        // 提交者的用户ID
+	Verdict       string    `json:"verdict"`        // 当前的评测结果，例如 system error
+	From          time.Time `json:"from"`           // 提交时间的范围 [from, to)
+	To            time.Time `json:"to"`
+}
+
+// VerdictOverrideReq 手动修改评测结果
+type VerdictOverrideReq struct {
+	SubmissionID string `json:"-"`                                // 提交ID
+	AdminID      int64  `json:"-"`                                // 操作的管理员
+	Verdict      string `json:"verdict" binding:"required"`       // 修改后的评测结果
+	Note         string `json:"note" binding:"required,max=1024"` // 修改原因，最多 1024 个字符
+}
@@ -0,0 +1,11 @@
+package request
+
+// RunCodeReq 使用自定义输入运行代码，不创建提交记录
+type RunCodeReq struct {
+	UserID    int64  `form:"-" json:"-"`
+	RunID     string `form:"-" json:"-"`                                      // 用于创建独立的工作目录
+	ProblemID string `form:"problem_id" json:"problem_id" binding:"required"` // 使用题目的时间和内存限制
+	Language  string `form:"language" json:"language" binding:"required"`     // 编程语言
+	Code      string `form:"code" json:"code" binding:"required,max=65536"`   // 代码
+	Input     string `form:"input" json:"input" binding:"max=65536"`          // 标准输入
+}
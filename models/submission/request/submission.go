@@ -10,4 +10,12 @@ type SubmissionReq struct {
 	Language       string    `form:"language" json:"language"`               //编程语言
 	Code           string    `form:"code" json:"code"`                       // 代码
 	SubmissionTime time.Time `form:"submission_time" json:"submission_time"` // 提交时间
+	ContestID      string    `form:"contest_id" json:"contest_id"`           // 比赛ID，为空表示不在比赛中提交
+	Pretest        bool      `form:"pretest" json:"pretest"`                 // 预测试模式，先评测预测试再评测其余测试数据
+}
+
+// SubmissionProgressReq 订阅评测进度
+type SubmissionProgressReq struct {
+	SubmissionID string `uri:"id" json:"submission_id"` // 提交ID
+	UserID       int64  `json:"user_id"`                // 当前用户ID
 }
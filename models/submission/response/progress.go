@@ -0,0 +1,13 @@
+package response
+
+// ProgressEvent 评测服务通过 redis 发布的评测进度
+type ProgressEvent struct {
+	SubmissionID string `json:"submission_id"`
+	Stage        string `json:"stage"`                  // received compiling running group_finished finished
+	Done         int    `json:"done,omitempty"`         // 已经完成的测试样例数量
+	Total        int    `json:"total,omitempty"`        // 测试样例总数
+	Group        string `json:"group,omitempty"`        // 测试组名称，只在 group_finished 阶段返回
+	Verdict      string `json:"verdict,omitempty"`      // 评测结果，在 group_finished 阶段为测试组的结果
+	Runtime      int    `json:"runtime,omitempty"`      // 运行时间
+	MemoryUsage  int    `json:"memory_usage,omitempty"` // 内存用量
+}
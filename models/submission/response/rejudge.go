@@ -0,0 +1,8 @@
+package response
+
+// RejudgeResult 重新评测的结果，评测任务发送失败的提交被标记为系统错误
+type RejudgeResult struct {
+	Versions map[string]int `json:"versions"` // 题目ID到重新评测使用的题目版本
+	Total    int            `json:"total"`    // 重新评测的提交数量
+	Failed   int            `json:"failed"`   // 评测任务发送失败的提交数量
+}
@@ -0,0 +1,12 @@
+package response
+
+// RunResult 使用自定义输入运行代码的结果
+type RunResult struct {
+	Status      string `json:"status"`       // ok 表示正常退出，其他和评测结果相同
+	Stdout      string `json:"stdout"`       // 标准输出，超过 64KB 时截断
+	Stderr      string `json:"stderr"`       // 标准错误，超过 64KB 时截断
+	ExitCode    int    `json:"exit_code"`    // 进程退出码，被信号杀死时为 -1
+	Runtime     int    `json:"runtime"`      // 运行时间，单位 ms
+	MemoryUsage int    `json:"memory_usage"` // 内存用量，单位 KB
+	Output      string `json:"output"`       // 编译错误信息或系统错误说明
+}
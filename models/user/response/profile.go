@@ -0,0 +1,36 @@
+package response
+
+// UserProfile 用户主页的统计信息
+type UserProfile struct {
+	UserID         int64           `json:"user_id"`
+	Username       string          `json:"username"`
+	FinishNum      int64           `json:"finish_num"`      // 通过的题目数量
+	SubmitNum      int64           `json:"submit_num"`      // 评测次数
+	AcceptedNum    int64           `json:"accepted_num"`    // 通过的评测次数
+	AcceptanceRate float64         `json:"acceptance_rate"` // 通过率，通过次数 / 评测次数
+	ByDifficulty   []SolvedCount   `json:"by_difficulty"`   // 按难度统计通过的题目
+	ByCategory     []SolvedCount   `json:"by_category"`     // 按分类统计通过的题目
+	Languages      []LanguageCount `json:"languages"`       // 各编程语言的提交次数，次数多的在前
+	CurrentStreak  int             `json:"current_streak"`  // 截止到今天或昨天连续提交的天数
+	LongestStreak  int             `json:"longest_streak"`  // 最长连续提交的天数
+	Heatmap        []HeatmapDay    `json:"heatmap"`         // 最近 365 天有提交的日期
+}
+
+// SolvedCount 按难度或分类统计的通过题目数量
+type SolvedCount struct {
+	ID    string `json:"id,omitempty"` // 分类ID，按难度统计时为空
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+// LanguageCount 某种编程语言的提交次数
+type LanguageCount struct {
+	Language string `json:"language"`
+	Count    int64  `json:"count"`
+}
+
+// HeatmapDay 某一天的提交次数
+type HeatmapDay struct {
+	Date  string `json:"date"` // 2006-01-02
+	Count int64  `json:"count"`
+}
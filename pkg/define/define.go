@@ -9,6 +9,16 @@ type CacheKeyMap struct {
 	HotSearchPrefix     string
 	RecentSearchPrefix  string
 	ProblemIDListPrefix string
+	ProgressPrefix      string
+	EvaluationPrefix    string
+	SubmissionPrefix    string
+	ScoreboardPrefix    string
+	LeaderboardPrefix   string
+	ProblemStatsPrefix  string
+	ProblemStatusPrefix string
+	UserProfilePrefix   string
+	RateLimitPrefix     string
+	ProblemAddedPrefix  string
 }
 
 var GlobalCacheKeyMap = CacheKeyMap{
@@ -18,6 +28,16 @@ var GlobalCacheKeyMap = CacheKeyMap{
 	HotSearchPrefix:     "hot_search",
 	RecentSearchPrefix:  "recent_search",
 	ProblemIDListPrefix: "problem_id_list",
+	ProgressPrefix:      "submission_progress",
+	EvaluationPrefix:    "evaluation_detail",
+	SubmissionPrefix:    "submission_detail",
+	ScoreboardPrefix:    "contest_scoreboard",
+	LeaderboardPrefix:   "leaderboard",
+	ProblemStatsPrefix:  "problem_stats",
+	ProblemStatusPrefix: "problem_status",
+	UserProfilePrefix:   "user_profile",
+	RateLimitPrefix:     "rate_limit",
+	ProblemAddedPrefix:  "problem_added",
 }
 
 var (
@@ -25,4 +45,7 @@ var (
 	ErrBloomFilterNotFound = fmt.Errorf("problem list not found")
 	ErrProblemIDNotFound   = fmt.Errorf("problem id not found")
 	ErrNoProblemIDFound    = fmt.Errorf("no problem ID found in cache")
+	ErrJudgementNotFound   = fmt.Errorf("judgement not found")
+	ErrSubmissionNotFound  = fmt.Errorf("submission not found")
+	ErrInvalidFilter       = fmt.Errorf("invalid filter")
 )
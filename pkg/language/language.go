@@ -0,0 +1,112 @@
+package language
+
+import (
+	"errors"
+	"fmt"
+	"online_judge/setting"
+	"strings"
+	"sync"
+)
+
+// Language 一种可提交的编程语言
+type Language struct {
+	Name         string   `json:"name"`          // 提交时使用的语言名称
+	Version      string   `json:"version"`       // 编译器或解释器版本
+	SourceFile   string   `json:"source_file"`   // 源代码文件名
+	CompileCmd   []string `json:"compile_cmd"`   // 编译命令，为空表示不需要编译
+	RunCmd       []string `json:"run_cmd"`       // 运行命令
+	Env          []string `json:"-"`             // 额外的环境变量
+	TimeFactor   float64  `json:"time_factor"`   // 时间限制倍数
+	MemoryFactor float64  `json:"memory_factor"` // 内存限制倍数
+}
+
+var (
+	ErrEmptyRegistry   = errors.New("no language configured")
+	ErrDuplicateName   = errors.New("duplicate language name")
+	ErrInvalidLanguage = errors.New("invalid language config")
+)
+
+var (
+	mu        sync.RWMutex
+	languages []*Language
+	byName    map[string]*Language
+)
+
+// Init 根据配置文件初始化语言注册表
+func Init(cfgs []*setting.LanguageConfig) error {
+	if len(cfgs) == 0 {
+		return ErrEmptyRegistry
+	}
+	list := make([]*Language, 0, len(cfgs))
+	m := make(map[string]*Language, len(cfgs))
+	for _, cfg := range cfgs {
+		lang, err := newLanguage(cfg)
+		if err != nil {
+			return err
+		}
+		if _, ok := m[lang.Name]; ok {
+			return fmt.Errorf("%w: %s", ErrDuplicateName, lang.Name)
+		}
+		m[lang.Name] = lang
+		list = append(list, lang)
+	}
+
+	mu.Lock()
+	languages = list
+	byName = m
+	mu.Unlock()
+	return nil
+}
+
+func newLanguage(cfg *setting.LanguageConfig) (*Language, error) {
+	if cfg == nil || cfg.Name == "" || cfg.SourceFile == "" || cfg.RunCmd == "" {
+		return nil, ErrInvalidLanguage
+	}
+	lang := &Language{
+		Name:         cfg.Name,
+		Version:      cfg.Version,
+		SourceFile:   cfg.SourceFile,
+		CompileCmd:   strings.Fields(cfg.CompileCmd),
+		RunCmd:       strings.Fields(cfg.RunCmd),
+		Env:          cfg.Env,
+		TimeFactor:   cfg.TimeFactor,
+		MemoryFactor: cfg.MemoryFactor,
+	}
+	if lang.TimeFactor <= 0 {
+		lang.TimeFactor = 1
+	}
+	if lang.MemoryFactor <= 0 {
+		lang.MemoryFactor = 1
+	}
+	return lang, nil
+}
+
+// Get 根据名称获取语言
+func Get(name string) (*Language, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	lang, ok := byName[name]
+	return lang, ok
+}
+
+// List 获取全部语言，顺序与配置文件一致
+func List() []*Language {
+	mu.RLock()
+	defer mu.RUnlock()
+	return languages
+}
+
+// NeedCompile 是否需要编译
+func (l *Language) NeedCompile() bool {
+	return len(l.CompileCmd) > 0
+}
+
+// TimeLimit 按照语言倍数调整后的时间限制
+func (l *Language) TimeLimit(limit int64) int64 {
+	return int64(float64(limit) * l.TimeFactor)
+}
+
+// MemoryLimit 按照语言倍数调整后的内存限制
+func (l *Language) MemoryLimit(limit int64) int64 {
+	return int64(float64(limit) * l.MemoryFactor)
+}
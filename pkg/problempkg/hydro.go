@@ -0,0 +1,290 @@
+package problempkg
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"online_judge/consts"
+	"online_judge/pkg/testcase"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Hydro 导出的题目包，所有文件可以位于同一个顶层目录中：
+//
+//	problem.yaml          题目信息，使用 title、tag 和 difficulty
+//	problem.md            题目描述，没有时使用 problem_zh.md 等带语言后缀的文件
+//	testdata/config.yaml  时间、内存限制、检查器和交互器，可选
+//	testdata/             测试数据，按 config.yaml 中列出的文件或者文件名配对
+const (
+	HydroMetaName   = "problem.yaml"
+	HydroTestsDir   = "testdata"
+	HydroConfigName = "config.yaml"
+)
+
+// Hydro 没有配置时的默认限制
+const (
+	hydroDefaultTimeLimit   = 1000       // 毫秒
+	hydroDefaultMemoryLimit = 256 * 1024 // KB
+)
+
+// hydroMeta problem.yaml 中使用的字段
+type hydroMeta struct {
+	Title      string   `yaml:"title"`
+	Tag        []string `yaml:"tag"`
+	Difficulty string   `yaml:"difficulty"` // 1 到 10
+}
+
+// hydroConfig testdata/config.yaml 中使用的字段
+type hydroConfig struct {
+	Type        string         `yaml:"type"` // default interactive
+	Time        string         `yaml:"time"`
+	Memory      string         `yaml:"memory"`
+	CheckerType string         `yaml:"checker_type"` // default strict real testlib
+	Checker     string         `yaml:"checker"`
+	Interactor  string         `yaml:"interactor"`
+	Cases       []hydroCase    `yaml:"cases"`
+	Subtasks    []hydroSubtask `yaml:"subtasks"`
+}
+
+type hydroSubtask struct {
+	Cases []hydroCase `yaml:"cases"`
+}
+
+type hydroCase struct {
+	Input  string `yaml:"input"`
+	Output string `yaml:"output"`
+}
+
+var (
+	hydroTimeRe   = regexp.MustCompile(`^(?i)([0-9]+(?:\.[0-9]*)?)\s*(m|u)?s$`)
+	hydroMemoryRe = regexp.MustCompile(`^(?i)([0-9]+(?:\.[0-9]*)?)\s*([kmg])b?$`)
+)
+
+// hydroRoot 返回 problem.yaml 所在的目录，只支持根目录或者唯一的顶层目录
+func hydroRoot(files map[string]*zip.File) (string, bool) {
+	if _, ok := files[HydroMetaName]; ok {
+		return "", true
+	}
+	var roots []string
+	for name := range files {
+		if dir, base := path.Split(name); base == HydroMetaName && strings.Count(dir, "/") == 1 {
+			roots = append(roots, path.Clean(dir))
+		}
+	}
+	if len(roots) != 1 {
+		return "", false
+	}
+	return roots[0], true
+}
+
+// readHydro 读取 root 目录下的 Hydro 题目包
+func readHydro(zr *zip.Reader, files map[string]*zip.File, root string, limits testcase.Limits) (*Package, error) {
+	data, err := readFile(files, path.Join(root, HydroMetaName))
+	if err != nil {
+		return nil, err
+	}
+	var meta hydroMeta
+	if err = yaml.Unmarshal([]byte(data), &meta); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidMeta, err)
+	}
+	pkg := &Package{Meta: Meta{
+		Version:    Version,
+		Title:      meta.Title,
+		Difficulty: hydroDifficulty(meta.Difficulty),
+		Categories: meta.Tag,
+	}}
+	if pkg.Statement, err = readFile(files, hydroStatementName(files, root)); err != nil {
+		return nil, err
+	}
+
+	testsDir := path.Join(root, HydroTestsDir)
+	var config hydroConfig
+	if _, ok := files[path.Join(testsDir, HydroConfigName)]; ok {
+		if data, err = readFile(files, path.Join(testsDir, HydroConfigName)); err != nil {
+			return nil, err
+		}
+		if err = yaml.Unmarshal([]byte(data), &config); err != nil {
+			return nil, fmt.Errorf("%w: testdata/config.yaml: %v", ErrInvalidMeta, err)
+		}
+	}
+	if err = config.apply(pkg, files, testsDir); err != nil {
+		return nil, err
+	}
+	if err = pkg.Meta.validate(); err != nil {
+		return nil, err
+	}
+
+	pairs := config.pairs()
+	if len(pairs) > 0 {
+		pkg.TestCases, _, err = testcase.ReadPairs(zr, testsDir, pairs, limits)
+	} else {
+		pkg.TestCases, _, err = testcase.ReadDir(zr, testsDir, limits)
+	}
+	if err != nil {
+		if errors.Is(err, testcase.ErrEmptyArchive) {
+			return nil, ErrNoTestCases
+		}
+		return nil, err
+	}
+	return pkg, nil
+}
+
+// apply 把限制、检查器和交互器写入 pkg，源代码的语言由扩展名决定
+func (c *hydroConfig) apply(pkg *Package, files map[string]*zip.File, testsDir string) error {
+	var err error
+	if pkg.Meta.TimeLimit, err = hydroTime(c.Time); err != nil {
+		return err
+	}
+	if pkg.Meta.MemoryLimit, err = hydroMemory(c.Memory); err != nil {
+		return err
+	}
+
+	switch c.CheckerType {
+	case "", "default":
+		pkg.Meta.Checker = &Checker{Mode: consts.CheckerToken}
+	case "strict":
+		pkg.Meta.Checker = &Checker{Mode: consts.CheckerExact}
+	case "real":
+		pkg.Meta.Checker = &Checker{Mode: consts.CheckerFloat}
+	case "testlib":
+		checker := &Checker{Mode: consts.CheckerCustom, Language: hydroLanguage(c.Checker), Source: c.Checker}
+		if checker.Source != "" {
+			if pkg.CheckerCode, err = readFile(files, path.Join(testsDir, checker.Source)); err != nil {
+				return err
+			}
+		}
+		pkg.Meta.Checker = checker
+	default:
+		return fmt.Errorf("%w: unsupported checker_type %q", ErrInvalidMeta, c.CheckerType)
+	}
+
+	switch c.Type {
+	case "", "default":
+	case "interactive":
+		interactor := &Interactor{Language: hydroLanguage(c.Interactor), Source: c.Interactor}
+		if interactor.Source != "" {
+			if pkg.InteractorCode, err = readFile(files, path.Join(testsDir, interactor.Source)); err != nil {
+				return err
+			}
+		}
+		pkg.Meta.Interactor = interactor
+	default:
+		return fmt.Errorf("%w: unsupported problem type %q", ErrInvalidMeta, c.Type)
+	}
+	return nil
+}
+
+// pairs 配置文件中列出的测试数据，按测试组的顺序展开
+func (c *hydroConfig) pairs() []testcase.Pair {
+	cases := append([]hydroCase{}, c.Cases...)
+	for _, s := range c.Subtasks {
+		cases = append(cases, s.Cases...)
+	}
+	pairs := make([]testcase.Pair, len(cases))
+	for i, hc := range cases {
+		pairs[i] = testcase.Pair{Input: hc.Input, Expected: hc.Output}
+	}
+	return pairs
+}
+
+// hydroStatementName 优先使用 problem.md，其次是按名称排序的第一个 problem_*.md
+func hydroStatementName(files map[string]*zip.File, root string) string {
+	name := path.Join(root, "problem.md")
+	if _, ok := files[name]; ok {
+		return name
+	}
+	var names []string
+	for n := range files {
+		dir, base := path.Split(n)
+		if path.Clean(dir) == path.Clean(root) && strings.HasPrefix(base, "problem_") && path.Ext(base) == ".md" {
+			names = append(names, n)
+		}
+	}
+	if len(names) == 0 {
+		return name
+	}
+	sort.Strings(names)
+	return names[0]
+}
+
+// hydroTime 解析 1s、500ms 格式的时间限制，只有数字时单位为毫秒
+func hydroTime(s string) (int, error) {
+	if s == "" {
+		return hydroDefaultTimeLimit, nil
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		return n, nil
+	}
+	m := hydroTimeRe.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return 0, fmt.Errorf("%w: invalid time %q", ErrInvalidMeta, s)
+	}
+	v, _ := strconv.ParseFloat(m[1], 64)
+	switch strings.ToLower(m[2]) {
+	case "m":
+	case "u":
+		v /= 1000
+	default:
+		v *= 1000
+	}
+	return int(v), nil
+}
+
+// hydroMemory 解析 256m、1g 格式的内存限制并转换为 KB，只有数字时单位为 MB
+func hydroMemory(s string) (int, error) {
+	if s == "" {
+		return hydroDefaultMemoryLimit, nil
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		return n * 1024, nil
+	}
+	m := hydroMemoryRe.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return 0, fmt.Errorf("%w: invalid memory %q", ErrInvalidMeta, s)
+	}
+	v, _ := strconv.ParseFloat(m[1], 64)
+	switch strings.ToLower(m[2]) {
+	case "m":
+		v *= 1024
+	case "g":
+		v *= 1024 * 1024
+	}
+	return int(v), nil
+}
+
+// hydroDifficulty 把 1 到 10 的难度转换为 easy mid hard，无法识别时为空
+func hydroDifficulty(s string) string {
+	n, err := strconv.Atoi(s)
+	switch {
+	case err != nil || n <= 0:
+		return ""
+	case n <= 3:
+		return "easy"
+	case n <= 6:
+		return "mid"
+	default:
+		return "hard"
+	}
+}
+
+// hydroLanguage 按源代码的扩展名推断语言，名称与配置文件中的语言一致
+func hydroLanguage(source string) string {
+	switch strings.ToLower(path.Ext(source)) {
+	case ".c":
+		return "C"
+	case ".cc", ".cpp", ".cxx":
+		return "C++"
+	case ".go":
+		return "Go"
+	case ".java":
+		return "Java"
+	case ".py":
+		return "Python"
+	}
+	return ""
+}
@@ -0,0 +1,230 @@
+// Package problempkg 读写可移植的题目包，用于在不同评测系统之间迁移题目
+//
+// 题目包是一个 zip 压缩包，目录结构如下：
+//
+//	problem.json        题目信息，见 Meta
+//	statement.md        题目描述
+//	tests/              测试数据，格式与 pkg/testcase 相同，manifest.json 中标记公开的样例
+//	checker/, interactor/  custom 检查器和交互器的源代码，路径由 problem.json 指定
+//
+// 导入时也支持 Hydro 导出的题目包，见 hydro.go
+package problempkg
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"online_judge/consts"
+	"online_judge/pkg/testcase"
+	"path"
+)
+
+// Version 当前题目包格式的版本
+const Version = 1
+
+// 题目包中的文件
+const (
+	MetaName      = "problem.json"
+	StatementName = "statement.md"
+	TestsDir      = "tests"
+)
+
+var (
+	ErrMissingFile  = errors.New("problem package is missing a required file")
+	ErrInvalidMeta  = errors.New("invalid problem.json")
+	ErrNoTestCases  = errors.New("problem package contains no test cases")
+	ErrFileTooLarge = errors.New("file in problem package is too large")
+)
+
+// Meta problem.json 的内容
+type Meta struct {
+	Version     int         `json:"version"`
+	Title       string      `json:"title"`
+	Difficulty  string      `json:"difficulty"`   // easy mid hard
+	TimeLimit   int         `json:"time_limit"`   // 与题目的 max_runtime 单位相同
+	MemoryLimit int         `json:"memory_limit"` // 与题目的 max_memory 单位相同
+	Categories  []string    `json:"categories"`   // 分类名称
+	Checker     *Checker    `json:"checker,omitempty"`
+	Interactor  *Interactor `json:"interactor,omitempty"`
+
+	StopOnPretestFailure bool `json:"stop_on_pretest_failure,omitempty"` // 预测试模式下预测试未通过时不再评测其余测试数据
+}
+
+// Checker 答案检查方式，为空时逐字节比较
+type Checker struct {
+	Mode     string  `json:"mode"` // exact token float custom
+	Epsilon  float64 `json:"epsilon,omitempty"`
+	Language string  `json:"language,omitempty"`
+	Source   string  `json:"source,omitempty"` // custom 模式的检查器源代码在包中的路径
+}
+
+// Interactor 交互题的交互器
+type Interactor struct {
+	Language string `json:"language"`
+	Source   string `json:"source"` // 交互器源代码在包中的路径
+}
+
+// Package 解析后的题目包
+type Package struct {
+	Meta           Meta
+	Statement      string
+	TestCases      []testcase.Case
+	CheckerCode    string
+	InteractorCode string
+}
+
+// Read 读取并校验题目包，没有 problem.json 但有 problem.yaml 时按 Hydro 的格式读取
+func Read(r io.ReaderAt, size int64, limits testcase.Limits) (*Package, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[path.Clean(f.Name)] = f
+	}
+	if _, ok := files[MetaName]; !ok {
+		if root, ok := hydroRoot(files); ok {
+			return readHydro(zr, files, root, limits)
+		}
+	}
+
+	pkg := &Package{}
+	data, err := readFile(files, MetaName)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal([]byte(data), &pkg.Meta); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidMeta, err)
+	}
+	if err = pkg.Meta.validate(); err != nil {
+		return nil, err
+	}
+	if pkg.Statement, err = readFile(files, StatementName); err != nil {
+		return nil, err
+	}
+	if c := pkg.Meta.Checker; c != nil && c.Mode == consts.CheckerCustom {
+		if pkg.CheckerCode, err = readFile(files, c.Source); err != nil {
+			return nil, err
+		}
+	}
+	if i := pkg.Meta.Interactor; i != nil {
+		if pkg.InteractorCode, err = readFile(files, i.Source); err != nil {
+			return nil, err
+		}
+	}
+
+	if pkg.TestCases, _, err = testcase.ReadDir(zr, TestsDir, limits); err != nil {
+		if errors.Is(err, testcase.ErrEmptyArchive) {
+			return nil, ErrNoTestCases
+		}
+		return nil, err
+	}
+	return pkg, nil
+}
+
+// Write 把题目写成题目包，custom 检查器和交互器的源代码分别写入 checker/ 和 interactor/ 目录
+func Write(w io.Writer, pkg *Package) error {
+	zw := zip.NewWriter(w)
+	meta := pkg.Meta
+	meta.Version = Version
+	if meta.Checker != nil && meta.Checker.Mode == consts.CheckerCustom {
+		checker := *meta.Checker
+		checker.Source = "checker/checker." + sourceExt(checker.Language)
+		meta.Checker = &checker
+		if err := writeFile(zw, checker.Source, pkg.CheckerCode); err != nil {
+			return err
+		}
+	}
+	if meta.Interactor != nil {
+		interactor := *meta.Interactor
+		interactor.Source = "interactor/interactor." + sourceExt(interactor.Language)
+		meta.Interactor = &interactor
+		if err := writeFile(zw, interactor.Source, pkg.InteractorCode); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = writeFile(zw, MetaName, string(data)); err != nil {
+		return err
+	}
+	if err = writeFile(zw, StatementName, pkg.Statement); err != nil {
+		return err
+	}
+	if err = testcase.WriteDir(zw, TestsDir, pkg.TestCases); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+func (m *Meta) validate() error {
+	switch {
+	case m.Version > Version:
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidMeta, m.Version)
+	case m.Title == "":
+		return fmt.Errorf("%w: title is required", ErrInvalidMeta)
+	case m.TimeLimit <= 0 || m.MemoryLimit <= 0:
+		return fmt.Errorf("%w: time_limit and memory_limit must be positive", ErrInvalidMeta)
+	}
+	if c := m.Checker; c != nil {
+		if c.Epsilon < 0 {
+			return fmt.Errorf("%w: checker epsilon must not be negative", ErrInvalidMeta)
+		}
+		switch c.Mode {
+		case consts.CheckerExact, consts.CheckerToken, consts.CheckerFloat:
+		case consts.CheckerCustom:
+			if c.Language == "" || c.Source == "" {
+				return fmt.Errorf("%w: custom checker requires language and source", ErrInvalidMeta)
+			}
+		default:
+			return fmt.Errorf("%w: unknown checker mode %q", ErrInvalidMeta, c.Mode)
+		}
+	}
+	if i := m.Interactor; i != nil && (i.Language == "" || i.Source == "") {
+		return fmt.Errorf("%w: interactor requires language and source", ErrInvalidMeta)
+	}
+	return nil
+}
+
+// sourceExt 导出时源代码文件的扩展名，只用于方便阅读，导入时以 problem.json 中的语言为准
+func sourceExt(language string) string {
+	if language == "" {
+		return "txt"
+	}
+	return language
+}
+
+func readFile(files map[string]*zip.File, name string) (string, error) {
+	f, ok := files[path.Clean(name)]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrMissingFile, name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(io.LimitReader(rc, testcase.DefaultMaxFileSize+1))
+	if err != nil {
+		return "", err
+	}
+	if len(data) > testcase.DefaultMaxFileSize {
+		return "", fmt.Errorf("%w: %s", ErrFileTooLarge, name)
+	}
+	return string(data), nil
+}
+
+func writeFile(zw *zip.Writer, name, content string) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, content)
+	return err
+}
@@ -0,0 +1,212 @@
+package similarity
+
+import "sort"
+
+// maxFragments 每对源代码最多返回的相同片段数量
+const maxFragments = 20
+
+// Fragment 两份源代码中相同的片段，行号从 1 开始，包含两端
+type Fragment struct {
+	AStartLine int
+	AEndLine   int
+	BStartLine int
+	BEndLine   int
+	Tokens     int // 片段包含的词法单元数量
+}
+
+// Result 两份源代码的比较结果
+type Result struct {
+	Score     float64 // ScoreA 和 ScoreB 中较大的一个，一份代码被另一份包含时也能发现
+	ScoreA    float64 // A 的指纹中出现在 B 中的比例
+	ScoreB    float64 // B 的指纹中出现在 A 中的比例
+	Fragments []Fragment
+}
+
+// Pair 相似度超过阈值的一对源代码，A B 为在输入中的下标
+type Pair struct {
+	A, B int
+	Result
+}
+
+// Options 查找相似代码的参数
+type Options struct {
+	Threshold float64 // 相似度阈值，低于阈值的结果不返回
+	MaxShare  float64 // 出现在超过这个比例的源代码中的指纹视为模板代码，不参与比较，零值表示不过滤
+}
+
+// Compare 比较两份源代码，ignore 中的指纹不参与比较
+func Compare(a, b *Document, ignore map[uint64]bool) Result {
+	inA := fingerprintSet(a, ignore)
+	inB := fingerprintSet(b, ignore)
+	var res Result
+	if len(inA) == 0 || len(inB) == 0 {
+		return res
+	}
+	var common int
+	for h := range inA {
+		if inB[h] {
+			common++
+		}
+	}
+	res.ScoreA = float64(common) / float64(len(inA))
+	res.ScoreB = float64(common) / float64(len(inB))
+	res.Score = max(res.ScoreA, res.ScoreB)
+	if common > 0 {
+		res.Fragments = fragments(a, b, inB)
+	}
+	return res
+}
+
+// FindPairs 找出相似度不低于阈值的源代码对，只比较语法相同且至少有一个相同指纹的源代码，结果按相似度从高到低排序
+func FindPairs(docs []*Document, opts Options) []Pair {
+	// 指纹到包含它的源代码
+	index := make(map[uint64][]int)
+	for i, d := range docs {
+		seen := make(map[uint64]bool)
+		for _, fp := range d.Fingerprints {
+			if !seen[fp.Hash] {
+				seen[fp.Hash] = true
+				index[fp.Hash] = append(index[fp.Hash], i)
+			}
+		}
+	}
+	ignore := make(map[uint64]bool)
+	if opts.MaxShare > 0 {
+		limit := max(2, int(opts.MaxShare*float64(len(docs))))
+		for h, ds := range index {
+			if len(ds) > limit {
+				ignore[h] = true
+			}
+		}
+	}
+
+	candidates := make(map[[2]int]bool)
+	for h, ds := range index {
+		if ignore[h] {
+			continue
+		}
+		for x := 0; x < len(ds); x++ {
+			for y := x + 1; y < len(ds); y++ {
+				if docs[ds[x]].Syntax == docs[ds[y]].Syntax {
+					candidates[[2]int{ds[x], ds[y]}] = true
+				}
+			}
+		}
+	}
+
+	var pairs []Pair
+	for c := range candidates {
+		res := Compare(docs[c[0]], docs[c[1]], ignore)
+		if res.Score >= opts.Threshold {
+			pairs = append(pairs, Pair{A: c[0], B: c[1], Result: res})
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].Score != pairs[j].Score {
+			return pairs[i].Score > pairs[j].Score
+		}
+		if pairs[i].A != pairs[j].A {
+			return pairs[i].A < pairs[j].A
+		}
+		return pairs[i].B < pairs[j].B
+	})
+	return pairs
+}
+
+func fingerprintSet(d *Document, ignore map[uint64]bool) map[uint64]bool {
+	set := make(map[uint64]bool, len(d.Fingerprints))
+	for _, fp := range d.Fingerprints {
+		if !ignore[fp.Hash] {
+			set[fp.Hash] = true
+		}
+	}
+	return set
+}
+
+// fragments 从相同的指纹出发向两边扩展出最长的相同片段，按长度从长到短选出互不重叠的片段
+func fragments(a, b *Document, inB map[uint64]bool) []Fragment {
+	positions := make(map[uint64][]int)
+	for _, fp := range b.Fingerprints {
+		if inB[fp.Hash] {
+			positions[fp.Hash] = append(positions[fp.Hash], fp.Pos)
+		}
+	}
+
+	type run struct{ a, b, n int }
+	seen := make(map[[2]int]bool)
+	var runs []run
+	for _, fp := range a.Fingerprints {
+		for _, pb := range positions[fp.Hash] {
+			pa := fp.Pos
+			// 哈希冲突时词法单元并不相同
+			if !equalTokens(a.Tokens, b.Tokens, pa, pb, DefaultK) {
+				continue
+			}
+			for pa > 0 && pb > 0 && a.Tokens[pa-1].Text == b.Tokens[pb-1].Text {
+				pa--
+				pb--
+			}
+			if seen[[2]int{pa, pb}] {
+				continue
+			}
+			seen[[2]int{pa, pb}] = true
+			n := 0
+			for pa+n < len(a.Tokens) && pb+n < len(b.Tokens) && a.Tokens[pa+n].Text == b.Tokens[pb+n].Text {
+				n++
+			}
+			runs = append(runs, run{pa, pb, n})
+		}
+	}
+	sort.Slice(runs, func(i, j int) bool {
+		if runs[i].n != runs[j].n {
+			return runs[i].n > runs[j].n
+		}
+		return runs[i].a < runs[j].a
+	})
+
+	usedA := make([]bool, len(a.Tokens))
+	usedB := make([]bool, len(b.Tokens))
+	var result []Fragment
+	for _, r := range runs {
+		if len(result) == maxFragments {
+			break
+		}
+		if overlaps(usedA, r.a, r.n) || overlaps(usedB, r.b, r.n) {
+			continue
+		}
+		for i := 0; i < r.n; i++ {
+			usedA[r.a+i] = true
+			usedB[r.b+i] = true
+		}
+		result = append(result, Fragment{
+			AStartLine: a.Tokens[r.a].Line,
+			AEndLine:   a.Tokens[r.a+r.n-1].Line,
+			BStartLine: b.Tokens[r.b].Line,
+			BEndLine:   b.Tokens[r.b+r.n-1].Line,
+			Tokens:     r.n,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].AStartLine < result[j].AStartLine })
+	return result
+}
+
+func equalTokens(a, b []Token, pa, pb, n int) bool {
+	if pa+n > len(a) || pb+n > len(b) {
+		return false
+	}
+	for i := 0; i < n; i++ {
+		if a[pa+i].Text != b[pb+i].Text {
+			return false
+		}
+	}
+	return true
+}
+
+func overlaps(used []bool, start, n int) bool {
+	for i := start; i < start+n; i++ {
+		if used[i] {
+			return true
+		}
+	}
+	return false
+}
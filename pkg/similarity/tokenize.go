@@ -0,0 +1,147 @@
+package similarity
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Syntax 源代码的注释和字符串语法
+type Syntax int
+
+const (
+	SyntaxC      Syntax = iota // // 和 /* */ 注释，C C++ Java Go JavaScript 等
+	SyntaxPython               // # 注释和三引号字符串
+)
+
+// 标识符、数字和字符串归一化后的词法单元
+const (
+	identToken  = "V"
+	numberToken = "N"
+	stringToken = "S"
+)
+
+// keywords 保留原文的关键字和常用类型，其余标识符都归一化为 identToken
+var keywords = make(map[string]bool)
+
+func init() {
+	for _, k := range strings.Fields(`
+		if else for while do switch case default break continue return goto
+		int long short char float double bool boolean void unsigned signed const static
+		struct class enum union typedef template typename namespace using public private protected
+		new delete true false null nullptr nil None True False
+		func var type package import interface map chan go defer select range
+		def lambda elif in not and or is pass yield with as try except finally raise
+		catch throw throws extends implements final let fn mut impl match loop`) {
+		keywords[k] = true
+	}
+}
+
+// SyntaxFor 根据源代码文件名选择语法，不认识的扩展名按 C 风格处理
+func SyntaxFor(sourceFile string) Syntax {
+	switch strings.ToLower(filepath.Ext(sourceFile)) {
+	case ".py", ".rb", ".sh", ".pl", ".r":
+		return SyntaxPython
+	default:
+		return SyntaxC
+	}
+}
+
+// Token 归一化后的词法单元，Line 为在源代码中的行号，从 1 开始
+type Token struct {
+	Text string
+	Line int
+}
+
+// Tokenize 去掉注释和空白，把标识符、数字和字符串归一化后切分为词法单元
+// 重命名变量、修改注释和格式不会改变结果
+func Tokenize(syntax Syntax, code string) []Token {
+	var tokens []Token
+	line := 1
+	for i := 0; i < len(code); {
+		c := code[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f' || c == '\v':
+			i++
+		case syntax == SyntaxC && strings.HasPrefix(code[i:], "//"),
+			syntax == SyntaxPython && c == '#':
+			for i < len(code) && code[i] != '\n' {
+				i++
+			}
+		case syntax == SyntaxC && strings.HasPrefix(code[i:], "/*"):
+			end := strings.Index(code[i+2:], "*/")
+			if end < 0 {
+				end = len(code)
+			} else {
+				end += i + 4
+			}
+			line += strings.Count(code[i:end], "\n")
+			i = end
+		case c == '"' || c == '\'' || (syntax == SyntaxC && c == '`'):
+			end := stringEnd(syntax, code, i)
+			tokens = append(tokens, Token{Text: stringToken, Line: line})
+			line += strings.Count(code[i:end], "\n")
+			i = end
+		case isIdentStart(c):
+			j := i + 1
+			for j < len(code) && (isIdentStart(code[j]) || isDigit(code[j])) {
+				j++
+			}
+			text := code[i:j]
+			if !keywords[text] {
+				text = identToken
+			}
+			tokens = append(tokens, Token{Text: text, Line: line})
+			i = j
+		case isDigit(c):
+			j := i + 1
+			for j < len(code) && (isIdentStart(code[j]) || isDigit(code[j]) || code[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, Token{Text: numberToken, Line: line})
+			i = j
+		default:
+			tokens = append(tokens, Token{Text: code[i : i+1], Line: line})
+			i++
+		}
+	}
+	return tokens
+}
+
+// stringEnd 返回从 start 开始的字符串字面量结束后的位置，未闭合的字符串延伸到行尾
+func stringEnd(syntax Syntax, code string, start int) int {
+	quote := code[start]
+	if syntax == SyntaxPython && strings.HasPrefix(code[start:], strings.Repeat(string(quote), 3)) {
+		delim := strings.Repeat(string(quote), 3)
+		if end := strings.Index(code[start+3:], delim); end >= 0 {
+			return start + 3 + end + 3
+		}
+		return len(code)
+	}
+	for i := start + 1; i < len(code); i++ {
+		switch code[i] {
+		case '\\':
+			if quote != '`' {
+				i++
+			}
+		case '\n':
+			// 只有反引号字符串可以跨行
+			if quote != '`' {
+				return i
+			}
+		case quote:
+			return i + 1
+		}
+	}
+	return len(code)
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
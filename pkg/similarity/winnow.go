@@ -0,0 +1,70 @@
+package similarity
+
+import "hash/fnv"
+
+// 默认的 k-gram 长度和窗口大小，长度不小于 DefaultK+DefaultW-1 个词法单元的相同片段一定会被发现
+const (
+	DefaultK = 8
+	DefaultW = 4
+)
+
+// Fingerprint 被窗口选中的 k-gram 的哈希值，Pos 为 k-gram 第一个词法单元的下标
+type Fingerprint struct {
+	Hash uint64
+	Pos  int
+}
+
+// Document 一份参与比较的源代码
+type Document struct {
+	ID           string
+	Syntax       Syntax
+	Tokens       []Token
+	Fingerprints []Fingerprint
+}
+
+// NewDocument 归一化源代码并计算指纹
+func NewDocument(id string, syntax Syntax, code string) *Document {
+	tokens := Tokenize(syntax, code)
+	return &Document{
+		ID:           id,
+		Syntax:       syntax,
+		Tokens:       tokens,
+		Fingerprints: Winnow(tokens, DefaultK, DefaultW),
+	}
+}
+
+// Winnow 计算所有 k-gram 的哈希值，在每 w 个连续的哈希值中选出最小的一个作为指纹
+// 最小值相同时选择最右边的一个，相邻窗口选中同一个 k-gram 时只记录一次
+func Winnow(tokens []Token, k, w int) []Fingerprint {
+	if len(tokens) < k {
+		return nil
+	}
+	hashes := make([]uint64, len(tokens)-k+1)
+	for i := range hashes {
+		h := fnv.New64a()
+		for _, t := range tokens[i : i+k] {
+			h.Write([]byte(t.Text))
+			h.Write([]byte{0})
+		}
+		hashes[i] = h.Sum64()
+	}
+	if len(hashes) < w {
+		w = len(hashes)
+	}
+
+	var fingerprints []Fingerprint
+	last := -1
+	for start := 0; start+w <= len(hashes); start++ {
+		min := start
+		for i := start + 1; i < start+w; i++ {
+			if hashes[i] <= hashes[min] {
+				min = i
+			}
+		}
+		if min != last {
+			fingerprints = append(fingerprints, Fingerprint{Hash: hashes[min], Pos: min})
+			last = min
+		}
+	}
+	return fingerprints
+}
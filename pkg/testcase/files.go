@@ -0,0 +1,169 @@
+package testcase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ReadFiles 读取输入输出为文件的题目的测试数据，inputN.in 对应 expectedN.out
+// 输入目录中有清单文件时按清单的顺序读取，并记录每组测试数据是否为样例和预测试
+// 没有清单时读取输入目录中的所有文件，按编号的数值排序，都不是样例和预测试
+func ReadFiles(inputDir, expectedDir string) ([]Case, error) {
+	pairs, err := filePairs(inputDir)
+	if err != nil {
+		return nil, err
+	}
+	cases := make([]Case, 0, len(pairs))
+	for _, p := range pairs {
+		input, err := os.ReadFile(filepath.Join(inputDir, p.Input))
+		if err != nil {
+			return nil, err
+		}
+		expected, err := os.ReadFile(filepath.Join(expectedDir, p.Expected))
+		if err != nil {
+			return nil, err
+		}
+		cases = append(cases, Case{
+			Name:      p.Name,
+			Input:     string(input),
+			Expected:  string(expected),
+			IsSample:  p.IsSample,
+			IsPretest: p.IsPretest,
+		})
+	}
+	return cases, nil
+}
+
+// filePairs 返回输入目录中的测试数据文件，优先使用清单文件
+func filePairs(inputDir string) ([]Pair, error) {
+	data, err := os.ReadFile(filepath.Join(inputDir, ManifestName))
+	if err == nil {
+		var m Manifest
+		if err = json.Unmarshal(data, &m); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidManifest, err)
+		}
+		for _, p := range m.TestCases {
+			if !validName(p.Input) || !validName(p.Expected) {
+				return nil, fmt.Errorf("%w: invalid file name in %q", ErrInvalidManifest, p.Name)
+			}
+		}
+		return m.TestCases, nil
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(inputDir)
+	if err != nil {
+		return nil, err
+	}
+	var pairs []Pair
+	for _, e := range entries {
+		if name := e.Name(); !e.IsDir() && name != ManifestName {
+			pairs = append(pairs, Pair{
+				Name:     strings.TrimSuffix(strings.TrimPrefix(name, "input"), filepath.Ext(name)),
+				Input:    name,
+				Expected: strings.Replace(strings.Replace(name, "input", "expected", 1), ".in", ".out", 1),
+			})
+		}
+	}
+	// 与压缩包相同按编号的数值排序，input10.in 排在 input2.in 之后
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return lessName(pairs[i].Name, pairs[j].Name)
+	})
+	return pairs, nil
+}
+
+// validName 清单中的文件名只能是目录中的文件，不能指向其他目录
+func validName(name string) bool {
+	return name != "" && name != "." && name != ".." && filepath.Base(name) == name
+}
+
+// WriteFiles 按 inputN.in、expectedN.out 的格式写入测试数据，并在输入目录中写入清单文件
+// 先写入同级的临时目录再替换原来的目录，写入失败时原有的测试数据不受影响
+// 输入和输出目录不同时依次替换，两次替换之间读取的输入和输出可能来自不同的版本
+func WriteFiles(inputDir, expectedDir string, cases []Case) error {
+	inputDir, expectedDir = filepath.Clean(inputDir), filepath.Clean(expectedDir)
+	inputTmp, err := tempDirFor(inputDir)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(inputTmp)
+	expectedTmp := inputTmp
+	if expectedDir != inputDir {
+		if expectedTmp, err = tempDirFor(expectedDir); err != nil {
+			return err
+		}
+		defer os.RemoveAll(expectedTmp)
+	}
+
+	var manifest Manifest
+	for i, c := range cases {
+		name := strconv.Itoa(i + 1)
+		pair := Pair{Name: name, Input: "input" + name + ".in", Expected: "expected" + name + ".out",
+			IsSample: c.IsSample, IsPretest: c.IsPretest}
+		if err = os.WriteFile(filepath.Join(inputTmp, pair.Input), []byte(c.Input), 0644); err != nil {
+			return err
+		}
+		if err = os.WriteFile(filepath.Join(expectedTmp, pair.Expected), []byte(c.Expected), 0644); err != nil {
+			return err
+		}
+		manifest.TestCases = append(manifest.TestCases, pair)
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(filepath.Join(inputTmp, ManifestName), data, 0644); err != nil {
+		return err
+	}
+
+	if expectedTmp != inputTmp {
+		if err = replaceDir(expectedTmp, expectedDir); err != nil {
+			return err
+		}
+	}
+	return replaceDir(inputTmp, inputDir)
+}
+
+// tempDirFor 在 dir 的同级目录中创建临时目录，保证之后的重命名在同一个文件系统中
+func tempDirFor(dir string) (string, error) {
+	parent := filepath.Dir(dir)
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return "", err
+	}
+	tmp, err := os.MkdirTemp(parent, filepath.Base(dir)+".tmp-")
+	if err != nil {
+		return "", err
+	}
+	return tmp, os.Chmod(tmp, 0755)
+}
+
+// replaceDir 用 tmp 替换 dir，平台支持时原子地交换两个目录，否则先把 dir 移走再重命名
+// 替换后 tmp 中是原来的内容，由调用方删除
+func replaceDir(tmp, dir string) error {
+	if _, err := os.Stat(dir); errors.Is(err, fs.ErrNotExist) {
+		return os.Rename(tmp, dir)
+	} else if err != nil {
+		return err
+	}
+	if exchangeDir(tmp, dir) == nil {
+		return nil
+	}
+
+	old := tmp + ".old"
+	if err := os.Rename(dir, old); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, dir); err != nil {
+		_ = os.Rename(old, dir)
+		return err
+	}
+	return os.Rename(old, tmp)
+}
@@ -0,0 +1,8 @@
+package testcase
+
+import "golang.org/x/sys/unix"
+
+// exchangeDir 原子地交换两个目录，文件系统不支持时返回错误
+func exchangeDir(a, b string) error {
+	return unix.Renameat2(unix.AT_FDCWD, a, unix.AT_FDCWD, b, unix.RENAME_EXCHANGE)
+}
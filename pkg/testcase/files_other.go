@@ -0,0 +1,10 @@
+//go:build !linux
+
+package testcase
+
+import "errors"
+
+// exchangeDir 非 Linux 平台不支持原子地交换目录
+func exchangeDir(a, b string) error {
+	return errors.ErrUnsupported
+}
@@ -0,0 +1,323 @@
+package testcase
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ManifestName 压缩包中可选的清单文件，存在时按清单配对输入输出
+const ManifestName = "manifest.json"
+
+// 导入压缩包的默认限制
+const (
+	DefaultMaxFiles     = 1000     // 压缩包中最多的文件数量
+	DefaultMaxFileSize  = 16 << 20 // 单个文件解压后的最大字节数
+	DefaultMaxTotalSize = 64 << 20 // 所有文件解压后的最大字节数
+)
+
+var (
+	ErrEmptyArchive    = errors.New("archive contains no test cases")
+	ErrTooManyFiles    = errors.New("archive contains too many files")
+	ErrFileTooLarge    = errors.New("file in archive is too large")
+	ErrArchiveTooLarge = errors.New("archive is too large after decompression")
+	ErrUnpaired        = errors.New("archive contains unpaired input or expected files")
+	ErrInvalidManifest = errors.New("invalid manifest")
+)
+
+// Limits 导入压缩包的限制，零值使用默认值
+type Limits struct {
+	MaxFiles     int
+	MaxFileSize  int64
+	MaxTotalSize int64
+}
+
+// Case 一组测试数据
+type Case struct {
+	Name      string // 配对使用的名称，例如 1、input1
+	Input     string
+	Expected  string
+	IsSample  bool
+	IsPretest bool
+}
+
+// Pair 一组配对成功的文件
+type Pair struct {
+	Name      string `json:"name"`
+	Input     string `json:"input"`
+	Expected  string `json:"expected"`
+	IsSample  bool   `json:"is_sample"`
+	IsPretest bool   `json:"is_pretest"`
+}
+
+// Report 压缩包的配对结果
+type Report struct {
+	Pairs    []Pair   `json:"pairs"`    // 配对成功的文件，按名称排序
+	Unpaired []string `json:"unpaired"` // 缺少对应输出或输入的文件
+	Ignored  []string `json:"ignored"`  // 不是测试数据的文件
+}
+
+// Manifest 清单文件，显式指定每组测试数据的文件和是否为样例、预测试
+type Manifest struct {
+	TestCases []Pair `json:"test_cases"`
+}
+
+// ReadZip 读取压缩包中的测试数据，支持 1.in/1.out、input1.in/expected1.out 和清单文件三种格式
+// 存在无法配对的文件时返回 ErrUnpaired，report 中记录了具体的文件
+func ReadZip(r io.ReaderAt, size int64, limits Limits) (cases []Case, report Report, err error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, report, err
+	}
+	return ReadDir(zr, "", limits)
+}
+
+// ReadDir 读取压缩包中 dir 目录下的测试数据，dir 为空时读取整个压缩包，report 中的文件名相对于 dir
+func ReadDir(zr *zip.Reader, dir string, limits Limits) (cases []Case, report Report, err error) {
+	limits = limits.withDefaults()
+	files, err := dirFiles(zr, dir, limits)
+	if err != nil {
+		return nil, report, err
+	}
+	if manifest, ok := files[ManifestName]; ok {
+		report, err = pairByManifest(manifest, files)
+	} else {
+		report = pairByName(files)
+	}
+	if err != nil {
+		return nil, report, err
+	}
+	return readPairs(files, report, limits)
+}
+
+// ReadPairs 按 pairs 读取压缩包中 dir 目录下的测试数据，用于其他格式的题目包在配置文件中列出的测试数据
+// pairs 中的文件名相对于 dir，名称为空时使用序号
+func ReadPairs(zr *zip.Reader, dir string, pairs []Pair, limits Limits) (cases []Case, report Report, err error) {
+	limits = limits.withDefaults()
+	files, err := dirFiles(zr, dir, limits)
+	if err != nil {
+		return nil, report, err
+	}
+	return readPairs(files, pairList(pairs, files, nil), limits)
+}
+
+// dirFiles 收集 dir 目录下的文件并检查数量和大小的限制，键为相对于 dir 的文件名
+func dirFiles(zr *zip.Reader, dir string, limits Limits) (map[string]*zip.File, error) {
+	prefix := ""
+	if dir != "" {
+		prefix = path.Clean(dir) + "/"
+	}
+
+	files := make(map[string]*zip.File)
+	var total uint64
+	for _, f := range zr.File {
+		name := path.Clean(f.Name)
+		if f.FileInfo().IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if len(files) >= limits.MaxFiles {
+			return nil, ErrTooManyFiles
+		}
+		if f.UncompressedSize64 > uint64(limits.MaxFileSize) {
+			return nil, fmt.Errorf("%w: %s", ErrFileTooLarge, f.Name)
+		}
+		if total += f.UncompressedSize64; total > uint64(limits.MaxTotalSize) {
+			return nil, ErrArchiveTooLarge
+		}
+		files[strings.TrimPrefix(name, prefix)] = f
+	}
+	return files, nil
+}
+
+// readPairs 读取配对成功的文件
+func readPairs(files map[string]*zip.File, report Report, limits Limits) ([]Case, Report, error) {
+	if len(report.Unpaired) > 0 {
+		return nil, report, ErrUnpaired
+	}
+	if len(report.Pairs) == 0 {
+		return nil, report, ErrEmptyArchive
+	}
+
+	cases := make([]Case, len(report.Pairs))
+	var read int64
+	var err error
+	for i, p := range report.Pairs {
+		c := Case{Name: p.Name, IsSample: p.IsSample, IsPretest: p.IsPretest}
+		if c.Input, err = readFile(files[p.Input], limits.MaxFileSize); err != nil {
+			return nil, report, err
+		}
+		if c.Expected, err = readFile(files[p.Expected], limits.MaxFileSize); err != nil {
+			return nil, report, err
+		}
+		if read += int64(len(c.Input) + len(c.Expected)); read > limits.MaxTotalSize {
+			return nil, report, ErrArchiveTooLarge
+		}
+		cases[i] = c
+	}
+	return cases, report, nil
+}
+
+// WriteZip 把测试数据写成 1.in/1.out 格式的压缩包，并附带记录样例的清单文件
+func WriteZip(w io.Writer, cases []Case) error {
+	zw := zip.NewWriter(w)
+	if err := WriteDir(zw, "", cases); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// WriteDir 把测试数据和清单文件写入压缩包的 dir 目录，dir 为空时写入根目录
+func WriteDir(zw *zip.Writer, dir string, cases []Case) error {
+	var manifest Manifest
+	for i, c := range cases {
+		name := strconv.Itoa(i + 1)
+		pair := Pair{Name: name, Input: name + ".in", Expected: name + ".out", IsSample: c.IsSample, IsPretest: c.IsPretest}
+		if err := writeFile(zw, path.Join(dir, pair.Input), c.Input); err != nil {
+			return err
+		}
+		if err := writeFile(zw, path.Join(dir, pair.Expected), c.Expected); err != nil {
+			return err
+		}
+		manifest.TestCases = append(manifest.TestCases, pair)
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(zw, path.Join(dir, ManifestName), string(data))
+}
+
+func (l Limits) withDefaults() Limits {
+	if l.MaxFiles <= 0 {
+		l.MaxFiles = DefaultMaxFiles
+	}
+	if l.MaxFileSize <= 0 {
+		l.MaxFileSize = DefaultMaxFileSize
+	}
+	if l.MaxTotalSize <= 0 {
+		l.MaxTotalSize = DefaultMaxTotalSize
+	}
+	return l
+}
+
+// pairByName 按文件名配对，input1.in 和 expected1.out 视为 1.in 和 1.out
+func pairByName(files map[string]*zip.File) (report Report) {
+	inputs := make(map[string]string)
+	expected := make(map[string]string)
+	for name := range files {
+		ext := path.Ext(name)
+		stem := strings.TrimSuffix(name, ext)
+		dir, base := path.Split(stem)
+		switch ext {
+		case ".in":
+			inputs[dir+strings.TrimPrefix(base, "input")] = name
+		case ".out", ".ans":
+			expected[dir+strings.TrimPrefix(base, "expected")] = name
+		default:
+			report.Ignored = append(report.Ignored, name)
+		}
+	}
+
+	for key, in := range inputs {
+		out, ok := expected[key]
+		if !ok {
+			report.Unpaired = append(report.Unpaired, in)
+			continue
+		}
+		delete(expected, key)
+		report.Pairs = append(report.Pairs, Pair{Name: key, Input: in, Expected: out})
+	}
+	for _, out := range expected {
+		report.Unpaired = append(report.Unpaired, out)
+	}
+	sort.Slice(report.Pairs, func(i, j int) bool { return lessName(report.Pairs[i].Name, report.Pairs[j].Name) })
+	sort.Strings(report.Unpaired)
+	sort.Strings(report.Ignored)
+	return
+}
+
+// pairByManifest 按清单文件配对，清单中引用了不存在的文件时记为无法配对
+func pairByManifest(manifest *zip.File, files map[string]*zip.File) (report Report, err error) {
+	data, err := readFile(manifest, DefaultMaxFileSize)
+	if err != nil {
+		return report, err
+	}
+	var m Manifest
+	if err = json.Unmarshal([]byte(data), &m); err != nil {
+		return report, fmt.Errorf("%w: %v", ErrInvalidManifest, err)
+	}
+	return pairList(m.TestCases, files, map[string]bool{ManifestName: true}), nil
+}
+
+// pairList 按列出的文件配对，引用了不存在的文件时记为无法配对，used 中的文件不记为忽略
+func pairList(pairs []Pair, files map[string]*zip.File, used map[string]bool) (report Report) {
+	if used == nil {
+		used = make(map[string]bool)
+	}
+	for i, p := range pairs {
+		p.Input, p.Expected = path.Clean(p.Input), path.Clean(p.Expected)
+		if p.Name == "" {
+			p.Name = strconv.Itoa(i + 1)
+		}
+		missing := false
+		for _, name := range []string{p.Input, p.Expected} {
+			if _, ok := files[name]; !ok {
+				report.Unpaired = append(report.Unpaired, name)
+				missing = true
+			}
+			used[name] = true
+		}
+		if !missing {
+			report.Pairs = append(report.Pairs, p)
+		}
+	}
+	for name := range files {
+		if !used[name] {
+			report.Ignored = append(report.Ignored, name)
+		}
+	}
+	sort.Strings(report.Ignored)
+	return report
+}
+
+// lessName 名称都是数字时按数值排序，否则按字符串排序
+func lessName(a, b string) bool {
+	x, errA := strconv.Atoi(path.Base(a))
+	y, errB := strconv.Atoi(path.Base(b))
+	if errA == nil && errB == nil && x != y {
+		return x < y
+	}
+	return a < b
+}
+
+func readFile(f *zip.File, limit int64) (string, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+	// 文件头中的大小可能被伪造，读取时再限制一次
+	data, err := io.ReadAll(io.LimitReader(rc, limit+1))
+	if err != nil {
+		return "", err
+	}
+	if int64(len(data)) > limit {
+		return "", fmt.Errorf("%w: %s", ErrFileTooLarge, f.Name)
+	}
+	return string(data), nil
+}
+
+func writeFile(zw *zip.Writer, name, content string) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, content)
+	return err
+}
@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCursor 无法解析的分页游标
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// EncodeCursor 把最后一条记录的创建时间和ID编码为分页游标
+func EncodeCursor(t time.Time, id string) string {
+	raw := strconv.FormatInt(t.UnixNano(), 10) + "|" + id
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor 解析分页游标，空字符串表示从第一页开始
+func DecodeCursor(cursor string) (t time.Time, id string, err error) {
+	if cursor == "" {
+		return
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return t, "", ErrInvalidCursor
+	}
+	nano, id, ok := strings.Cut(string(raw), "|")
+	if !ok || id == "" {
+		return t, "", ErrInvalidCursor
+	}
+	n, err := strconv.ParseInt(nano, 10, 64)
+	if err != nil {
+		return t, "", ErrInvalidCursor
+	}
+	return time.Unix(0, n), id, nil
+}
@@ -37,6 +37,7 @@ func NewSubmissionEndpoints() []*api.Endpoint {
 
 type SubmissionService interface {
 	SubmitCode(ctx context.Context, in *SubmitRequest, opts ...client.CallOption) (*SubmitResponse, error)
+	RunCode(ctx context.Context, in *RunRequest, opts ...client.CallOption) (*RunResponse, error)
 }
 
 type submissionService struct {
@@ -61,15 +62,27 @@ func (c *submissionService) SubmitCode(ctx context.Context, in *SubmitRequest, o
 	return out, nil
 }
 
+func (c *submissionService) RunCode(ctx context.Context, in *RunRequest, opts ...client.CallOption) (*RunResponse, error) {
+	req := c.c.NewRequest(c.name, "Submission.RunCode", in)
+	out := new(RunResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Submission service
 
 type SubmissionHandler interface {
 	SubmitCode(context.Context, *SubmitRequest, *SubmitResponse) error
+	RunCode(context.Context, *RunRequest, *RunResponse) error
 }
 
 func RegisterSubmissionHandler(s server.Server, hdlr SubmissionHandler, opts ...server.HandlerOption) error {
 	type submission interface {
 		SubmitCode(ctx context.Context, in *SubmitRequest, out *SubmitResponse) error
+		RunCode(ctx context.Context, in *RunRequest, out *RunResponse) error
 	}
 	type Submission struct {
 		submission
@@ -85,3 +98,7 @@ type submissionHandler struct {
 func (h *submissionHandler) SubmitCode(ctx context.Context, in *SubmitRequest, out *SubmitResponse) error {
 	return h.SubmissionHandler.SubmitCode(ctx, in, out)
 }
+
+func (h *submissionHandler) RunCode(ctx context.Context, in *RunRequest, out *RunResponse) error {
+	return h.SubmissionHandler.RunCode(ctx, in, out)
+}
@@ -0,0 +1,20 @@
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	v1 "online_judge/api/v1"
+)
+
+type ApiAdminContest struct{}
+
+func (a *ApiAdminContest) InitAdminContest(Router *gin.RouterGroup) {
+	adminApi := v1.ApiGroupApp.ApiAdmin
+	adminContest := Router.Group("/contest")
+	{
+		adminContest.POST("/create", adminApi.CreateContest)       // 创建比赛
+		adminContest.PUT("/update", adminApi.UpdateContest)        // 更新比赛信息
+		adminContest.DELETE("/delete", adminApi.DeleteContest)     // 删除比赛
+		adminContest.GET("/resolver", adminApi.GetContestResolver) // 导出揭晓顺序
+		adminContest.POST("/unfreeze", adminApi.UnfreezeContest)   // 揭晓封榜结果
+	}
+}
@@ -18,8 +18,18 @@ func (a *ApiAdminProblem) InitAdminProblem(Router *gin.RouterGroup) {
 			file.PUT("/update", adminApi.UpdateProblemWithFile)         // 创建新题目
 			file.DELETE("/:problem_id", adminApi.DeleteProblemWithFile) // 删除题目
 		}
-		adminProblem.POST("/create", adminApi.CreateProblem)   // 创建新题目
-		adminProblem.PUT("/update", adminApi.UpdateProblem)    // 更新题目信息
-		adminProblem.DELETE("/delete", adminApi.DeleteProblem) // 删除题目
+		pkg := adminProblem.Group("/package") // 题目包
+		{
+			pkg.POST("/import", adminApi.ImportProblemPackage)            // 导入题目包
+			pkg.GET("/export/:problem_id", adminApi.ExportProblemPackage) // 导出题目包
+		}
+		adminProblem.POST("/create", adminApi.CreateProblem)                         // 创建新题目
+		adminProblem.PUT("/update", adminApi.UpdateProblem)                          // 更新题目信息
+		adminProblem.DELETE("/delete", adminApi.DeleteProblem)                       // 删除题目
+		adminProblem.GET("/test-cases/:problem_id", adminApi.GetProblemTestCases)    // 获取题目全部测试数据
+		adminProblem.POST("/:problem_id/testcases/import", adminApi.ImportTestCases) // 从压缩包导入测试数据
+		adminProblem.GET("/:problem_id/testcases/export", adminApi.ExportTestCases)  // 导出测试数据
+		adminProblem.POST("/:problem_id/rejudge", adminApi.Rejudge)                  // 重新评测
+		adminProblem.GET("/:problem_id/similarity", adminApi.GetSimilarityReport)    // 代码相似度报告
 	}
 }
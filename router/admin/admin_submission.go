@@ -0,0 +1,17 @@
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	v1 "online_judge/api/v1"
+)
+
+type ApiAdminSubmission struct{}
+
+func (a *ApiAdminSubmission) InitAdminSubmission(Router *gin.RouterGroup) {
+	adminApi := v1.ApiGroupApp.ApiAdmin
+	adminSubmission := Router.Group("/submission")
+	{
+		adminSubmission.POST("/rejudge", adminApi.RejudgeSubmissions) // 按条件重新评测
+		adminSubmission.PUT("/:id/verdict", adminApi.OverrideVerdict) // 手动修改评测结果
+	}
+}
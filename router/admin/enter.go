@@ -4,4 +4,6 @@ type RouterGroup struct {
 	ApiAdminUser
 	ApiAdminProblem
 	ApiAdminCategory
+	ApiAdminContest
+	ApiAdminSubmission
 }
@@ -0,0 +1,20 @@
+package contest
+
+import (
+	"github.com/gin-gonic/gin"
+	v1 "online_judge/api/v1"
+	"online_judge/middlewares"
+)
+
+type Contest struct{}
+
+// InitContest 比赛相关
+func (ct *Contest) InitContest(RouterGroup *gin.RouterGroup) {
+	contestApi := v1.ApiGroupApp.ApiContest
+
+	RouterGroup.GET("/list", contestApi.GetContestList)                  // 获取比赛列表
+	RouterGroup.GET("/:contest_id", contestApi.GetContestDetail)         // 获取比赛详细
+	RouterGroup.GET("/:contest_id/scoreboard", contestApi.GetScoreboard) // 获取比赛排行榜
+	RouterGroup.POST("/:contest_id/register",
+		middlewares.JWTUserAuthMiddleware(), contestApi.RegisterContest) // 报名比赛
+}
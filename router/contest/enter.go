@@ -0,0 +1,5 @@
+package contest
+
+type RouterGroup struct {
+	Contest
+}
@@ -4,7 +4,9 @@ import (
 	"online_judge/router/admin"
 	"online_judge/router/auth"
 	"online_judge/router/category"
+	"online_judge/router/contest"
 	"online_judge/router/evaluation"
+	"online_judge/router/language"
 	"online_judge/router/leaderboard"
 	"online_judge/router/problem"
 	"online_judge/router/submission"
@@ -22,6 +24,8 @@ type RouterGroup struct {
 	Leaderboard leaderboard.RouterGroup
 	Evaluation  evaluation.RouterGroup
 	Category    category.RouterGroup
+	Language    language.RouterGroup
+	Contest     contest.RouterGroup
 }
 
 var RouterGroupApp = new(RouterGroup)
@@ -0,0 +1,5 @@
+package language
+
+type RouterGroup struct {
+	Language
+}
@@ -0,0 +1,14 @@
+package language
+
+import (
+	"github.com/gin-gonic/gin"
+	v1 "online_judge/api/v1"
+)
+
+type Language struct{}
+
+func (l *Language) InitLanguage(Router *gin.RouterGroup) {
+	languageApi := v1.ApiGroupApp.ApiLanguage
+
+	Router.GET("", languageApi.GetLanguageList) // 获取支持的语言列表
+}
@@ -3,6 +3,7 @@ package leaderboard
 import (
 	"github.com/gin-gonic/gin"
 	v1 "online_judge/api/v1"
+	"online_judge/middlewares"
 )
 
 type ApiLeaderboard struct{}
@@ -10,5 +11,7 @@ type ApiLeaderboard struct{}
 func (l *ApiLeaderboard) InitLeaderboard(RouterGroup *gin.RouterGroup) {
 	leaderboardApi := v1.ApiGroupApp.ApiLeaderboard
 
-	RouterGroup.GET("/user", leaderboardApi.GetUserLeaderboard)
+	RouterGroup.GET("/user", leaderboardApi.GetUserLeaderboard)                           // 获取用户排行榜
+	RouterGroup.GET("/me", middlewares.JWTUserAuthMiddleware(), leaderboardApi.GetMyRank) // 获取当前用户排名
+	RouterGroup.GET("/problem/:problem_id", leaderboardApi.GetProblemLeaderboard)         // 获取题目最快通过排行
 }
@@ -59,6 +59,8 @@ func SetUpRouter(mode string) *gin.Engine {
 	leaderboardRouter := RouterGroupApp.Leaderboard
 	evaluationRouter := RouterGroupApp.Evaluation
 	categoryRouter := RouterGroupApp.Category
+	languageRouter := RouterGroupApp.Language
+	contestRouter := RouterGroupApp.Contest
 
 	{
 		// 健康监测
@@ -91,9 +93,11 @@ func SetUpRouter(mode string) *gin.Engine {
 	adminGroup := router.Group("/admin")
 	adminGroup.Use(middlewares.JWTAdminAuthMiddleware())
 	{
-		adminRouter.InitAdminProblem(adminGroup)  // 注册管理员的 problem 相关路由
-		adminRouter.InitAdminUser(adminGroup)     // 注册管理员的 user 相关路由
-		adminRouter.InitAdminCategory(adminGroup) // 注册管理员的 category 相关路由
+		adminRouter.InitAdminProblem(adminGroup)    // 注册管理员的 problem 相关路由
+		adminRouter.InitAdminUser(adminGroup)       // 注册管理员的 user 相关路由
+		adminRouter.InitAdminCategory(adminGroup)   // 注册管理员的 category 相关路由
+		adminRouter.InitAdminContest(adminGroup)    // 注册管理员的 contest 相关路由
+		adminRouter.InitAdminSubmission(adminGroup) // 注册管理员的 submission 相关路由
 	}
 
 	adminApi := v1.ApiGroupApp.ApiAdmin
@@ -133,6 +137,7 @@ func SetUpRouter(mode string) *gin.Engine {
 
 	// 判题结果相关api
 	evaluationGroup := router.Group("/evaluation")
+	evaluationGroup.Use(middlewares.JWTOptionalAuthMiddleware())
 	{
 		evaluationRouter.InitEvaluate(evaluationGroup)
 	}
@@ -143,6 +148,19 @@ func SetUpRouter(mode string) *gin.Engine {
 		categoryRouter.InitCategory(categoryGroup)
 	}
 
+	// 语言相关api
+	languageGroup := router.Group("/languages")
+	{
+		languageRouter.InitLanguage(languageGroup)
+	}
+
+	// 比赛相关api
+	contestGroup := router.Group("/contest")
+	contestGroup.Use(middlewares.JWTOptionalAuthMiddleware())
+	{
+		contestRouter.InitContest(contestGroup)
+	}
+
 	return r
 }
 
@@ -3,8 +3,14 @@ package submission
 import (
 	"github.com/gin-gonic/gin"
 	v1 "online_judge/api/v1"
+	"online_judge/middlewares"
+	"online_judge/setting"
+	"time"
 )
 
+// defaultRunLimit 每个用户每分钟使用自定义输入运行代码的默认次数上限
+const defaultRunLimit = 10
+
 type Submission struct{}
 
 func (s *Submission) InitSubmission(Router *gin.RouterGroup) {
@@ -12,4 +18,20 @@ func (s *Submission) InitSubmission(Router *gin.RouterGroup) {
 
 	Router.POST("/code", submissionApi.SubmitCode)              // 提交代码
 	Router.POST("/file/code", submissionApi.SubmitCodeWithFile) // 提交代码
+	Router.GET("/:id/stream", submissionApi.StreamSubmission)   // 推送评测进度
+
+	// 自定义输入运行代码，不创建提交记录，每个用户单独限流
+	Router.POST("/run", middlewares.UserRateLimiterMiddleWare("run", runLimit(), time.Minute), submissionApi.RunCode)
+
+	Router.GET("/:id", submissionApi.GetSubmissionDetail)                   // 获取单个提交详细
+	Router.GET("/user/:user_id", submissionApi.GetUserSubmissions)          // 获取用户的提交记录
+	Router.GET("/problem/:problem_id", submissionApi.GetProblemSubmissions) // 获取题目的提交记录
+}
+
+// runLimit 读取每个用户每分钟运行代码的次数上限
+func runLimit() int64 {
+	if setting.Conf.JudgementConfig == nil || setting.Conf.JudgementConfig.RunLimit <= 0 {
+		return defaultRunLimit
+	}
+	return int64(setting.Conf.JudgementConfig.RunLimit)
 }
@@ -14,4 +14,5 @@ func (u *ApiUser) InitApiUser(Router *gin.RouterGroup) {
 	Router.POST("/user-id", userApi.GetUserID)
 	Router.POST("/detail", userApi.GetUserDetail)
 	Router.PUT("/update", userApi.UpdateUserDetail)
+	Router.GET("/profile", userApi.GetUserProfile) // 获取用户主页统计
 }
@@ -0,0 +1,147 @@
+package admin
+
+import (
+	"go.uber.org/zap"
+	"online_judge/consts"
+	"online_judge/consts/resp_code"
+	"online_judge/dao/mysql"
+	"online_judge/dao/redis"
+	"online_judge/models/admin/request"
+	"online_judge/models/common/response"
+	"online_judge/pkg/utils"
+	"time"
+)
+
+type AdminContestService struct{}
+
+// CreateContest 创建比赛
+func (a *AdminContestService) CreateContest(req request.AdminContestReq) (resp response.ResponseWithData) {
+	contest, code := convertContest(utils.GetUUID(), req)
+	if code != resp_code.Success {
+		resp.Code = code
+		return
+	}
+	if err := mysql.CreateContest(contest); err != nil {
+		resp.Code = resp_code.InternalServerError
+		zap.L().Error("services-CreateContest-CreateContest ", zap.Error(err))
+		return
+	}
+	resp.Code = resp_code.Success
+	resp.Data = struct {
+		ContestID string `json:"contest_id"`
+	}{ContestID: contest.ContestID}
+	return
+}
+
+// UpdateContest 更新比赛信息，题目列表整体替换
+func (a *AdminContestService) UpdateContest(req request.AdminContestReq) (resp response.Response) {
+	contest, code := convertContest(req.ContestID, req)
+	if code != resp_code.Success {
+		resp.Code = code
+		return
+	}
+	if err := mysql.UpdateContest(contest); err != nil {
+		if err == mysql.ErrContestNotFound {
+			resp.Code = resp_code.ContestNotExist
+			return
+		}
+		resp.Code = resp_code.InternalServerError
+		zap.L().Error("services-UpdateContest-UpdateContest ", zap.Error(err))
+		return
+	}
+	deleteScoreboardSnapshot(req.ContestID)
+	resp.Code = resp_code.Success
+	return
+}
+
+// DeleteContest 删除比赛
+func (a *AdminContestService) DeleteContest(contestID string) (resp response.Response) {
+	if err := mysql.DeleteContest(contestID); err != nil {
+		if err == mysql.ErrContestNotFound {
+			resp.Code = resp_code.ContestNotExist
+			return
+		}
+		resp.Code = resp_code.InternalServerError
+		zap.L().Error("services-DeleteContest-DeleteContest ", zap.Error(err))
+		return
+	}
+	deleteScoreboardSnapshot(contestID)
+	resp.Code = resp_code.Success
+	return
+}
+
+// deleteScoreboardSnapshot 比赛信息变化后排行榜快照失效
+func deleteScoreboardSnapshot(contestID string) {
+	if err := redis.DeleteScoreboardSnapshot(contestID); err != nil {
+		zap.L().Error("services-deleteScoreboardSnapshot-DeleteScoreboardSnapshot ", zap.Error(err))
+	}
+}
+
+// convertContest 校验比赛参数并补全默认值
+func convertContest(contestID string, req request.AdminContestReq) (*mysql.Contest, int) {
+	if req.Rule != consts.ContestRuleICPC && req.Rule != consts.ContestRuleOI {
+		return nil, resp_code.InvalidContest
+	}
+	if !req.EndTime.After(req.StartTime) || req.Penalty < 0 || req.FreezeMinutes < 0 {
+		return nil, resp_code.InvalidContest
+	}
+	// 封榜时间不能早于比赛开始
+	if time.Duration(req.FreezeMinutes)*time.Minute > req.EndTime.Sub(req.StartTime) {
+		return nil, resp_code.InvalidContest
+	}
+	contest := &mysql.Contest{
+		ContestID:     contestID,
+		Title:         req.Title,
+		Description:   req.Description,
+		Rule:          req.Rule,
+		StartTime:     req.StartTime,
+		EndTime:       req.EndTime,
+		Penalty:       req.Penalty,
+		FreezeMinutes: req.FreezeMinutes,
+		Problems:      make([]mysql.ContestProblem, len(req.Problems)),
+	}
+	if contest.Penalty == 0 {
+		contest.Penalty = consts.DefaultContestPenalty
+	}
+
+	seen := make(map[string]bool, 2*len(req.Problems))
+	for i, p := range req.Problems {
+		label := p.Label
+		if label == "" {
+			label = problemLabel(i)
+		}
+		score := p.Score
+		if score == 0 {
+			score = consts.DefaultContestScore
+		}
+		if score < 0 || seen[p.ProblemID] || seen["label:"+label] {
+			return nil, resp_code.InvalidContest
+		}
+		seen[p.ProblemID], seen["label:"+label] = true, true
+
+		exists, err := mysql.CheckProblemIDExists(p.ProblemID)
+		if err != nil {
+			zap.L().Error("services-convertContest-CheckProblemIDExists ", zap.Error(err))
+			return nil, resp_code.SearchDBError
+		}
+		if !exists {
+			return nil, resp_code.ProblemNotExist
+		}
+		contest.Problems[i] = mysql.ContestProblem{
+			ContestID: contestID,
+			ProblemID: p.ProblemID,
+			Label:     label,
+			Score:     score,
+		}
+	}
+	return contest, resp_code.Success
+}
+
+// problemLabel 按顺序生成题号 A B ... Z AA AB
+func problemLabel(i int) string {
+	label := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		label = string(rune('A'+(i-1)%26)) + label
+	}
+	return label
+}
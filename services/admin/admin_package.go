@@ -0,0 +1,148 @@
+package admin
+
+import (
+	"errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"online_judge/consts"
+	"online_judge/consts/resp_code"
+	"online_judge/dao/mysql"
+	"online_judge/models/admin/request"
+	"online_judge/models/common/response"
+	"online_judge/pkg/language"
+	"online_judge/pkg/problempkg"
+	"online_judge/pkg/testcase"
+	"online_judge/pkg/utils"
+)
+
+// ImportProblemPackage 从题目包创建新题目，成功时 response.Data 为新题目的ID
+// 题目包格式错误时 response.Data 为错误原因
+func (p *AdminProblemService) ImportProblemPackage(req request.AdminImportProblemPackageReq) (response response.ResponseWithData) {
+	pkg, err := problempkg.Read(req.Archive, req.Size, testcase.Limits{})
+	if err == nil {
+		err = checkPackageLanguages(pkg)
+	}
+	if err != nil {
+		response.Code = resp_code.InvalidProblemPackage
+		response.Data = err.Error()
+		zap.L().Error("services-ImportProblemPackage-Read ", zap.Error(err))
+		return
+	}
+
+	categories, err := mysql.GetOrCreateCategoryIDs(pkg.Meta.Categories)
+	if err != nil {
+		response.Code = resp_code.InternalServerError
+		zap.L().Error("services-ImportProblemPackage-GetOrCreateCategoryIDs ", zap.Error(err))
+		return
+	}
+
+	createReq := request.AdminCreateProblemReq{
+		ProblemID:  utils.GetUUID(),
+		Title:      pkg.Meta.Title,
+		Content:    pkg.Statement,
+		Difficulty: pkg.Meta.Difficulty,
+		MaxRuntime: pkg.Meta.TimeLimit,
+		MaxMemory:  pkg.Meta.MemoryLimit,
+		Category:   categories,
+		TestCases:  make([]*request.TestCase, len(pkg.TestCases)),
+
+		StopOnPretestFailure: pkg.Meta.StopOnPretestFailure,
+	}
+	for i, c := range pkg.TestCases {
+		createReq.TestCases[i] = &request.TestCase{
+			TID:       utils.GetUUID(),
+			PID:       createReq.ProblemID,
+			Input:     c.Input,
+			Expected:  c.Expected,
+			IsSample:  c.IsSample,
+			IsPretest: c.IsPretest,
+		}
+	}
+	if c := pkg.Meta.Checker; c != nil {
+		createReq.ProblemChecker = request.ProblemChecker{
+			CheckerMode:     c.Mode,
+			CheckerEpsilon:  c.Epsilon,
+			CheckerCode:     pkg.CheckerCode,
+			CheckerLanguage: c.Language,
+		}
+	}
+	if i := pkg.Meta.Interactor; i != nil {
+		createReq.ProblemInteractor = request.ProblemInteractor{
+			InteractorCode:     pkg.InteractorCode,
+			InteractorLanguage: i.Language,
+		}
+	}
+
+	response.Code = p.CreateProblem(createReq).Code
+	if response.Code == resp_code.Success {
+		response.Data = createReq.ProblemID
+	}
+	return
+}
+
+// ExportProblemPackage 把题目导出为题目包，response.Data 为 *problempkg.Package
+func (p *AdminProblemService) ExportProblemPackage(pid string) (response response.ResponseWithData) {
+	problem, err := mysql.GetProblemPackage(pid)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.Code = resp_code.ProblemNotExist
+			return
+		}
+		response.Code = resp_code.SearchDBError
+		zap.L().Error("services-ExportProblemPackage-GetProblemPackage ", zap.Error(err))
+		return
+	}
+
+	pkg := &problempkg.Package{
+		Meta: problempkg.Meta{
+			Title:       problem.Title,
+			Difficulty:  problem.Difficulty,
+			TimeLimit:   problem.MaxRuntime,
+			MemoryLimit: problem.MaxMemory,
+			Categories:  make([]string, 0, len(problem.ProblemCategories)),
+
+			StopOnPretestFailure: problem.StopOnPretestFailure,
+		},
+		Statement:   problem.Content,
+		TestCases:   make([]testcase.Case, len(problem.TestCases)),
+		CheckerCode: problem.CheckerCode,
+	}
+	for _, pc := range problem.ProblemCategories {
+		if pc.Category != nil {
+			pkg.Meta.Categories = append(pkg.Meta.Categories, pc.Category.Name)
+		}
+	}
+	for i, tc := range problem.TestCases {
+		pkg.TestCases[i] = testcase.Case{Input: tc.Input, Expected: tc.Expected, IsSample: tc.IsSample, IsPretest: tc.IsPretest}
+	}
+	if problem.CheckerMode != "" {
+		pkg.Meta.Checker = &problempkg.Checker{
+			Mode:     problem.CheckerMode,
+			Epsilon:  problem.CheckerEpsilon,
+			Language: problem.CheckerLanguage,
+		}
+	}
+	if problem.InteractorCode != "" {
+		pkg.Meta.Interactor = &problempkg.Interactor{Language: problem.InteractorLanguage}
+		pkg.InteractorCode = problem.InteractorCode
+	}
+
+	response.Code = resp_code.Success
+	response.Data = pkg
+	return
+}
+
+// checkPackageLanguages 检查题目包中的检查器和交互器语言是否被评测机支持
+func checkPackageLanguages(pkg *problempkg.Package) error {
+	if c := pkg.Meta.Checker; c != nil && c.Mode == consts.CheckerCustom {
+		if _, ok := language.Get(c.Language); !ok {
+			return errors.New("unsupported checker language: " + c.Language)
+		}
+	}
+	if i := pkg.Meta.Interactor; i != nil {
+		if _, ok := language.Get(i.Language); !ok {
+			return errors.New("unsupported interactor language: " + i.Language)
+		}
+	}
+	return nil
+}
@@ -11,6 +11,8 @@ import (
 	"online_judge/dao/mysql"
 	"online_judge/models/admin/request"
 	"online_judge/models/common/response"
+	problemResponse "online_judge/models/problem/response"
+	"online_judge/pkg/testcase"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -48,6 +50,10 @@ func (p *AdminProblemService) CreateProblem(request request.AdminCreateProblemRe
 		MaxMemory:         request.MaxMemory,
 		TestCases:         p.convertTestCases(request.TestCases),
 		ProblemCategories: categories,
+		Checker:           p.convertChecker(request.ProblemChecker),
+		Interactor:        p.convertInteractor(request.ProblemInteractor),
+
+		StopOnPretestFailure: request.StopOnPretestFailure,
 	})
 
 	if err != nil {
@@ -61,6 +67,7 @@ func (p *AdminProblemService) CreateProblem(request request.AdminCreateProblemRe
 		zap.L().Error("services-CreateProblem-CreateProblem ", zap.Error(err))
 		return
 	}
+	p.saveProblemVersion(request.ProblemID)
 
 	response.Code = resp_code.Success
 	return
@@ -122,6 +129,16 @@ func (p *AdminProblemService) UpdateProblem(request request.AdminUpdateProblemRe
 		MaxRuntime: p.defaultResolve(request.MaxRuntime, oldProblem.MaxRuntime).(int),
 		MaxMemory:  p.defaultResolve(request.MaxMemory, oldProblem.MaxMemory).(int),
 		TestCases:  p.convertTestCases(request.TestCases),
+		Interactor: p.convertInteractor(request.ProblemInteractor),
+
+		StopOnPretestFailure: oldProblem.StopOnPretestFailure,
+	}
+	if request.StopOnPretestFailure != nil {
+		newProblem.StopOnPretestFailure = *request.StopOnPretestFailure
+	}
+	// checker_mode 为空时沿用原先的检查方式
+	if request.CheckerMode != "" {
+		newProblem.Checker = p.convertChecker(request.ProblemChecker)
 	}
 	err = mysql.UpdateProblem(newProblem, oldProblem.ProblemID, request.Category)
 
@@ -137,10 +154,38 @@ func (p *AdminProblemService) UpdateProblem(request request.AdminUpdateProblemRe
 		response.Code = resp_code.InternalServerError
 		return
 	}
+	p.saveProblemVersion(newProblem.ProblemID)
 	response.Code = resp_code.Success
 	return
 }
 
+// saveProblemVersion 题目修改后保存新的题目版本，提交评测时只读取最新的版本号
+func (p *AdminProblemService) saveProblemVersion(pid string) {
+	problem, err := mysql.GetEntireProblem(pid)
+	if err == nil {
+		_, err = mysql.SaveProblemVersion(problem)
+	}
+	if err != nil {
+		zap.L().Error("services-saveProblemVersion-SaveProblemVersion ", zap.Error(err))
+	}
+}
+
+// saveProblemWithFileVersion 和 saveProblemVersion 相同，测试数据从题目的输入输出文件中读取
+func (p *AdminProblemService) saveProblemWithFileVersion(pid string) {
+	problem, err := mysql.GetEntireProblemWithFile(pid)
+	if err != nil {
+		zap.L().Error("services-saveProblemWithFileVersion-GetEntireProblemWithFile ", zap.Error(err))
+		return
+	}
+	cases, err := testcase.ReadFiles(problem.InputPath, problem.ExpectedPath)
+	if err == nil {
+		_, err = mysql.SaveProblemWithFileVersion(problem, cases)
+	}
+	if err != nil {
+		zap.L().Error("services-saveProblemWithFileVersion-SaveProblemWithFileVersion ", zap.Error(err))
+	}
+}
+
 // DeleteProblem 删除题目
 func (p *AdminProblemService) DeleteProblem(request request.AdminDeleteProblemReq) (response response.Response) {
 	// 删除题目
@@ -187,6 +232,10 @@ func (p *AdminProblemService) CreateProblemWithFile(request request.AdminCreateP
 		MaxMemory:    request.MaxMemory,
 		InputPath:    request.InputDst,
 		ExpectedPath: request.ExpectedDst,
+		Checker:      p.convertChecker(request.ProblemChecker),
+		Interactor:   p.convertInteractor(request.ProblemInteractor),
+
+		StopOnPretestFailure: request.StopOnPretestFailure,
 	})
 
 	if err != nil {
@@ -194,6 +243,7 @@ func (p *AdminProblemService) CreateProblemWithFile(request request.AdminCreateP
 		zap.L().Error("services-CreateProblemWithFile-CreateProblemWithFile ", zap.Error(err))
 		return
 	}
+	p.saveProblemWithFileVersion(request.ProblemID)
 	response.Code = resp_code.Success
 	return
 }
@@ -278,7 +328,7 @@ func (p *AdminProblemService) DeleteProblemTestCaseWithFile(request request.Admi
 // UpdateProblemWithFile 更新题目
 func (p *AdminProblemService) UpdateProblemWithFile(request request.AdminUpdateProblemWithFileReq) (response response.Response) {
 
-	err := mysql.UpdateProblemWithFile(&mysql.ProblemWithFile{
+	problem := &mysql.ProblemWithFile{
 		ProblemID:    request.ProblemID,
 		Title:        request.Title,
 		Content:      request.Content,
@@ -287,12 +337,31 @@ func (p *AdminProblemService) UpdateProblemWithFile(request request.AdminUpdateP
 		MaxMemory:    request.MaxMemory,
 		InputPath:    request.InputDst,
 		ExpectedPath: request.ExpectedDst,
-	})
+		Interactor:   p.convertInteractor(request.ProblemInteractor),
+	}
+	// checker_mode 为空时沿用原先的检查方式
+	if request.CheckerMode != "" {
+		problem.Checker = p.convertChecker(request.ProblemChecker)
+	}
+	// stop_on_pretest_failure 为空时沿用原先的设置
+	if request.StopOnPretestFailure != nil {
+		problem.StopOnPretestFailure = *request.StopOnPretestFailure
+	} else {
+		oldProblem, err := mysql.GetEntireProblemWithFile(request.ProblemID)
+		if err != nil {
+			zap.L().Error("services-UpdateProblemWithFile-GetEntireProblemWithFile ", zap.Error(err))
+			response.Code = resp_code.InternalServerError
+			return
+		}
+		problem.StopOnPretestFailure = oldProblem.StopOnPretestFailure
+	}
+	err := mysql.UpdateProblemWithFile(problem)
 	if err != nil {
 		zap.L().Error("services-UpdateProblemWithFile-UpdateProblemWithFile ", zap.Error(err))
 		response.Code = resp_code.InternalServerError
 		return
 	}
+	p.saveProblemWithFileVersion(request.ProblemID)
 	response.Code = resp_code.Success
 	return
 }
@@ -303,15 +372,38 @@ func (p *AdminProblemService) convertTestCases(testCases []*request.TestCase) []
 	for _, tc := range testCases {
 		// 进行类型转换
 		convertedTestCases = append(convertedTestCases, &mysql.TestCase{
-			TID:      tc.TID,
-			PID:      tc.PID,
-			Input:    tc.Input,
-			Expected: tc.Expected,
+			TID:       tc.TID,
+			PID:       tc.PID,
+			Input:     tc.Input,
+			Expected:  tc.Expected,
+			IsSample:  tc.IsSample,
+			IsPretest: tc.IsPretest,
 		})
 	}
 	return convertedTestCases
 }
 
+// convertChecker 转换检查方式，未指定时使用逐字节比较
+func (p *AdminProblemService) convertChecker(checker request.ProblemChecker) mysql.Checker {
+	c := mysql.Checker{
+		CheckerMode:    p.defaultResolve(checker.CheckerMode, consts.CheckerExact).(string),
+		CheckerEpsilon: checker.CheckerEpsilon,
+	}
+	if c.CheckerMode == consts.CheckerCustom {
+		c.CheckerCode = checker.CheckerCode
+		c.CheckerLanguage = checker.CheckerLanguage
+	}
+	return c
+}
+
+// convertInteractor 转换交互器，更新题目时源代码为空会沿用原先的交互器
+func (p *AdminProblemService) convertInteractor(interactor request.ProblemInteractor) mysql.Interactor {
+	return mysql.Interactor{
+		InteractorCode:     interactor.InteractorCode,
+		InteractorLanguage: interactor.InteractorLanguage,
+	}
+}
+
 func (p *AdminProblemService) deleteCacheByPrefix(redisClient *redis.Client, prefix string) error {
 	ctx := context.Background()
 	iter := redisClient.Scan(ctx, 0, prefix+"*", 0).Iterator()
@@ -325,3 +417,38 @@ func (p *AdminProblemService) deleteCacheByPrefix(redisClient *redis.Client, pre
 	}
 	return nil
 }
+
+// GetProblemTestCases 获取题目的全部测试数据，包括隐藏的测试数据
+func (p *AdminProblemService) GetProblemTestCases(pid string) (response response.ResponseWithData) {
+	exist, err := mysql.CheckProblemIDExists(pid)
+	if err != nil {
+		response.Code = resp_code.SearchDBError
+		zap.L().Error("services-GetProblemTestCases-CheckProblemIDExists ", zap.Error(err))
+		return
+	}
+	if !exist {
+		response.Code = resp_code.ProblemNotExist
+		return
+	}
+
+	testCases, err := mysql.GetProblemTestCases(pid)
+	if err != nil {
+		response.Code = resp_code.SearchDBError
+		zap.L().Error("services-GetProblemTestCases-GetProblemTestCases ", zap.Error(err))
+		return
+	}
+	data := make([]problemResponse.TestCaseResponse, len(testCases))
+	for i, tc := range testCases {
+		data[i] = problemResponse.TestCaseResponse{
+			TID:       tc.TID,
+			PID:       tc.PID,
+			Input:     tc.Input,
+			Expected:  tc.Expected,
+			IsSample:  tc.IsSample,
+			IsPretest: tc.IsPretest,
+		}
+	}
+	response.Code = resp_code.Success
+	response.Data = data
+	return
+}
@@ -0,0 +1,136 @@
+package admin
+
+import (
+	"errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"online_judge/consts/resp_code"
+	"online_judge/dao/mysql"
+	"online_judge/models/admin/request"
+	adminResponse "online_judge/models/admin/response"
+	"online_judge/models/common/response"
+	"online_judge/pkg/language"
+	"online_judge/pkg/similarity"
+	"sort"
+	"strings"
+)
+
+const (
+	defaultSimilarityThreshold = 0.6
+	maxSimilarityCodes         = 500
+	// 出现在超过这个比例的代码中的片段视为模板代码
+	similarityMaxShare = 0.5
+)
+
+// GetSimilarityReport 比较题目中每个用户最近一次通过的代码，response.Data 为 *adminResponse.SimilarityReport
+// 语法相近的语言之间也会互相比较，例如 C 和 C++
+func (p *AdminProblemService) GetSimilarityReport(req request.AdminSimilarityReq) (response response.ResponseWithData) {
+	exist, err := mysql.CheckProblemIDExists(req.ProblemID)
+	if err != nil {
+		response.Code = resp_code.SearchDBError
+		zap.L().Error("services-GetSimilarityReport-CheckProblemIDExists ", zap.Error(err))
+		return
+	}
+	if !exist {
+		if _, err = mysql.GetEntireProblemWithFile(req.ProblemID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				response.Code = resp_code.ProblemNotExist
+				return
+			}
+			response.Code = resp_code.SearchDBError
+			zap.L().Error("services-GetSimilarityReport-GetEntireProblemWithFile ", zap.Error(err))
+			return
+		}
+	}
+
+	if req.Threshold <= 0 {
+		req.Threshold = defaultSimilarityThreshold
+	}
+	if req.Limit <= 0 || req.Limit > maxSimilarityCodes {
+		req.Limit = maxSimilarityCodes
+	}
+	codes, err := mysql.GetLatestAcceptedCodes(req.ProblemID, req.Limit)
+	if err != nil {
+		response.Code = resp_code.SearchDBError
+		zap.L().Error("services-GetSimilarityReport-GetLatestAcceptedCodes ", zap.Error(err))
+		return
+	}
+
+	docs := make([]*similarity.Document, len(codes))
+	for i, c := range codes {
+		syntax := similarity.SyntaxC
+		if lang, ok := language.Get(c.Language); ok {
+			syntax = similarity.SyntaxFor(lang.SourceFile)
+		}
+		docs[i] = similarity.NewDocument(c.SubmissionID, syntax, c.Code)
+	}
+	pairs := similarity.FindPairs(docs, similarity.Options{Threshold: req.Threshold, MaxShare: similarityMaxShare})
+
+	report := &adminResponse.SimilarityReport{
+		ProblemID: req.ProblemID,
+		Threshold: req.Threshold,
+		Compared:  len(codes),
+		Pairs:     make([]adminResponse.SimilarityPair, len(pairs)),
+	}
+	for i, pair := range pairs {
+		a, b := codes[pair.A], codes[pair.B]
+		// 先提交的代码放在前面
+		if b.SubmissionTime.Before(a.SubmissionTime) {
+			pair.A, pair.B = pair.B, pair.A
+			pair.ScoreA, pair.ScoreB = pair.ScoreB, pair.ScoreA
+			for j, f := range pair.Fragments {
+				pair.Fragments[j] = similarity.Fragment{
+					AStartLine: f.BStartLine,
+					AEndLine:   f.BEndLine,
+					BStartLine: f.AStartLine,
+					BEndLine:   f.AEndLine,
+					Tokens:     f.Tokens,
+				}
+			}
+			sort.Slice(pair.Fragments, func(x, y int) bool {
+				return pair.Fragments[x].AStartLine < pair.Fragments[y].AStartLine
+			})
+			a, b = b, a
+		}
+		report.Pairs[i] = adminResponse.SimilarityPair{
+			Score:     pair.Score,
+			ScoreA:    pair.ScoreA,
+			ScoreB:    pair.ScoreB,
+			A:         similaritySubmission(a),
+			B:         similaritySubmission(b),
+			Fragments: similarityFragments(a.Code, b.Code, pair.Fragments),
+		}
+	}
+	response.Code = resp_code.Success
+	response.Data = report
+	return
+}
+
+func similaritySubmission(c mysql.AcceptedCode) adminResponse.SimilaritySubmission {
+	return adminResponse.SimilaritySubmission{
+		SubmissionID:   c.SubmissionID,
+		UserID:         c.UserID,
+		Username:       c.Username,
+		Language:       c.Language,
+		SubmissionTime: c.SubmissionTime,
+	}
+}
+
+// similarityFragments 附上相同片段所在行的代码
+func similarityFragments(codeA, codeB string, fragments []similarity.Fragment) []adminResponse.SimilarityFragment {
+	linesA := strings.Split(codeA, "\n")
+	linesB := strings.Split(codeB, "\n")
+	result := make([]adminResponse.SimilarityFragment, len(fragments))
+	for i, f := range fragments {
+		result[i] = adminResponse.SimilarityFragment{
+			AStartLine: f.AStartLine,
+			AEndLine:   f.AEndLine,
+			BStartLine: f.BStartLine,
+			BEndLine:   f.BEndLine,
+			ACode:      strings.Join(linesA[f.AStartLine-1:f.AEndLine], "\n"),
+			BCode:      strings.Join(linesB[f.BStartLine-1:f.BEndLine], "\n"),
+			Tokens:     f.Tokens,
+		}
+	}
+	return result
+}
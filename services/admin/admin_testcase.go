@@ -0,0 +1,122 @@
+package admin
+
+import (
+	"errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"online_judge/consts/resp_code"
+	"online_judge/dao/mysql"
+	"online_judge/models/admin/request"
+	"online_judge/models/common/response"
+	"online_judge/pkg/testcase"
+	"online_judge/pkg/utils"
+)
+
+// ImportTestCases 从压缩包导入测试数据，替换题目原有的测试数据
+// 普通题目写入 test_case 表，输入输出为文件的题目写入题目的测试数据目录，样例和预测试标记保存在清单文件中
+// 压缩包格式错误时 response.Data 为配对结果
+func (p *AdminProblemService) ImportTestCases(req request.AdminImportTestCasesReq) (response response.ResponseWithData) {
+	cases, report, err := testcase.ReadZip(req.Archive, req.Size, testcase.Limits{})
+	if err != nil {
+		response.Code = resp_code.InvalidTestCaseArchive
+		response.Data = report
+		zap.L().Error("services-ImportTestCases-ReadZip ", zap.Error(err))
+		return
+	}
+
+	exist, err := mysql.CheckProblemIDExists(req.ProblemID)
+	if err != nil {
+		response.Code = resp_code.SearchDBError
+		zap.L().Error("services-ImportTestCases-CheckProblemIDExists ", zap.Error(err))
+		return
+	}
+	if exist {
+		testCases := make([]*mysql.TestCase, len(cases))
+		for i, c := range cases {
+			testCases[i] = &mysql.TestCase{
+				TID:       utils.GetUUID(),
+				PID:       req.ProblemID,
+				Input:     c.Input,
+				Expected:  c.Expected,
+				IsSample:  c.IsSample,
+				IsPretest: c.IsPretest,
+			}
+		}
+		if err = mysql.ReplaceTestCases(req.ProblemID, testCases); err != nil {
+			response.Code = resp_code.InternalServerError
+			zap.L().Error("services-ImportTestCases-ReplaceTestCases ", zap.Error(err))
+			return
+		}
+		p.saveProblemVersion(req.ProblemID)
+		response.Code = resp_code.Success
+		response.Data = report
+		return
+	}
+
+	problem, err := mysql.GetEntireProblemWithFile(req.ProblemID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.Code = resp_code.ProblemNotExist
+			return
+		}
+		response.Code = resp_code.SearchDBError
+		zap.L().Error("services-ImportTestCases-GetEntireProblemWithFile ", zap.Error(err))
+		return
+	}
+	if err = testcase.WriteFiles(problem.InputPath, problem.ExpectedPath, cases); err != nil {
+		response.Code = resp_code.InternalServerError
+		zap.L().Error("services-ImportTestCases-WriteFiles ", zap.Error(err))
+		return
+	}
+	if _, err = mysql.SaveProblemWithFileVersion(problem, cases); err != nil {
+		zap.L().Error("services-ImportTestCases-SaveProblemWithFileVersion ", zap.Error(err))
+	}
+	response.Code = resp_code.Success
+	response.Data = report
+	return
+}
+
+// ExportTestCases 导出题目的全部测试数据，response.Data 为 []testcase.Case
+func (p *AdminProblemService) ExportTestCases(pid string) (response response.ResponseWithData) {
+	exist, err := mysql.CheckProblemIDExists(pid)
+	if err != nil {
+		response.Code = resp_code.SearchDBError
+		zap.L().Error("services-ExportTestCases-CheckProblemIDExists ", zap.Error(err))
+		return
+	}
+	if exist {
+		testCases, err := mysql.GetProblemTestCases(pid)
+		if err != nil {
+			response.Code = resp_code.SearchDBError
+			zap.L().Error("services-ExportTestCases-GetProblemTestCases ", zap.Error(err))
+			return
+		}
+		cases := make([]testcase.Case, len(testCases))
+		for i, tc := range testCases {
+			cases[i] = testcase.Case{Input: tc.Input, Expected: tc.Expected, IsSample: tc.IsSample, IsPretest: tc.IsPretest}
+		}
+		response.Code = resp_code.Success
+		response.Data = cases
+		return
+	}
+
+	problem, err := mysql.GetEntireProblemWithFile(pid)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.Code = resp_code.ProblemNotExist
+			return
+		}
+		response.Code = resp_code.SearchDBError
+		zap.L().Error("services-ExportTestCases-GetEntireProblemWithFile ", zap.Error(err))
+		return
+	}
+	cases, err := testcase.ReadFiles(problem.InputPath, problem.ExpectedPath)
+	if err != nil {
+		response.Code = resp_code.ReadTestFileError
+		zap.L().Error("services-ExportTestCases-ReadFiles ", zap.Error(err))
+		return
+	}
+	response.Code = resp_code.Success
+	response.Data = cases
+	return
+}
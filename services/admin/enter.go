@@ -4,4 +4,5 @@ type ServiceGroup struct {
 	AdminUserService
 	AdminProblemService
 	AdminCategoryService
+	AdminContestService
 }
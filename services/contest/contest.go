@@ -0,0 +1,207 @@
+package contest
+
+import (
+	"go.uber.org/zap"
+	"online_judge/consts"
+	"online_judge/consts/resp_code"
+	"online_judge/dao/mysql"
+	"online_judge/dao/redis"
+	"online_judge/models/common/response"
+	"online_judge/models/contest/request"
+	contest "online_judge/models/contest/response"
+	"time"
+)
+
+type ContestService struct{}
+
+// GetContestList 分页获取比赛列表
+func (c *ContestService) GetContestList(req request.ContestListReq) (resp response.ResponseWithData) {
+	var total int64
+	contests, err := mysql.GetContestList(req.Page, req.Size, &total)
+	if err != nil {
+		resp.Code = resp_code.SearchDBError
+		zap.L().Error("services-GetContestList-GetContestList ", zap.Error(err))
+		return
+	}
+	list := contest.ContestList{Total: total, List: make([]contest.ContestListItem, len(contests))}
+	for i, ct := range contests {
+		list.List[i] = listItem(&ct)
+	}
+	resp.Code = resp_code.Success
+	resp.Data = list
+	return
+}
+
+// GetContestDetail 获取比赛详细，比赛开始前只有管理员能看到题目
+func (c *ContestService) GetContestDetail(req request.ContestReq) (resp response.ResponseWithData) {
+	ct, err := mysql.GetContest(req.ContestID)
+	if err != nil {
+		resp.Code = contestErrorCode(err)
+		zap.L().Error("services-GetContestDetail-GetContest ", zap.Error(err))
+		return
+	}
+	detail := contest.ContestDetail{
+		ContestListItem: listItem(ct),
+		Description:     ct.Description,
+		Penalty:         ct.Penalty,
+		Problems:        []contest.ContestProblem{},
+	}
+	if req.UserID != 0 {
+		detail.Registered, err = mysql.IsContestParticipant(ct.ContestID, req.UserID)
+		if err != nil {
+			resp.Code = resp_code.SearchDBError
+			zap.L().Error("services-GetContestDetail-IsContestParticipant ", zap.Error(err))
+			return
+		}
+	}
+	if req.IsAdmin || !time.Now().Before(ct.StartTime) {
+		for _, p := range ct.Problems {
+			detail.Problems = append(detail.Problems, contestProblem(ct.Rule, p))
+		}
+	}
+	resp.Code = resp_code.Success
+	resp.Data = detail
+	return
+}
+
+// RegisterContest 报名比赛，比赛结束前都可以报名
+func (c *ContestService) RegisterContest(req request.ContestReq) (resp response.Response) {
+	ct, err := mysql.GetContest(req.ContestID)
+	if err != nil {
+		resp.Code = contestErrorCode(err)
+		zap.L().Error("services-RegisterContest-GetContest ", zap.Error(err))
+		return
+	}
+	if !time.Now().Before(ct.EndTime) {
+		resp.Code = resp_code.ContestAlreadyEnded
+		return
+	}
+	if err = mysql.RegisterContest(ct.ContestID, req.UserID); err != nil {
+		resp.Code = resp_code.SearchDBError
+		zap.L().Error("services-RegisterContest-RegisterContest ", zap.Error(err))
+		return
+	}
+	resp.Code = resp_code.Success
+	return
+}
+
+// GetScoreboard 计算比赛排行榜，封榜期间只有管理员能看到封榜后的结果
+func (c *ContestService) GetScoreboard(req request.ContestReq) (resp response.ResponseWithData) {
+	ct, users, judgements, code := loadContestResults(req.ContestID)
+	if code != resp_code.Success {
+		resp.Code = code
+		return
+	}
+	frozen := !req.IsAdmin && IsFrozen(ct, time.Now())
+	resp.Code = resp_code.Success
+	resp.Data = BuildScoreboard(ct, users, judgements, frozen)
+	return
+}
+
+// GetResolver 导出封榜结果的揭晓顺序，比赛结束后才能导出
+func (c *ContestService) GetResolver(req request.ContestReq) (resp response.ResponseWithData) {
+	ct, users, judgements, code := loadContestResults(req.ContestID)
+	if code != resp_code.Success {
+		resp.Code = code
+		return
+	}
+	if time.Now().Before(ct.EndTime) {
+		resp.Code = resp_code.ContestNotEnded
+		return
+	}
+	resp.Code = resp_code.Success
+	resp.Data = BuildResolver(ct, users, judgements)
+	return
+}
+
+// Unfreeze 揭晓封榜结果，之后所有用户都能看到最终排行榜，返回揭晓顺序
+func (c *ContestService) Unfreeze(req request.ContestReq) (resp response.ResponseWithData) {
+	resp = c.GetResolver(req)
+	if resp.Code != resp_code.Success {
+		return
+	}
+	uids, pids, err := mysql.UnfreezeContest(req.ContestID)
+	if err != nil {
+		resp.Code = contestErrorCode(err)
+		resp.Data = nil
+		zap.L().Error("services-Unfreeze-UnfreezeContest ", zap.Error(err))
+		return
+	}
+	if err = redis.DeleteScoreboardSnapshot(req.ContestID); err != nil {
+		zap.L().Error("services-Unfreeze-DeleteScoreboardSnapshot ", zap.Error(err))
+	}
+	// 封榜期间的评测记录已经计入题目统计和用户的通过数量
+	for _, pid := range pids {
+		if err = redis.MarkProblemStatsDirty(pid); err != nil {
+			zap.L().Error("services-Unfreeze-MarkProblemStatsDirty ", zap.Error(err))
+		}
+	}
+	for _, uid := range uids {
+		if err = redis.DeleteUserProfile(uid); err != nil {
+			zap.L().Error("services-Unfreeze-DeleteUserProfile ", zap.Error(err))
+		}
+		updateLeaderboardScore(uid)
+	}
+	return
+}
+
+// updateLeaderboardScore 揭晓封榜结果后更新用户在全站排行榜中的分数，失败时等待定期校正
+func updateLeaderboardScore(uid int64) {
+	entry, err := mysql.GetLeaderboardEntry(uid)
+	if err != nil {
+		zap.L().Error("services-updateLeaderboardScore-GetLeaderboardEntry ", zap.Error(err))
+		return
+	}
+	if entry.FinishNum == 0 {
+		return
+	}
+	err = redis.SetLeaderboardScore(uid, redis.LeaderboardScore(entry.FinishNum, entry.LastAcceptedAt))
+	if err != nil {
+		zap.L().Error("services-updateLeaderboardScore-SetLeaderboardScore ", zap.Error(err))
+	}
+}
+
+// loadContestResults 获取计算排行榜需要的比赛、报名用户和评测记录
+func loadContestResults(contestID string) (*mysql.Contest, []mysql.ContestUser, []mysql.Judgement, int) {
+	ct, err := mysql.GetContest(contestID)
+	if err != nil {
+		zap.L().Error("services-loadContestResults-GetContest ", zap.Error(err))
+		return nil, nil, nil, contestErrorCode(err)
+	}
+	users, err := mysql.GetContestUsers(ct.ContestID)
+	if err != nil {
+		zap.L().Error("services-loadContestResults-GetContestUsers ", zap.Error(err))
+		return nil, nil, nil, resp_code.SearchDBError
+	}
+	judgements, err := mysql.GetContestJudgements(ct.ContestID, ct.StartTime, ct.EndTime)
+	if err != nil {
+		zap.L().Error("services-loadContestResults-GetContestJudgements ", zap.Error(err))
+		return nil, nil, nil, resp_code.SearchDBError
+	}
+	return ct, users, judgements, resp_code.Success
+}
+
+func contestErrorCode(err error) int {
+	if err == mysql.ErrContestNotFound {
+		return resp_code.ContestNotExist
+	}
+	return resp_code.SearchDBError
+}
+
+func listItem(ct *mysql.Contest) contest.ContestListItem {
+	return contest.ContestListItem{
+		ContestID: ct.ContestID,
+		Title:     ct.Title,
+		Rule:      ct.Rule,
+		StartTime: ct.StartTime,
+		EndTime:   ct.EndTime,
+	}
+}
+
+func contestProblem(rule string, p mysql.ContestProblem) contest.ContestProblem {
+	cp := contest.ContestProblem{ProblemID: p.ProblemID, Label: p.Label}
+	if rule == consts.ContestRuleOI {
+		cp.Score = p.Score
+	}
+	return cp
+}
@@ -0,0 +1,62 @@
+package contest
+
+import (
+	"online_judge/dao/mysql"
+	"online_judge/models/contest/response"
+)
+
+// BuildResolver 从封榜时的排行榜开始逐步揭晓封榜后的提交
+// 每一步揭晓当前排名最后、仍有待揭晓题目的用户的第一道待揭晓题目，然后重新排名
+// 最后一步之后的排行榜与不封榜时的排行榜一致
+func BuildResolver(contest *mysql.Contest, users []mysql.ContestUser, judgements []mysql.Judgement) *response.Resolver {
+	final := BuildScoreboard(contest, users, judgements, false)
+	finalResults := make(map[int64][]response.ProblemResult, len(final.Rows))
+	for _, row := range final.Rows {
+		finalResults[row.UserID] = row.Results
+	}
+
+	resolver := &response.Resolver{
+		ContestID: contest.ContestID,
+		Rule:      contest.Rule,
+		Initial:   BuildScoreboard(contest, users, judgements, true),
+		Steps:     []response.ResolverStep{},
+	}
+	rows := BuildScoreboard(contest, users, judgements, true).Rows
+	for {
+		pos, problem := nextPending(rows)
+		if pos < 0 {
+			break
+		}
+		row := &rows[pos]
+		step := response.ResolverStep{
+			UserID:     row.UserID,
+			Username:   row.Username,
+			Label:      row.Results[problem].Label,
+			RankBefore: row.Rank,
+			Result:     finalResults[row.UserID][problem],
+		}
+		row.Results[problem] = step.Result
+		RankRows(contest, rows)
+		for _, r := range rows {
+			if r.UserID == step.UserID {
+				step.RankAfter = r.Rank
+				step.Solved, step.Penalty, step.Score = r.Solved, r.Penalty, r.Score
+				break
+			}
+		}
+		resolver.Steps = append(resolver.Steps, step)
+	}
+	return resolver
+}
+
+// nextPending 找到排名最后、仍有待揭晓题目的用户及其第一道待揭晓的题目
+func nextPending(rows []response.ScoreboardRow) (pos, problem int) {
+	for i := len(rows) - 1; i >= 0; i-- {
+		for k, r := range rows[i].Results {
+			if r.Pending > 0 {
+				return i, k
+			}
+		}
+	}
+	return -1, -1
+}
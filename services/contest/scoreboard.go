@@ -0,0 +1,165 @@
+package contest
+
+import (
+	"online_judge/consts"
+	"online_judge/consts/resp_code"
+	"online_judge/dao/mysql"
+	"online_judge/models/contest/response"
+	"sort"
+	"time"
+)
+
+// FreezeStart 封榜开始时间，不封榜时返回零值
+func FreezeStart(contest *mysql.Contest) time.Time {
+	if contest.FreezeMinutes <= 0 {
+		return time.Time{}
+	}
+	return contest.EndTime.Add(-time.Duration(contest.FreezeMinutes) * time.Minute)
+}
+
+// IsFrozen 当前是否处于封榜状态，比赛结束后直到管理员揭晓结果前都保持封榜
+func IsFrozen(contest *mysql.Contest, now time.Time) bool {
+	start := FreezeStart(contest)
+	return !start.IsZero() && !contest.Unfrozen && !now.Before(start)
+}
+
+// BuildScoreboard 根据评测记录计算排行榜，只统计报名用户在比赛时间内的提交
+// ICPC：通过题数多者在前，题数相同时罚时少者在前，罚时为通过时间加上通过前的错误次数乘以每次罚时
+// OI：每道题取最高得分，得分为满分乘以通过的测试样例比例
+// 编译错误、系统错误和等待评测的提交不计入错误次数
+// frozen 为 true 时封榜后的提交只记为待揭晓，不影响成绩
+func BuildScoreboard(contest *mysql.Contest, users []mysql.ContestUser, judgements []mysql.Judgement, frozen bool) *response.Scoreboard {
+	board := &response.Scoreboard{
+		ContestID: contest.ContestID,
+		Rule:      contest.Rule,
+		Frozen:    frozen,
+		Problems:  make([]response.ContestProblem, len(contest.Problems)),
+		Rows:      make([]response.ScoreboardRow, len(users)),
+	}
+	if start := FreezeStart(contest); !start.IsZero() {
+		board.FreezeTime = &start
+	}
+	index := make(map[string]int, len(contest.Problems))
+	for i, p := range contest.Problems {
+		index[p.ProblemID] = i
+		board.Problems[i] = contestProblem(contest.Rule, p)
+	}
+
+	rows := make(map[int64]*response.ScoreboardRow, len(users))
+	for i, u := range users {
+		board.Rows[i] = response.ScoreboardRow{
+			UserID:   u.UserID,
+			Username: u.Username,
+			Results:  make([]response.ProblemResult, len(contest.Problems)),
+		}
+		for k, p := range contest.Problems {
+			board.Rows[i].Results[k].Label = p.Label
+		}
+		rows[u.UserID] = &board.Rows[i]
+	}
+
+	freezeStart := FreezeStart(contest)
+	for _, j := range judgements {
+		row, ok := rows[j.UID]
+		if !ok || !counted(j.Verdict) {
+			continue
+		}
+		i, ok := index[j.ProblemID]
+		if !ok || j.CreatedAt.Before(contest.StartTime) || !j.CreatedAt.Before(contest.EndTime) {
+			continue
+		}
+		result := &row.Results[i]
+		// ICPC 通过之后的提交不再统计
+		if contest.Rule != consts.ContestRuleOI && result.Solved {
+			continue
+		}
+		if frozen && !freezeStart.IsZero() && !j.CreatedAt.Before(freezeStart) {
+			result.Pending++
+			continue
+		}
+		applyJudgement(contest, i, result, j)
+	}
+
+	RankRows(contest, board.Rows)
+	return board
+}
+
+// applyJudgement 把一次提交计入用户在一道题上的结果
+func applyJudgement(contest *mysql.Contest, i int, result *response.ProblemResult, j mysql.Judgement) {
+	result.Attempts++
+	if contest.Rule == consts.ContestRuleOI {
+		score := oiScore(contest.Problems[i].Score, j)
+		if score > result.Score {
+			result.Score = score
+		}
+		result.Solved = result.Score == contest.Problems[i].Score
+		return
+	}
+	if j.Verdict == resp_code.VerdictAccepted {
+		result.Solved = true
+		result.SolvedAt = int64(j.CreatedAt.Sub(contest.StartTime) / time.Minute)
+	}
+}
+
+// RankRows 汇总每个用户的成绩并排序，成绩完全相同的用户排名相同
+func RankRows(contest *mysql.Contest, rows []response.ScoreboardRow) {
+	lastAC := make(map[int64]int64, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		row.Solved, row.Penalty, row.Score = 0, 0, 0
+		for _, r := range row.Results {
+			if r.Solved {
+				row.Solved++
+				if contest.Rule != consts.ContestRuleOI {
+					row.Penalty += r.SolvedAt + int64(r.Attempts-1)*int64(contest.Penalty)
+				}
+				if r.SolvedAt > lastAC[row.UserID] {
+					lastAC[row.UserID] = r.SolvedAt
+				}
+			}
+			row.Score += r.Score
+		}
+	}
+
+	less := func(a, b *response.ScoreboardRow) bool {
+		if contest.Rule == consts.ContestRuleOI {
+			return a.Score > b.Score
+		}
+		if a.Solved != b.Solved {
+			return a.Solved > b.Solved
+		}
+		if a.Penalty != b.Penalty {
+			return a.Penalty < b.Penalty
+		}
+		// 罚时相同时最后一次通过较早者在前
+		return lastAC[a.UserID] < lastAC[b.UserID]
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return less(&rows[i], &rows[j]) })
+	for i := range rows {
+		rows[i].Rank = i + 1
+		if i > 0 && !less(&rows[i-1], &rows[i]) {
+			rows[i].Rank = rows[i-1].Rank
+		}
+	}
+}
+
+// counted 判断一次提交是否计入成绩
+func counted(verdict string) bool {
+	switch verdict {
+	case resp_code.VerdictPending, resp_code.VerdictCompilerError,
+		resp_code.VerdictSystemError, resp_code.VerdictUnknown:
+		return false
+	}
+	return true
+}
+
+// oiScore 按通过的测试样例比例计算得分
+func oiScore(full int, j mysql.Judgement) int {
+	if j.Verdict == resp_code.VerdictAccepted {
+		return full
+	}
+	if j.TotalNum <= 0 {
+		return 0
+	}
+	return full * j.PassNum / j.TotalNum
+}
@@ -4,7 +4,9 @@ import (
 	"online_judge/services/admin"
 	"online_judge/services/auth"
 	"online_judge/services/category"
+	"online_judge/services/contest"
 	"online_judge/services/evaluation"
+	"online_judge/services/language"
 	"online_judge/services/leaderboard"
 	"online_judge/services/problem"
 	"online_judge/services/submission"
@@ -22,6 +24,8 @@ type ServiceGroup struct {
 	LeaderboardService leaderboard.ServiceGroup
 	EvaluationService  evaluation.ServiceGroup
 	CategoryService    category.ServiceGroup
+	LanguageService    language.ServiceGroup
+	ContestService     contest.ServiceGroup
 }
 
 var ServiceGroupApp = new(ServiceGroup)
@@ -1,15 +1,175 @@
 package evaluation
 
+import (
+	"errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"online_judge/consts/resp_code"
+	"online_judge/dao/mysql"
+	"online_judge/models/evaluation/request"
+	"online_judge/models/evaluation/response"
+	"online_judge/pkg/define"
+	"online_judge/pkg/utils"
+	"time"
+)
+
+const (
+	// defaultListSize 默认每页数量
+	defaultListSize = 20
+	// maxListSize 每页最大数量
+	maxListSize = 100
+)
+
 type EvaluationService struct{}
 
-func (e *EvaluationService) GetEvaluationResult() {
+// GetEvaluationResult 根据评测ID获取评测记录，返回完整内容，由调用方按权限隐藏代码
+func (e *EvaluationService) GetEvaluationResult(req request.EvaluationDetailReq) (*response.EvaluationDetail, error) {
+	record, err := mysql.GetJudgementRecord(req.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, define.ErrJudgementNotFound
+		}
+		zap.L().Error("services-GetEvaluationResult-GetJudgementRecord ", zap.Error(err))
+		return nil, err
+	}
+	return detailWithGroups(record)
+}
+
+// GetSubmissionResult 根据提交ID获取评测记录
+func (e *EvaluationService) GetSubmissionResult(req request.EvaluationDetailReq) (*response.EvaluationDetail, error) {
+	record, err := mysql.GetJudgementRecordBySubmissionID(req.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, define.ErrSubmissionNotFound
+		}
+		zap.L().Error("services-GetSubmissionResult-GetJudgementRecordBySubmissionID ", zap.Error(err))
+		return nil, err
+	}
+	return detailWithGroups(record)
+}
+
+// GetUserEvaluations 获取用户的评测记录
+func (e *EvaluationService) GetUserEvaluations(req request.EvaluationListReq) (*response.EvaluationList, error) {
+	return e.listEvaluations(req)
+}
+
+// GetProblemEvaluations 获取题目的评测记录
+func (e *EvaluationService) GetProblemEvaluations(req request.EvaluationListReq) (*response.EvaluationList, error) {
+	return e.listEvaluations(req)
+}
 
+func (e *EvaluationService) listEvaluations(req request.EvaluationListReq) (*response.EvaluationList, error) {
+	filter, err := buildFilter(req)
+	if err != nil {
+		return nil, err
+	}
+	// 多查一条用来判断是否还有下一页
+	limit := filter.Limit
+	filter.Limit++
+	records, err := mysql.ListJudgements(filter)
+	if err != nil {
+		zap.L().Error("services-listEvaluations-ListJudgements ", zap.Error(err))
+		return nil, err
+	}
+
+	list := &response.EvaluationList{List: make([]response.EvaluationDetail, 0, limit)}
+	if len(records) > limit {
+		records = records[:limit]
+		last := records[limit-1]
+		list.NextCursor = utils.EncodeCursor(last.CreatedAt, last.JudgementID)
+	}
+	for i := range records {
+		detail := convertRecord(&records[i])
+		detail.HidePrivate(req.ViewerID, req.IsAdmin)
+		list.List = append(list.List, *detail)
+	}
+	return list, nil
 }
 
-func (e *EvaluationService) GetUserEvaluations() {
+// buildFilter 校验并转换查询参数
+func buildFilter(req request.EvaluationListReq) (filter mysql.JudgementFilter, err error) {
+	filter = mysql.JudgementFilter{
+		UserID:    req.UserID,
+		ProblemID: req.ProblemID,
+		Verdict:   req.Verdict,
+		Language:  req.Language,
+		Limit:     req.Size,
+		// 按评测结果过滤时排除其他用户封榜期间的记录，否则会暴露被隐藏的结果
+		HideFrozen: req.Verdict != "" && !req.IsAdmin,
+		ViewerID:   req.ViewerID,
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultListSize
+	}
+	if filter.Limit > maxListSize {
+		filter.Limit = maxListSize
+	}
+	if req.Verdict != "" && !resp_code.ValidVerdict(req.Verdict) {
+		return filter, define.ErrInvalidFilter
+	}
+	if filter.From, _, err = parseDate(req.From); err != nil {
+		return filter, define.ErrInvalidFilter
+	}
+	var dateOnly bool
+	if filter.To, dateOnly, err = parseDate(req.To); err != nil {
+		return filter, define.ErrInvalidFilter
+	}
+	// 只有日期时包含结束当天
+	if dateOnly {
+		filter.To = filter.To.AddDate(0, 0, 1)
+	}
+	filter.CursorTime, filter.CursorID, err = utils.DecodeCursor(req.Cursor)
+	return filter, err
+}
 
+// parseDate 解析日期，支持 2006-01-02 和 RFC3339 两种格式
+func parseDate(s string) (t time.Time, dateOnly bool, err error) {
+	if s == "" {
+		return
+	}
+	if t, err = time.ParseInLocation(time.DateOnly, s, time.Local); err == nil {
+		return t, true, nil
+	}
+	t, err = time.Parse(time.RFC3339, s)
+	return t, false, err
 }
 
-func (e *EvaluationService) GetProblemEvaluations() {
+// detailWithGroups 转换单条评测记录并附带测试组的结果
+func detailWithGroups(r *mysql.JudgementRecord) (*response.EvaluationDetail, error) {
+	groups, err := mysql.GetJudgementGroups(r.JudgementID)
+	if err != nil {
+		zap.L().Error("services-detailWithGroups-GetJudgementGroups ", zap.Error(err))
+		return nil, err
+	}
+	detail := convertRecord(r)
+	for _, g := range groups {
+		detail.Groups = append(detail.Groups, response.Group{
+			Name:        g.Name,
+			Verdict:     g.Verdict,
+			PassNum:     g.PassNum,
+			TotalNum:    g.TotalNum,
+			Runtime:     g.Runtime,
+			MemoryUsage: g.MemoryUsage,
+		})
+	}
+	return detail, nil
+}
 
+func convertRecord(r *mysql.JudgementRecord) *response.EvaluationDetail {
+	return &response.EvaluationDetail{
+		JudgementID:    r.JudgementID,
+		SubmissionID:   r.SubmissionID,
+		UserID:         r.UserID,
+		ProblemID:      r.ProblemID,
+		Language:       r.Language,
+		Verdict:        r.Verdict,
+		Runtime:        r.Runtime,
+		MemoryUsage:    r.MemoryUsage,
+		Output:         r.Output,
+		Code:           r.Code,
+		SubmissionTime: r.SubmissionTime,
+		CreatedAt:      r.CreatedAt,
+		ProblemVersion: r.ProblemVersion,
+		Frozen:         r.Frozen,
+	}
 }
@@ -0,0 +1,5 @@
+package language
+
+type ServiceGroup struct {
+	LanguageService
+}
@@ -0,0 +1,12 @@
+package language
+
+import (
+	"online_judge/pkg/language"
+)
+
+type LanguageService struct{}
+
+// GetLanguageList 获取支持的语言列表
+func (l *LanguageService) GetLanguageList() []*language.Language {
+	return language.List()
+}
@@ -1,23 +1,105 @@
 package leaderboard
 
 import (
+	"errors"
+	goredis "github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 	"online_judge/consts/resp_code"
 	"online_judge/dao/mysql"
+	"online_judge/dao/redis"
 	"online_judge/models/common/response"
+	"online_judge/models/leaderboard/request"
+	leaderboard "online_judge/models/leaderboard/response"
+	"strconv"
 )
 
 type LeaderboardService struct{}
 
-func (l *LeaderboardService) GetUserLeaderboard() (response response.ResponseWithData, err error) {
-	data, err := mysql.GetUserLeaderboard()
+// GetUserLeaderboard 分页获取用户排行榜，按通过数量排名，数量相同时最后通过时间较早者在前
+func (l *LeaderboardService) GetUserLeaderboard(req request.LeaderboardReq) (response response.ResponseWithData, err error) {
+	key, err := l.leaderboardKey(req.Window, req.CategoryID)
 	if err != nil {
 		response.Code = resp_code.GetUserRankError
-		zap.L().Error("services-GetUserLeaderboard-GetUserLeaderboard ", zap.Error(err))
+		zap.L().Error("services-GetUserLeaderboard-leaderboardKey ", zap.Error(err))
 		return response, err
 	}
+	start := int64(req.Page-1) * int64(req.Size)
+	members, err := redis.GetLeaderboardRange(key, start, start+int64(req.Size)-1)
+	if err != nil {
+		response.Code = resp_code.GetUserRankError
+		zap.L().Error("services-GetUserLeaderboard-GetLeaderboardRange ", zap.Error(err))
+		return response, err
+	}
+	total, err := redis.GetLeaderboardSize(key)
+	if err != nil {
+		response.Code = resp_code.GetUserRankError
+		zap.L().Error("services-GetUserLeaderboard-GetLeaderboardSize ", zap.Error(err))
+		return response, err
+	}
+
+	uids := make([]int64, 0, len(members))
+	for _, m := range members {
+		uid, _ := strconv.ParseInt(m.Member.(string), 10, 64)
+		uids = append(uids, uid)
+	}
+	names, err := mysql.GetUsernames(uids)
+	if err != nil {
+		response.Code = resp_code.GetUserRankError
+		zap.L().Error("services-GetUserLeaderboard-GetUsernames ", zap.Error(err))
+		return response, err
+	}
+
+	page := leaderboard.LeaderboardPage{Total: total, List: make([]leaderboard.LeaderboardItem, 0, len(members))}
+	for i, m := range members {
+		item := rankItem(start+int64(i), m.Score)
+		item.UserID = uids[i]
+		item.Username = names[uids[i]]
+		page.List = append(page.List, item)
+	}
+	response.Code = resp_code.Success
+	response.Data = page
+	return response, nil
+}
+
+// GetMyRank 获取当前用户的排名，没有通过任何题目时排名为 0
+func (l *LeaderboardService) GetMyRank(req request.LeaderboardReq) (response response.ResponseWithData, err error) {
+	key, err := l.leaderboardKey(req.Window, req.CategoryID)
+	if err != nil {
+		response.Code = resp_code.GetUserRankError
+		zap.L().Error("services-GetMyRank-leaderboardKey ", zap.Error(err))
+		return response, err
+	}
+	uid := req.UserID
+	rank, score, err := redis.GetLeaderboardRank(key, uid)
+	if err != nil && !errors.Is(err, goredis.Nil) {
+		response.Code = resp_code.GetUserRankError
+		zap.L().Error("services-GetMyRank-GetLeaderboardRank ", zap.Error(err))
+		return response, err
+	}
+
+	var item leaderboard.LeaderboardItem
+	if err == nil {
+		item = rankItem(rank, score)
+	}
+	names, err := mysql.GetUsernames([]int64{uid})
+	if err != nil {
+		response.Code = resp_code.GetUserRankError
+		zap.L().Error("services-GetMyRank-GetUsernames ", zap.Error(err))
+		return response, err
+	}
+	item.UserID = uid
+	item.Username = names[uid]
 	response.Code = resp_code.Success
-	response.Data = data
-	//fmt.Println(data)
+	response.Data = item
 	return response, nil
 }
+
+// rankItem 根据排名和分数生成排行榜中的一项，rank 从 0 开始
+func rankItem(rank int64, score float64) leaderboard.LeaderboardItem {
+	finishNum, lastAcceptedAt := redis.ParseLeaderboardScore(score)
+	item := leaderboard.LeaderboardItem{Rank: rank + 1, FinishNum: finishNum}
+	if lastAcceptedAt.Unix() > 0 {
+		item.LastAcceptedAt = &lastAcceptedAt
+	}
+	return item
+}
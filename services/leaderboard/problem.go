@@ -0,0 +1,50 @@
+package leaderboard
+
+import (
+	"go.uber.org/zap"
+	"online_judge/consts/resp_code"
+	"online_judge/dao/mysql"
+	"online_judge/models/common/response"
+	"online_judge/models/leaderboard/request"
+	leaderboard "online_judge/models/leaderboard/response"
+)
+
+// GetProblemLeaderboard 分页获取题目的最快通过排行，按运行时间、内存、提交时间排序，每个用户只保留最优的一次
+func (l *LeaderboardService) GetProblemLeaderboard(req request.ProblemLeaderboardReq) (response response.ResponseWithData, err error) {
+	exist, err := mysql.CheckProblemIDExists(req.ProblemID)
+	if err != nil {
+		response.Code = resp_code.SearchDBError
+		zap.L().Error("services-GetProblemLeaderboard-CheckProblemIDExists ", zap.Error(err))
+		return response, err
+	}
+	if !exist {
+		response.Code = resp_code.ProblemNotExist
+		return response, nil
+	}
+
+	var total int64
+	offset := (req.Page - 1) * req.Size
+	solutions, err := mysql.GetFastestSolutions(req.ProblemID, req.Language, offset, req.Size, &total)
+	if err != nil {
+		response.Code = resp_code.GetUserRankError
+		zap.L().Error("services-GetProblemLeaderboard-GetFastestSolutions ", zap.Error(err))
+		return response, err
+	}
+
+	page := leaderboard.ProblemLeaderboardPage{Total: total, List: make([]leaderboard.ProblemLeaderboardItem, 0, len(solutions))}
+	for i, s := range solutions {
+		page.List = append(page.List, leaderboard.ProblemLeaderboardItem{
+			Rank:           int64(offset + i + 1),
+			UserID:         s.UserID,
+			Username:       s.Username,
+			SubmissionID:   s.SubmissionID,
+			Language:       s.Language,
+			Runtime:        s.Runtime,
+			MemoryUsage:    s.MemoryUsage,
+			SubmissionTime: s.SubmissionTime,
+		})
+	}
+	response.Code = resp_code.Success
+	response.Data = page
+	return response, nil
+}
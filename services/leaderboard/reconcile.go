@@ -0,0 +1,65 @@
+package leaderboard
+
+import (
+	"context"
+	goredis "github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"online_judge/dao/mysql"
+	"online_judge/dao/redis"
+	"time"
+)
+
+// defaultReconcileInterval 默认的排行榜校正间隔
+const defaultReconcileInterval = 10 * time.Minute
+
+// ReconcileLeaderboard 以 MySQL 中的通过数量为准重建 redis 排行榜
+// 评测 worker 增量更新排行榜失败或者 redis 数据丢失时由这里修正
+func (l *LeaderboardService) ReconcileLeaderboard() error {
+	entries, err := mysql.GetLeaderboardEntries()
+	if err != nil {
+		return err
+	}
+	members := make([]goredis.Z, len(entries))
+	for i, e := range entries {
+		members[i] = goredis.Z{
+			Score:  redis.LeaderboardScore(e.FinishNum, e.LastAcceptedAt),
+			Member: e.UserID,
+		}
+	}
+	return redis.ReplaceLeaderboard(redis.GlobalLeaderboardKey(), members, 0)
+}
+
+// StartReconcile 启动时立即校正一次，之后定期校正，ctx 取消后停止
+// 多个实例同时运行时通过 redis 锁保证每个周期只有一个实例执行
+func (l *LeaderboardService) StartReconcile(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultReconcileInterval
+	}
+	reconcile := func() {
+		ok, err := redis.TryLockLeaderboardReconcile(interval / 2)
+		if err != nil {
+			zap.L().Error("services-StartReconcile-TryLockLeaderboardReconcile ", zap.Error(err))
+			return
+		}
+		if !ok {
+			return
+		}
+		if err = l.ReconcileLeaderboard(); err != nil {
+			zap.L().Error("services-StartReconcile-ReconcileLeaderboard ", zap.Error(err))
+		}
+	}
+
+	go func() {
+		reconcile()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				reconcile()
+			}
+		}
+	}()
+}
@@ -0,0 +1,56 @@
+package leaderboard
+
+import (
+	"fmt"
+	goredis "github.com/redis/go-redis/v9"
+	"online_judge/consts"
+	"online_judge/dao/mysql"
+	"online_judge/dao/redis"
+	"time"
+)
+
+// windowLeaderboardExpiration 按时间段和分类统计的排行榜缓存时间，过期后重新统计
+const windowLeaderboardExpiration = 5 * time.Minute
+
+// leaderboardKey 返回排行榜对应的 redis key，按时间段或分类统计的排行榜没有缓存时先从 MySQL 统计
+func (l *LeaderboardService) leaderboardKey(window, categoryID string) (string, error) {
+	if (window == "" || window == consts.LeaderboardWindowAll) && categoryID == "" {
+		return redis.GlobalLeaderboardKey(), nil
+	}
+
+	from, period := windowStart(window, time.Now())
+	key := redis.WindowLeaderboardKey(window, period, categoryID)
+	ready, err := redis.LeaderboardReady(key)
+	if err != nil || ready {
+		return key, err
+	}
+
+	entries, err := mysql.GetWindowLeaderboardEntries(from, categoryID)
+	if err != nil {
+		return "", err
+	}
+	members := make([]goredis.Z, len(entries))
+	for i, e := range entries {
+		members[i] = goredis.Z{
+			Score:  redis.LeaderboardScore(e.FinishNum, e.LastAcceptedAt),
+			Member: e.UserID,
+		}
+	}
+	return key, redis.ReplaceLeaderboard(key, members, windowLeaderboardExpiration)
+}
+
+// windowStart 返回统计时间段的开始时间和标识，全部时间的开始时间为零值
+func windowStart(window string, now time.Time) (time.Time, string) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch window {
+	case consts.LeaderboardWindowWeek:
+		// 一周从周一开始
+		start := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+		year, week := start.ISOWeek()
+		return start, fmt.Sprintf("%d-W%02d", year, week)
+	case consts.LeaderboardWindowMonth:
+		return today.AddDate(0, 0, 1-today.Day()), today.Format("2006-01")
+	default:
+		return time.Time{}, consts.LeaderboardWindowAll
+	}
+}
@@ -39,6 +39,10 @@ func (p *ProblemService) GetProblemList(req request.GetProblemListReq) (problems
 			}
 		}
 	}
+	if err = fillProblemStats(problems.Data); err != nil {
+		zap.L().Error("services-GetProblemList-fillProblemStats ", zap.Error(err))
+		return
+	}
 	problems.Count = count
 	problems.Size = req.Size
 	problems.Page = req.Page
@@ -86,9 +90,15 @@ func (p *ProblemService) GetProblemDetail(req request.GetProblemDetailReq) (*res
 			PID:      tc.PID,
 			Input:    tc.Input,
 			Expected: tc.Expected,
+			IsSample: tc.IsSample,
 		}
 	}
 
+	if problemResp.Stats, err = getProblemStats(problem.ProblemID); err != nil {
+		zap.L().Error("services-GetProblemDetail-getProblemStats ", zap.Error(err))
+		return nil, err
+	}
+
 	return problemResp, nil
 }
 
@@ -141,9 +151,15 @@ func (p *ProblemService) GetProblemRandom() (*response.ProblemDetailResponse, er
 			PID:      tc.PID,
 			Input:    tc.Input,
 			Expected: tc.Expected,
+			IsSample: tc.IsSample,
 		}
 	}
 
+	if problemResp.Stats, err = getProblemStats(problem.ProblemID); err != nil {
+		zap.L().Error("services-GetProblemRandom-getProblemStats ", zap.Error(err))
+		return nil, err
+	}
+
 	return problemResp, nil
 	//// 加入redis缓存
 	//cacheKey := fmt.Sprintf("%s:%s", cache.GlobalCacheKeyMap.ProblemDetailPrefix, problem.ProblemID)
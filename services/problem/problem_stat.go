@@ -0,0 +1,60 @@
+package problem
+
+import (
+	"online_judge/dao/mysql"
+	"online_judge/models/problem/response"
+)
+
+// newProblemStats 把统计记录转换为响应，没有提交记录时各项为 0
+func newProblemStats(s mysql.ProblemStat) response.ProblemStats {
+	stats := response.ProblemStats{
+		SubmitNum:   s.SubmitNum,
+		AcceptedNum: s.AcceptedNum,
+		SolverNum:   s.SolverNum,
+	}
+	if s.SubmitNum > 0 {
+		stats.AcceptanceRate = float64(s.AcceptedNum) / float64(s.SubmitNum)
+	}
+	return stats
+}
+
+// fillProblemStats 为题目列表填充提交统计
+func fillProblemStats(problems []*response.ProblemResponse) error {
+	pids := make([]string, len(problems))
+	for i, p := range problems {
+		pids[i] = p.ProblemID
+	}
+	stats, err := mysql.GetProblemStats(pids)
+	if err != nil {
+		return err
+	}
+	for _, p := range problems {
+		p.Stats = newProblemStats(stats[p.ProblemID])
+	}
+	return nil
+}
+
+// getProblemStats 获取单个题目的提交统计，包括按评测结果和编程语言分组的次数
+func getProblemStats(pid string) (response.ProblemStats, error) {
+	stats, err := mysql.GetProblemStats([]string{pid})
+	if err != nil {
+		return response.ProblemStats{}, err
+	}
+	counts, err := mysql.GetProblemStatCounts(pid)
+	if err != nil {
+		return response.ProblemStats{}, err
+	}
+
+	resp := newProblemStats(stats[pid])
+	resp.Verdicts = make(map[string]int64)
+	resp.Languages = make(map[string]int64)
+	for _, c := range counts {
+		switch c.Kind {
+		case mysql.ProblemStatVerdict:
+			resp.Verdicts[c.Name] = c.Count
+		case mysql.ProblemStatLanguage:
+			resp.Languages[c.Name] = c.Count
+		}
+	}
+	return resp, nil
+}
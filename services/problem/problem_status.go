@@ -0,0 +1,71 @@
+package problem
+
+import (
+	"go.uber.org/zap"
+	"online_judge/consts"
+	"online_judge/dao/mysql"
+	"online_judge/dao/redis"
+	"online_judge/models/problem/response"
+)
+
+// GetProblemStatus 获取用户在各题目上的状态，缓存不存在时从 MySQL 加载
+func (p *ProblemService) GetProblemStatus(uid int64, pids []string) (map[string]string, error) {
+	status, loaded, err := redis.GetProblemStatus(uid, pids)
+	if err != nil {
+		zap.L().Error("services-GetProblemStatus-GetProblemStatus ", zap.Error(err))
+		return nil, err
+	}
+	if !loaded {
+		finished, err := mysql.GetUserProblemStatus(uid)
+		if err != nil {
+			zap.L().Error("services-GetProblemStatus-GetUserProblemStatus ", zap.Error(err))
+			return nil, err
+		}
+		all := make(map[string]string, len(finished))
+		for pid, solved := range finished {
+			all[pid] = consts.ProblemStatusAttempted
+			if solved {
+				all[pid] = consts.ProblemStatusSolved
+			}
+		}
+		if err = redis.SetProblemStatus(uid, all); err != nil {
+			zap.L().Warn("services-GetProblemStatus-SetProblemStatus ", zap.Error(err))
+		}
+		status = all
+	}
+
+	result := make(map[string]string, len(pids))
+	for _, pid := range pids {
+		result[pid] = consts.ProblemStatusUntouched
+		if s, ok := status[pid]; ok {
+			result[pid] = s
+		}
+	}
+	return result, nil
+}
+
+// FillProblemListStatus 为题目列表标注当前用户的状态，不修改共享的列表缓存
+func (p *ProblemService) FillProblemListStatus(uid int64, problems []*response.ProblemResponse) error {
+	pids := make([]string, len(problems))
+	for i, problem := range problems {
+		pids[i] = problem.ProblemID
+	}
+	status, err := p.GetProblemStatus(uid, pids)
+	if err != nil {
+		return err
+	}
+	for _, problem := range problems {
+		problem.Status = status[problem.ProblemID]
+	}
+	return nil
+}
+
+// FillProblemDetailStatus 为题目详情标注当前用户的状态
+func (p *ProblemService) FillProblemDetailStatus(uid int64, problem *response.ProblemDetailResponse) error {
+	status, err := p.GetProblemStatus(uid, []string{problem.ProblemID})
+	if err != nil {
+		return err
+	}
+	problem.Status = status[problem.ProblemID]
+	return nil
+}
@@ -0,0 +1,47 @@
+package submission
+
+import (
+	"go.uber.org/zap"
+	"online_judge/consts/resp_code"
+	"online_judge/dao/mysql"
+	"online_judge/models/submission/request"
+)
+
+// checkContest 比赛中的提交需要已报名、题目属于比赛并且在比赛时间内
+func (s *SubmissionService) checkContest(request request.SubmissionReq) int {
+	if request.ContestID == "" {
+		return resp_code.Success
+	}
+	contest, err := mysql.GetContest(request.ContestID)
+	if err != nil {
+		if err == mysql.ErrContestNotFound {
+			return resp_code.ContestNotExist
+		}
+		zap.L().Error("services-checkContest-GetContest ", zap.Error(err))
+		return resp_code.SearchDBError
+	}
+	if request.SubmissionTime.Before(contest.StartTime) || !request.SubmissionTime.Before(contest.EndTime) {
+		return resp_code.ContestNotRunning
+	}
+
+	inContest := false
+	for _, p := range contest.Problems {
+		if p.ProblemID == request.ProblemID {
+			inContest = true
+			break
+		}
+	}
+	if !inContest {
+		return resp_code.ProblemNotInContest
+	}
+
+	registered, err := mysql.IsContestParticipant(request.ContestID, request.UserID)
+	if err != nil {
+		zap.L().Error("services-checkContest-IsContestParticipant ", zap.Error(err))
+		return resp_code.SearchDBError
+	}
+	if !registered {
+		return resp_code.ContestNotRegistered
+	}
+	return resp_code.Success
+}
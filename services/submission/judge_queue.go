@@ -0,0 +1,73 @@
+package submission
+
+import (
+	"encoding/json"
+	"go.uber.org/zap"
+	"online_judge/consts/resp_code"
+	"online_judge/dao/mq"
+	"online_judge/dao/mysql"
+	"online_judge/models/common/response"
+	"online_judge/models/submission/request"
+	"online_judge/pkg/utils"
+	pb "online_judge/proto"
+)
+
+// enqueueJudgement 保存等待评测的记录并把评测任务发送到消息队列，不等待评测结果
+// 评测服务的 worker 消费任务后把结果写回 judgement 表，version 为评测使用的题目版本
+func (s *SubmissionService) enqueueJudgement(request request.SubmissionReq, data *pb.SubmitRequest, version int) (response response.ResponseWithData) {
+	judgementID := utils.GetUUID()
+	data.ProblemId = request.ProblemID
+	data.JudgementId = judgementID
+
+	err := mysql.InsertNewSubmission(&mysql.Judgement{
+		UID:            request.UserID,
+		JudgementID:    judgementID,
+		SubmissionID:   request.SubmissionID,
+		ProblemID:      request.ProblemID,
+		Verdict:        resp_code.VerdictPending,
+		ContestID:      request.ContestID,
+		ProblemVersion: version,
+	})
+	if err != nil {
+		response.Code = resp_code.InsertToJudgementError
+		zap.L().Error("services-enqueueJudgement-InsertNewSubmission ", zap.Error(err))
+		return
+	}
+
+	dataBody, err := json.Marshal(data)
+	if err != nil {
+		response.Code = resp_code.JSONMarshalError
+		zap.L().Error("services-enqueueJudgement-Marshal ", zap.Error(err))
+		s.failJudgement(judgementID)
+		return
+	}
+	if err = mq.SendMessage2MQ(dataBody, 0); err != nil {
+		response.Code = resp_code.Send2MQError
+		zap.L().Error("services-enqueueJudgement-SendMessage2MQ ", zap.Error(err))
+		s.failJudgement(judgementID)
+		return
+	}
+
+	response.Code = resp_code.Success
+	response.Data = struct {
+		SubmissionID string `json:"submission_id"`
+		JudgementID  string `json:"judgement_id"`
+		Verdict      string `json:"verdict"`
+	}{
+		SubmissionID: request.SubmissionID,
+		JudgementID:  judgementID,
+		Verdict:      resp_code.VerdictPending,
+	}
+	return
+}
+
+// failJudgement 评测任务没有发送成功，避免记录一直处于等待评测的状态
+func (s *SubmissionService) failJudgement(judgementID string) {
+	_, err := mysql.UpdateJudgementResult(&mysql.Judgement{
+		JudgementID: judgementID,
+		Verdict:     resp_code.VerdictSystemError,
+	})
+	if err != nil {
+		zap.L().Error("services-failJudgement-UpdateJudgementResult ", zap.Error(err))
+	}
+}
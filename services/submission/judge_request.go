@@ -0,0 +1,120 @@
+package submission
+
+import (
+	"errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"online_judge/consts/resp_code"
+	"online_judge/dao/mysql"
+	"online_judge/pkg/testcase"
+	pb "online_judge/proto"
+)
+
+// 预测试模式下的测试组名称
+const (
+	pretestGroup = "pretest"
+	mainGroup    = "main"
+)
+
+// judgeRequest 读取题目的测试数据、限制和最新的版本号，返回不含提交信息的评测请求
+// pretest 为 true 并且题目有预测试时，先评测预测试再评测其余测试数据
+func (s *SubmissionService) judgeRequest(pid string, pretest bool) (data *pb.SubmitRequest, version int, code int) {
+	problem, err := mysql.GetEntireProblem(pid)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, 0, resp_code.ProblemNotExist
+		}
+		zap.L().Error("services-judgeRequest-GetEntireProblem ", zap.Error(err))
+		return nil, 0, resp_code.SearchDBError
+	}
+	if version, err = mysql.GetLatestProblemVersion(pid); err != nil {
+		zap.L().Error("services-judgeRequest-GetLatestProblemVersion ", zap.Error(err))
+		return nil, 0, resp_code.SearchDBError
+	}
+
+	// 得到输入和输出
+	input := make([]string, len(problem.TestCases))
+	expected := make([]string, len(problem.TestCases))
+	isSample := make([]bool, len(problem.TestCases))
+	isPretest := make([]bool, len(problem.TestCases))
+	for i, tc := range problem.TestCases {
+		input[i], expected[i], isSample[i], isPretest[i] = tc.Input, tc.Expected, tc.IsSample, tc.IsPretest
+	}
+	data = &pb.SubmitRequest{
+		ProblemId:   pid,
+		Input:       input,
+		Expected:    expected,
+		IsSample:    isSample,
+		TimeLimit:   int32(problem.MaxRuntime),
+		MemoryLimit: int32(problem.MaxMemory),
+		TotalNum:    int32(len(input)),
+		Checker:     checkerConfig(problem.Checker),
+		Interactor:  interactorConfig(problem.Interactor),
+	}
+	if pretest {
+		data.Groups = PretestGroups(isPretest, problem.StopOnPretestFailure)
+	}
+	return data, version, resp_code.Success
+}
+
+// PretestGroups 把测试数据分为预测试和其余测试两组，isPretest 按测试数据的顺序标记是否为预测试
+// 没有预测试时返回 nil，stopOnFailure 为 true 时预测试未通过不再评测其余测试数据
+func PretestGroups(isPretest []bool, stopOnFailure bool) []*pb.TestGroup {
+	pre := &pb.TestGroup{Name: pretestGroup, StopOnFirstFailure: stopOnFailure}
+	main := &pb.TestGroup{Name: mainGroup}
+	for i, p := range isPretest {
+		if p {
+			pre.Cases = append(pre.Cases, int32(i))
+		} else {
+			main.Cases = append(main.Cases, int32(i))
+		}
+	}
+	if len(pre.Cases) == 0 {
+		return nil
+	}
+	return []*pb.TestGroup{pre, main}
+}
+
+// judgeRequestWithFile 和 judgeRequest 相同，测试数据从题目的输入输出文件中读取，预测试标记来自清单文件
+func (s *SubmissionService) judgeRequestWithFile(pid string, pretest bool) (data *pb.SubmitRequest, version int, code int) {
+	problem, err := mysql.GetEntireProblemWithFile(pid)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, 0, resp_code.ProblemNotExist
+		}
+		zap.L().Error("services-judgeRequestWithFile-GetEntireProblemWithFile ", zap.Error(err))
+		return nil, 0, resp_code.SearchDBError
+	}
+	cases, err := testcase.ReadFiles(problem.InputPath, problem.ExpectedPath)
+	if err != nil {
+		zap.L().Error("services-judgeRequestWithFile-ReadFiles ", zap.Error(err))
+		return nil, 0, resp_code.ReadTestFileError
+	}
+	if version, err = mysql.GetLatestProblemVersion(pid); err != nil {
+		zap.L().Error("services-judgeRequestWithFile-GetLatestProblemVersion ", zap.Error(err))
+		return nil, 0, resp_code.SearchDBError
+	}
+
+	input := make([]string, len(cases))
+	expected := make([]string, len(cases))
+	isSample := make([]bool, len(cases))
+	isPretest := make([]bool, len(cases))
+	for i, c := range cases {
+		input[i], expected[i], isSample[i], isPretest[i] = c.Input, c.Expected, c.IsSample, c.IsPretest
+	}
+	data = &pb.SubmitRequest{
+		ProblemId:   pid,
+		Input:       input,
+		Expected:    expected,
+		IsSample:    isSample,
+		TimeLimit:   int32(problem.MaxRuntime),
+		MemoryLimit: int32(problem.MaxMemory),
+		TotalNum:    int32(len(input)),
+		Checker:     checkerConfig(problem.Checker),
+		Interactor:  interactorConfig(problem.Interactor),
+	}
+	if pretest {
+		data.Groups = PretestGroups(isPretest, problem.StopOnPretestFailure)
+	}
+	return data, version, resp_code.Success
+}
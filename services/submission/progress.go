@@ -0,0 +1,124 @@
+package submission
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"online_judge/consts"
+	"online_judge/consts/resp_code"
+	"online_judge/dao/mysql"
+	"online_judge/dao/redis"
+	"online_judge/models/common/response"
+	"online_judge/models/submission/request"
+	submission "online_judge/models/submission/response"
+	"time"
+)
+
+// progressTimeout 单次订阅推送评测进度的最长时间
+const progressTimeout = 5 * time.Minute
+
+// StreamProgress 订阅提交的评测进度，评测已经结束时只返回最终结果
+// 返回的 channel 在评测结束、ctx 取消或者超时后关闭
+func (s *SubmissionService) StreamProgress(ctx context.Context, request request.SubmissionProgressReq) (response response.Response, events <-chan *submission.ProgressEvent) {
+	sub, err := mysql.GetSubmission(request.SubmissionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.Code = resp_code.SubmissionNotExist
+			return
+		}
+		response.Code = resp_code.SearchDBError
+		zap.L().Error("services-StreamProgress-GetSubmission ", zap.Error(err))
+		return
+	}
+	if sub.UserID != request.UserID {
+		response.Code = resp_code.PermissionDenied
+		return
+	}
+
+	// 先订阅再查询评测结果，避免错过订阅之前发布的结束事件
+	ps, err := redis.SubscribeProgress(ctx, request.SubmissionID)
+	if err != nil {
+		response.Code = resp_code.InternalServerError
+		zap.L().Error("services-StreamProgress-SubscribeProgress ", zap.Error(err))
+		return
+	}
+	judgement, err := mysql.GetJudgementBySubmissionID(request.SubmissionID)
+	if err != nil {
+		_ = ps.Close()
+		response.Code = resp_code.SearchDBError
+		zap.L().Error("services-StreamProgress-GetJudgementBySubmissionID ", zap.Error(err))
+		return
+	}
+
+	ch := make(chan *submission.ProgressEvent, 1)
+	if judgement.Verdict != resp_code.VerdictPending {
+		_ = ps.Close()
+		ch <- finishedEvent(judgement, nil)
+		close(ch)
+		response.Code = resp_code.Success
+		return response, ch
+	}
+
+	go func() {
+		defer close(ch)
+		defer ps.Close()
+		timer := time.NewTimer(progressTimeout)
+		defer timer.Stop()
+
+		msgs := ps.Channel()
+		for {
+			var event submission.ProgressEvent
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+				return
+			case msg, ok := <-msgs:
+				if !ok {
+					return
+				}
+				if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+					zap.L().Error("services-StreamProgress-Unmarshal ", zap.Error(err))
+					continue
+				}
+			}
+
+			finished := event.Stage == consts.ProgressFinished
+			if finished {
+				// 以数据库中写回的结果为准
+				if j, err := mysql.GetJudgementBySubmissionID(request.SubmissionID); err == nil {
+					event = *finishedEvent(j, &event)
+				}
+			}
+			select {
+			case ch <- &event:
+			case <-ctx.Done():
+				return
+			}
+			if finished {
+				return
+			}
+		}
+	}()
+
+	response.Code = resp_code.Success
+	return response, ch
+}
+
+// finishedEvent 根据评测记录生成结束事件，last 为评测服务发布的结束事件
+func finishedEvent(j *mysql.Judgement, last *submission.ProgressEvent) *submission.ProgressEvent {
+	event := &submission.ProgressEvent{
+		SubmissionID: j.SubmissionID,
+		Stage:        consts.ProgressFinished,
+		Verdict:      j.Verdict,
+		Runtime:      j.Runtime,
+		MemoryUsage:  j.MemoryUsage,
+	}
+	if last != nil {
+		event.Done = last.Done
+		event.Total = last.Total
+	}
+	return event
+}
@@ -0,0 +1,191 @@
+package submission
+
+import (
+	"encoding/json"
+	"errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"online_judge/consts/resp_code"
+	"online_judge/dao/mq"
+	"online_judge/dao/mysql"
+	"online_judge/dao/redis"
+	"online_judge/models/common/response"
+	"online_judge/models/submission/request"
+	submission "online_judge/models/submission/response"
+	pb "online_judge/proto"
+)
+
+// Rejudge 使用各题目的最新版本重新评测满足条件的提交，评测记录原地更新
+// 指定题目时题目不存在返回 ProblemNotExist，按其他条件选出的已删除题目会被跳过
+// response.Data 为 submission.RejudgeResult
+func (s *SubmissionService) Rejudge(req request.RejudgeReq) (response response.ResponseWithData) {
+	filter := mysql.RejudgeFilter{
+		ProblemID:     req.ProblemID,
+		SubmissionIDs: req.SubmissionIDs,
+		UserID:        req.UserID,
+		Verdict:       req.Verdict,
+		From:          req.From,
+		To:            req.To,
+	}
+	pids := []string{req.ProblemID}
+	if req.ProblemID == "" {
+		var err error
+		if pids, err = mysql.GetRejudgeProblemIDs(filter); err != nil {
+			response.Code = resp_code.SearchDBError
+			zap.L().Error("services-Rejudge-GetRejudgeProblemIDs ", zap.Error(err))
+			return
+		}
+	}
+
+	result := submission.RejudgeResult{Versions: make(map[string]int, len(pids))}
+	for _, pid := range pids {
+		data, version, code := s.judgeRequest(pid, false)
+		if code == resp_code.ProblemNotExist {
+			data, version, code = s.judgeRequestWithFile(pid, false)
+		}
+		if code == resp_code.ProblemNotExist && req.ProblemID == "" {
+			zap.L().Warn("services-Rejudge-judgeRequest problem not exist", zap.String("problem_id", pid))
+			continue
+		}
+		if code != resp_code.Success {
+			response.Code = code
+			return
+		}
+
+		items, err := mysql.ResetJudgements(pid, version, filter)
+		if err != nil {
+			response.Code = resp_code.SearchDBError
+			zap.L().Error("services-Rejudge-ResetJudgements ", zap.Error(err))
+			return
+		}
+		result.Versions[pid] = version
+		result.Total += len(items)
+		result.Failed += s.dispatchRejudge(data, items)
+		s.invalidateJudgements(items)
+	}
+
+	response.Code = resp_code.Success
+	response.Data = result
+	return
+}
+
+// dispatchRejudge 把重置后的提交重新发送到评测队列，发送失败的记录标记为系统错误，返回失败的数量
+// 重新评测总是评测全部测试数据，不使用预测试模式
+func (s *SubmissionService) dispatchRejudge(data *pb.SubmitRequest, items []mysql.RejudgeItem) (failed int) {
+	for _, item := range items {
+		body, err := json.Marshal(&pb.SubmitRequest{
+			UserId:       item.UserID,
+			Language:     item.Language,
+			SubmissionId: item.SubmissionID,
+			Code:         item.Code,
+			Input:        data.Input,
+			Expected:     data.Expected,
+			IsSample:     data.IsSample,
+			TimeLimit:    data.TimeLimit,
+			MemoryLimit:  data.MemoryLimit,
+			TotalNum:     data.TotalNum,
+			ProblemId:    item.ProblemID,
+			JudgementId:  item.JudgementID,
+			Checker:      data.Checker,
+			Interactor:   data.Interactor,
+		})
+		if err == nil {
+			err = mq.SendMessage2MQ(body, 0)
+		}
+		if err != nil {
+			zap.L().Error("services-dispatchRejudge-SendMessage2MQ ",
+				zap.String("judgement_id", item.JudgementID), zap.Error(err))
+			s.failJudgement(item.JudgementID)
+			failed++
+		}
+	}
+	return
+}
+
+// OverrideVerdict 手动修改提交的评测结果，修改记录和原因保存在审计日志中
+func (s *SubmissionService) OverrideVerdict(req request.VerdictOverrideReq) (response response.Response) {
+	old, err := mysql.OverrideVerdict(req.SubmissionID, req.Verdict, req.AdminID, req.Note)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			response.Code = resp_code.SubmissionNotExist
+		case errors.Is(err, mysql.ErrJudgementPending):
+			response.Code = resp_code.JudgementPending
+		default:
+			response.Code = resp_code.SearchDBError
+			zap.L().Error("services-OverrideVerdict-OverrideVerdict ", zap.Error(err))
+		}
+		return
+	}
+	s.invalidateJudgements([]mysql.RejudgeItem{{
+		JudgementID:  old.JudgementID,
+		SubmissionID: old.SubmissionID,
+		UserID:       old.UID,
+		ProblemID:    old.ProblemID,
+		ContestID:    old.ContestID,
+	}})
+	response.Code = resp_code.Success
+	return
+}
+
+// invalidateJudgements 评测结果被重置或修改后删除受影响的评测记录、题目、用户和比赛的缓存，并更新全站排行榜
+// 重新评测完成后 worker 会再次更新这些数据，这里处理的是重置评测记录造成的变化
+func (s *SubmissionService) invalidateJudgements(items []mysql.RejudgeItem) {
+	if len(items) == 0 {
+		return
+	}
+	judgementIDs := make([]string, len(items))
+	submissionIDs := make([]string, len(items))
+	problems := make(map[string]struct{})
+	users := make(map[int64]struct{})
+	contests := make(map[string]struct{})
+	for i, item := range items {
+		judgementIDs[i] = item.JudgementID
+		submissionIDs[i] = item.SubmissionID
+		problems[item.ProblemID] = struct{}{}
+		users[item.UserID] = struct{}{}
+		if item.ContestID != "" {
+			contests[item.ContestID] = struct{}{}
+		}
+	}
+
+	if err := redis.DeleteEvaluationDetails(judgementIDs, submissionIDs); err != nil {
+		zap.L().Error("services-invalidateJudgements-DeleteEvaluationDetails ", zap.Error(err))
+	}
+	for pid := range problems {
+		if err := redis.MarkProblemStatsDirty(pid); err != nil {
+			zap.L().Error("services-invalidateJudgements-MarkProblemStatsDirty ", zap.Error(err))
+		}
+	}
+	for cid := range contests {
+		if err := redis.DeleteScoreboardSnapshot(cid); err != nil {
+			zap.L().Error("services-invalidateJudgements-DeleteScoreboardSnapshot ", zap.Error(err))
+		}
+	}
+	for uid := range users {
+		if err := redis.DeleteProblemStatus(uid); err != nil {
+			zap.L().Error("services-invalidateJudgements-DeleteProblemStatus ", zap.Error(err))
+		}
+		if err := redis.DeleteUserProfile(uid); err != nil {
+			zap.L().Error("services-invalidateJudgements-DeleteUserProfile ", zap.Error(err))
+		}
+		updateLeaderboardScore(uid)
+	}
+}
+
+// updateLeaderboardScore 用户的通过数量重新计算后更新全站排行榜，失败时等待定期校正
+func updateLeaderboardScore(uid int64) {
+	entry, err := mysql.GetLeaderboardEntry(uid)
+	if err != nil {
+		zap.L().Error("services-updateLeaderboardScore-GetLeaderboardEntry ", zap.Error(err))
+		return
+	}
+	if entry.FinishNum == 0 {
+		err = redis.RemoveLeaderboardScore(uid)
+	} else {
+		err = redis.SetLeaderboardScore(uid, redis.LeaderboardScore(entry.FinishNum, entry.LastAcceptedAt))
+	}
+	if err != nil {
+		zap.L().Error("services-updateLeaderboardScore-SetLeaderboardScore ", zap.Error(err))
+	}
+}
@@ -0,0 +1,92 @@
+package submission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-micro/plugins/v4/registry/etcd"
+	"go-micro.dev/v4"
+	"go-micro.dev/v4/client"
+	"go-micro.dev/v4/registry"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"online_judge/consts/resp_code"
+	"online_judge/dao/mysql"
+	"online_judge/models/common/response"
+	"online_judge/models/submission/request"
+	submission "online_judge/models/submission/response"
+	"online_judge/pkg/language"
+	pb "online_judge/proto"
+	"online_judge/setting"
+	"sync"
+	"time"
+)
+
+// judgeServiceName 评测服务在 etcd 中注册的名称
+const judgeServiceName = "rpcSubmissionService"
+
+// runCodeTimeout 等待评测服务返回运行结果的时间，包含编译时间
+const runCodeTimeout = time.Minute
+
+var (
+	judgeClient     pb.SubmissionService
+	judgeClientOnce sync.Once
+)
+
+// getJudgeClient 通过 etcd 发现评测服务，第一次使用时创建客户端
+func getJudgeClient() pb.SubmissionService {
+	judgeClientOnce.Do(func() {
+		etcdReg := etcd.NewRegistry(
+			registry.Addrs(fmt.Sprintf("%s:%d", setting.Conf.EtcdConfig.Host, setting.Conf.EtcdConfig.Port)),
+		)
+		service := micro.NewService(micro.Registry(etcdReg))
+		judgeClient = pb.NewSubmissionService(judgeServiceName, service.Client())
+	})
+	return judgeClient
+}
+
+// RunCode 使用题目的时间和内存限制，在评测服务中用自定义输入运行代码
+// 不保存提交记录和评测结果，response.Data 为 submission.RunResult
+func (s *SubmissionService) RunCode(req request.RunCodeReq) (response response.ResponseWithData) {
+	if _, ok := language.Get(req.Language); !ok {
+		response.Code = resp_code.UnsupportedLanguage
+		return
+	}
+	limits, err := mysql.GetProblemLimits(req.ProblemID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.Code = resp_code.ProblemNotExist
+			return
+		}
+		response.Code = resp_code.SearchDBError
+		zap.L().Error("services-RunCode-GetProblemLimits ", zap.Error(err))
+		return
+	}
+
+	resp, err := getJudgeClient().RunCode(context.Background(), &pb.RunRequest{
+		UserId:      req.UserID,
+		Code:        req.Code,
+		Language:    req.Language,
+		Input:       req.Input,
+		TimeLimit:   int32(limits.MaxRuntime),
+		MemoryLimit: int32(limits.MaxMemory),
+		RunId:       req.RunID,
+	}, client.WithRequestTimeout(runCodeTimeout))
+	if err != nil {
+		response.Code = resp_code.InternalServerError
+		zap.L().Error("services-RunCode-RunCode ", zap.Error(err))
+		return
+	}
+
+	response.Code = resp_code.Success
+	response.Data = submission.RunResult{
+		Status:      resp_code.RunStatus(resp.Status),
+		Stdout:      resp.Stdout,
+		Stderr:      resp.Stderr,
+		ExitCode:    int(resp.ExitCode),
+		Runtime:     int(resp.Runtime),
+		MemoryUsage: int(resp.MemoryUsage),
+		Output:      resp.Output,
+	}
+	return
+}
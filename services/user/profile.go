@@ -0,0 +1,128 @@
+package user
+
+import (
+	"go.uber.org/zap"
+	"online_judge/consts/resp_code"
+	"online_judge/dao/mysql"
+	"online_judge/models/common/response"
+	"online_judge/models/user/request"
+	profile "online_judge/models/user/response"
+	"time"
+)
+
+// heatmapDays 热力图统计的天数
+const heatmapDays = 365
+
+// GetUserProfile 获取用户主页的统计信息
+func (u *UserService) GetUserProfile(req request.GetUserDetailReq) (response response.ResponseWithData) {
+	entry, err := mysql.GetLeaderboardEntry(req.UserID)
+	if err != nil {
+		if err == mysql.ErrUserNotFound {
+			response.Code = resp_code.NotExistUserID
+			return
+		}
+		response.Code = resp_code.SearchDBError
+		zap.L().Error("services-GetUserProfile-GetLeaderboardEntry ", zap.Error(err))
+		return
+	}
+
+	data, err := loadUserProfile(req.UserID, time.Now())
+	if err != nil {
+		response.Code = resp_code.SearchDBError
+		zap.L().Error("services-GetUserProfile-loadUserProfile ", zap.Error(err))
+		return
+	}
+	data.UserID = entry.UserID
+	data.Username = entry.Username
+	data.FinishNum = entry.FinishNum
+	response.Code = resp_code.Success
+	response.Data = data
+	return
+}
+
+// loadUserProfile 从 submission 和 judgement 表统计用户的提交信息
+func loadUserProfile(uid int64, now time.Time) (*profile.UserProfile, error) {
+	data := &profile.UserProfile{}
+	var err error
+	if data.SubmitNum, data.AcceptedNum, err = mysql.GetUserVerdictCount(uid); err != nil {
+		return nil, err
+	}
+	if data.SubmitNum > 0 {
+		data.AcceptanceRate = float64(data.AcceptedNum) / float64(data.SubmitNum)
+	}
+
+	difficulties, err := mysql.GetSolvedByDifficulty(uid)
+	if err != nil {
+		return nil, err
+	}
+	data.ByDifficulty = solvedCounts(difficulties)
+	categories, err := mysql.GetSolvedByCategory(uid)
+	if err != nil {
+		return nil, err
+	}
+	data.ByCategory = solvedCounts(categories)
+
+	languages, err := mysql.GetUserLanguageCount(uid)
+	if err != nil {
+		return nil, err
+	}
+	data.Languages = make([]profile.LanguageCount, len(languages))
+	for i, l := range languages {
+		data.Languages[i] = profile.LanguageCount{Language: l.Name, Count: l.Count}
+	}
+
+	days, err := mysql.GetUserDailySubmissions(uid, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	dates := make([]string, len(days))
+	for i, d := range days {
+		dates[i] = d.Day
+	}
+	data.CurrentStreak, data.LongestStreak = Streaks(dates, now)
+
+	from := now.AddDate(0, 0, 1-heatmapDays).Format(time.DateOnly)
+	data.Heatmap = make([]profile.HeatmapDay, 0, len(days))
+	for _, d := range days {
+		if d.Day >= from {
+			data.Heatmap = append(data.Heatmap, profile.HeatmapDay{Date: d.Day, Count: d.Count})
+		}
+	}
+	return data, nil
+}
+
+func solvedCounts(counts []mysql.NameCount) []profile.SolvedCount {
+	result := make([]profile.SolvedCount, len(counts))
+	for i, c := range counts {
+		result[i] = profile.SolvedCount{ID: c.ID, Name: c.Name, Count: c.Count}
+	}
+	return result
+}
+
+// Streaks 根据按升序排列、格式为 2006-01-02 的提交日期计算连续提交天数
+// 今天还没有提交时，截止到昨天的连续天数仍然算作当前连续天数
+func Streaks(dates []string, now time.Time) (current, longest int) {
+	var prev time.Time
+	run := 0
+	for _, date := range dates {
+		day, err := time.Parse(time.DateOnly, date)
+		if err != nil {
+			continue
+		}
+		if !prev.IsZero() && day.Sub(prev) == 24*time.Hour {
+			run++
+		} else {
+			run = 1
+		}
+		if run > longest {
+			longest = run
+		}
+		prev = day
+	}
+
+	today, _ := time.Parse(time.DateOnly, now.Format(time.DateOnly))
+	if !prev.IsZero() && (prev.Equal(today) || prev.Equal(today.AddDate(0, 0, -1))) {
+		current = run
+	}
+	return
+}
@@ -9,17 +9,21 @@ import (
 var Conf = new(AppConfig)
 
 type AppConfig struct {
-	Name            string `mapstructure:"name"`
-	Mode            string `mapstructure:"mode"`
-	Version         string `mapstructure:"version"`
-	StartTime       string `mapstructure:"start_time"`
-	MachineID       int64  `mapstructure:"machine_id"`
-	Port            int    `mapstructure:"port"`
-	*LogConfig      `mapstructure:"log"`
-	*MySQLConfig    `mapstructure:"mysql"`
-	*RedisConfig    `mapstructure:"redis"`
-	*RabbitMQConfig `mapstructure:"rabbitmq"`
-	*EtcdConfig     `mapstructure:"etcd"`
+	Name               string `mapstructure:"name"`
+	Mode               string `mapstructure:"mode"`
+	Version            string `mapstructure:"version"`
+	StartTime          string `mapstructure:"start_time"`
+	MachineID          int64  `mapstructure:"machine_id"`
+	Port               int    `mapstructure:"port"`
+	*LogConfig         `mapstructure:"log"`
+	*MySQLConfig       `mapstructure:"mysql"`
+	*RedisConfig       `mapstructure:"redis"`
+	*RabbitMQConfig    `mapstructure:"rabbitmq"`
+	*EtcdConfig        `mapstructure:"etcd"`
+	*SandboxConfig     `mapstructure:"sandbox"`
+	*JudgementConfig   `mapstructure:"judgement"`
+	*LeaderboardConfig `mapstructure:"leaderboard"`
+	Languages          []*LanguageConfig `mapstructure:"languages"`
 }
 
 type LogConfig struct {
@@ -64,6 +68,41 @@ type EtcdConfig struct {
 	Port int    `mapstructure:"port"`
 }
 
+type SandboxConfig struct {
+	Namespace    bool   `mapstructure:"namespace"`
+	Seccomp      bool   `mapstructure:"seccomp"`
+	CgroupRoot   string `mapstructure:"cgroup_root"`
+	UID          int    `mapstructure:"uid"`
+	GID          int    `mapstructure:"gid"`
+	TmpfsSize    int    `mapstructure:"tmpfs_size"`
+	OutputLimit  int    `mapstructure:"output_limit"`
+	ProcessLimit int    `mapstructure:"process_limit"`
+}
+
+type JudgementConfig struct {
+	WorkRoot    string `mapstructure:"work_root"`
+	DiskQuota   int    `mapstructure:"disk_quota"`
+	Parallelism int    `mapstructure:"parallelism"`
+	Workers     int    `mapstructure:"workers"`
+	MaxRetry    int    `mapstructure:"max_retry"`
+	RunLimit    int    `mapstructure:"run_limit"`
+}
+
+type LeaderboardConfig struct {
+	ReconcileInterval int `mapstructure:"reconcile_interval"`
+}
+
+type LanguageConfig struct {
+	Name         string   `mapstructure:"name"`
+	Version      string   `mapstructure:"version"`
+	SourceFile   string   `mapstructure:"source_file"`
+	CompileCmd   string   `mapstructure:"compile_cmd"`
+	RunCmd       string   `mapstructure:"run_cmd"`
+	Env          []string `mapstructure:"env"`
+	TimeFactor   float64  `mapstructure:"time_factor"`
+	MemoryFactor float64  `mapstructure:"memory_factor"`
+}
+
 func Init() (err error) {
 	// 读取配置文件
 	viper.SetConfigFile("./conf/config.yaml")
@@ -0,0 +1,138 @@
+package test
+
+import (
+	"github.com/stretchr/testify/require"
+	"online_judge/app/judgement/responses"
+	"online_judge/app/judgement/service/judging"
+	"online_judge/app/judgement/service/judging/checker"
+	"online_judge/consts"
+	pb "online_judge/proto"
+	"testing"
+)
+
+type checkerCase struct {
+	name     string
+	output   string
+	answer   string
+	accepted bool
+	message  string
+}
+
+func runCheckerCases(t *testing.T, chk checker.Checker, cases []checkerCase) {
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			res, err := chk.Check(0, "", c.output, c.answer)
+			require.NoError(t, err)
+			require.Equal(t, c.accepted, res.Accepted)
+			require.Equal(t, c.message, res.Message)
+		})
+	}
+}
+
+func TestCheckerNew(t *testing.T) {
+	require.Equal(t, checker.Exact{}, checker.New(nil))
+	require.Equal(t, checker.Exact{}, checker.New(&pb.Checker{Mode: consts.CheckerExact}))
+	require.Equal(t, checker.Token{}, checker.New(&pb.Checker{Mode: consts.CheckerToken}))
+	require.Equal(t, checker.Float{Epsilon: consts.DefaultCheckerEpsilon}, checker.New(&pb.Checker{Mode: consts.CheckerFloat}))
+	require.Equal(t, checker.Float{Epsilon: 1e-3}, checker.New(&pb.Checker{Mode: consts.CheckerFloat, Epsilon: 1e-3}))
+}
+
+func TestCheckerExact(t *testing.T) {
+	runCheckerCases(t, checker.Exact{}, []checkerCase{
+		{name: "same", output: "1 2\n3", answer: "1 2\n3", accepted: true},
+		{name: "trailing newline", output: "1 2\n3\n", answer: "1 2\n3"},
+		{name: "trailing space", output: "1 2 \n3", answer: "1 2\n3"},
+		{name: "crlf", output: "1 2\r\n3", answer: "1 2\n3"},
+		{name: "different", output: "1 2\n4", answer: "1 2\n3"},
+		{name: "empty", output: "", answer: "", accepted: true},
+	})
+}
+
+func TestCheckerToken(t *testing.T) {
+	runCheckerCases(t, checker.Token{}, []checkerCase{
+		{name: "same", output: "1 2\n3", answer: "1 2\n3", accepted: true},
+		{name: "trailing whitespace", output: "1 2  \n3\n\n", answer: "1 2\n3", accepted: true},
+		{name: "leading whitespace", output: "\n\t1 2 3", answer: "1 2\n3", accepted: true},
+		{name: "crlf", output: "1 2\r\n3\r\n", answer: "1 2\n3", accepted: true},
+		{name: "split by newline", output: "1\n2\n3", answer: "1 2 3", accepted: true},
+		{name: "joined token", output: "12 3", answer: "1 2 3", message: "expected 3 tokens, found 2"},
+		{name: "missing token", output: "1 2", answer: "1 2 3", message: "expected 3 tokens, found 2"},
+		{name: "extra token", output: "1 2 3 4", answer: "1 2 3", message: "expected 3 tokens, found 4"},
+		{name: "different token", output: "1 5 3", answer: "1 2 3", message: `token 2 differs, found "5"`},
+		{name: "case sensitive", output: "yes", answer: "YES", message: `token 1 differs, found "yes"`},
+		{name: "numbers compared as text", output: "1.0", answer: "1", message: `token 1 differs, found "1.0"`},
+		{name: "empty", output: " \n", answer: "", accepted: true},
+	})
+}
+
+func TestCheckerFloat(t *testing.T) {
+	runCheckerCases(t, checker.Float{Epsilon: 1e-6}, []checkerCase{
+		{name: "same text", output: "abc 1.5", answer: "abc 1.5", accepted: true},
+		{name: "trailing whitespace", output: "1.5 \n2\n\n", answer: "1.5 2", accepted: true},
+		{name: "different format", output: "1.000000", answer: "1", accepted: true},
+		// 答案绝对值不超过 1 时按绝对误差比较
+		{name: "absolute within", output: "0.1000009", answer: "0.1", accepted: true},
+		{name: "absolute boundary", output: "0.000001", answer: "0", accepted: true},
+		{name: "absolute exceeded", output: "0.100002", answer: "0.1", message: `token 1 differs, found "0.100002"`},
+		// 答案绝对值大于 1 时按相对误差比较
+		{name: "relative within", output: "1000000.5", answer: "1000000", accepted: true},
+		{name: "relative exceeded", output: "1000002", answer: "1000000", message: `token 1 differs, found "1000002"`},
+		{name: "relative negative", output: "-1000000.5", answer: "-1000000", accepted: true},
+		{name: "small answer uses absolute", output: "0.0000019", answer: "0.000001", accepted: true},
+		{name: "not a number", output: "abc", answer: "1", message: `token 1 differs, found "abc"`},
+		{name: "text differs", output: "1 abd", answer: "1 abc", message: `token 2 differs, found "abd"`},
+		{name: "nan", output: "NaN", answer: "nan", message: `token 1 differs, found "NaN"`},
+		{name: "token count", output: "1.0", answer: "1.0 2.0", message: "expected 2 tokens, found 1"},
+	})
+
+	runCheckerCases(t, checker.Float{Epsilon: 1e-2}, []checkerCase{
+		{name: "custom epsilon absolute", output: "0.505", answer: "0.5", accepted: true},
+		{name: "custom epsilon relative", output: "1005", answer: "1000", accepted: true},
+		{name: "custom epsilon exceeded", output: "1020", answer: "1000", message: `token 1 differs, found "1020"`},
+	})
+}
+
+func TestWorseVerdict(t *testing.T) {
+	// SE > CE > RE > TLE > MLE > WA > AC
+	order := []int32{
+		responses.Accepted,
+		responses.WrongAnswer,
+		responses.MemoryLimited,
+		responses.TimeLimited,
+		responses.RuntimeError,
+		responses.CompilerError,
+		responses.SystemError,
+	}
+	for i, a := range order {
+		for j, b := range order {
+			require.Equal(t, i > j, judging.WorseVerdict(a, b), "%d vs %d", a, b)
+		}
+	}
+
+	// 按评测流水线的方式合并多个样例的结果
+	merge := func(statuses ...int32) int32 {
+		final := int32(responses.Accepted)
+		for _, s := range statuses {
+			if judging.WorseVerdict(s, final) {
+				final = s
+			}
+		}
+		return final
+	}
+	require.Equal(t, int32(responses.Accepted), merge())
+	require.Equal(t, int32(responses.Accepted), merge(responses.Accepted, responses.Accepted))
+	require.Equal(t, int32(responses.WrongAnswer), merge(responses.Accepted, responses.WrongAnswer, responses.Accepted))
+	require.Equal(t, int32(responses.TimeLimited), merge(responses.WrongAnswer, responses.TimeLimited, responses.MemoryLimited))
+	require.Equal(t, int32(responses.RuntimeError), merge(responses.TimeLimited, responses.RuntimeError, responses.WrongAnswer))
+	require.Equal(t, int32(responses.SystemError), merge(responses.SystemError, responses.CompilerError, responses.RuntimeError))
+}
+
+func TestRunTestCasesLengthMismatch(t *testing.T) {
+	// 期望输出缺失时直接返回系统错误，不进入沙箱
+	request := &pb.SubmitRequest{UserId: 1, Input: []string{"1", "2"}, Expected: []string{"1"}}
+	response := judging.RunTestCases(nil, nil, nil, t.TempDir(), request, &pb.SubmitResponse{}, nil)
+	require.Equal(t, int32(responses.SystemError), response.Status)
+	require.Equal(t, int64(1), response.UserId)
+	require.Empty(t, response.Cases)
+	require.Contains(t, response.Output, "2 inputs, 1 expected outputs")
+}
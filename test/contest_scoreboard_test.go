@@ -0,0 +1,115 @@
+package test
+
+import (
+	"github.com/stretchr/testify/require"
+	"online_judge/consts"
+	"online_judge/consts/resp_code"
+	"online_judge/dao/mysql"
+	"online_judge/services/contest"
+	"testing"
+	"time"
+)
+
+func scoreboardContest(rule string) (*mysql.Contest, time.Time) {
+	start := time.Date(2024, 5, 1, 9, 0, 0, 0, time.Local)
+	return &mysql.Contest{
+		ContestID: "contest",
+		Rule:      rule,
+		StartTime: start,
+		EndTime:   start.Add(5 * time.Hour),
+		Penalty:   consts.DefaultContestPenalty,
+		Problems: []mysql.ContestProblem{
+			{ProblemID: "p1", Label: "A", Score: 100},
+			{ProblemID: "p2", Label: "B", Score: 100},
+		},
+	}, start
+}
+
+func judgement(uid int64, pid, verdict string, at time.Time, pass, total int) mysql.Judgement {
+	j := mysql.Judgement{UID: uid, ProblemID: pid, Verdict: verdict, PassNum: pass, TotalNum: total}
+	j.CreatedAt = at
+	return j
+}
+
+func TestICPCScoreboard(t *testing.T) {
+	ct, start := scoreboardContest(consts.ContestRuleICPC)
+	users := []mysql.ContestUser{{UserID: 1, Username: "a"}, {UserID: 2, Username: "b"}, {UserID: 3, Username: "c"}}
+	judgements := []mysql.Judgement{
+		// 用户 1：A 错一次后 30 分钟通过，编译错误不计罚时，B 60 分钟通过
+		judgement(1, "p1", resp_code.VerdictWrongAnswer, start.Add(10*time.Minute), 0, 0),
+		judgement(1, "p1", resp_code.VerdictCompilerError, start.Add(20*time.Minute), 0, 0),
+		judgement(1, "p1", resp_code.VerdictAccepted, start.Add(30*time.Minute), 0, 0),
+		judgement(1, "p1", resp_code.VerdictWrongAnswer, start.Add(40*time.Minute), 0, 0),
+		judgement(1, "p2", resp_code.VerdictAccepted, start.Add(60*time.Minute), 0, 0),
+		// 用户 2：两题都一次通过，罚时更少
+		judgement(2, "p1", resp_code.VerdictAccepted, start.Add(50*time.Minute), 0, 0),
+		judgement(2, "p2", resp_code.VerdictAccepted, start.Add(55*time.Minute), 0, 0),
+		// 比赛结束后的提交不计入
+		judgement(3, "p1", resp_code.VerdictAccepted, start.Add(6*time.Hour), 0, 0),
+	}
+
+	board := contest.BuildScoreboard(ct, users, judgements, false)
+	require.Len(t, board.Rows, 3)
+	require.Equal(t, int64(2), board.Rows[0].UserID)
+	require.Equal(t, int64(105), board.Rows[0].Penalty)
+	require.Equal(t, int64(1), board.Rows[1].UserID)
+	require.Equal(t, 2, board.Rows[1].Solved)
+	require.Equal(t, int64(30+20+60), board.Rows[1].Penalty)
+	require.Equal(t, 2, board.Rows[1].Results[0].Attempts)
+	require.Equal(t, 3, board.Rows[2].Rank)
+	require.Equal(t, 0, board.Rows[2].Solved)
+}
+
+func TestOIScoreboard(t *testing.T) {
+	ct, start := scoreboardContest(consts.ContestRuleOI)
+	users := []mysql.ContestUser{{UserID: 1, Username: "a"}, {UserID: 2, Username: "b"}}
+	judgements := []mysql.Judgement{
+		// 每道题取最高分
+		judgement(1, "p1", resp_code.VerdictWrongAnswer, start.Add(time.Minute), 7, 10),
+		judgement(1, "p1", resp_code.VerdictWrongAnswer, start.Add(2*time.Minute), 3, 10),
+		judgement(1, "p2", resp_code.VerdictTimeLimited, start.Add(3*time.Minute), 1, 4),
+		judgement(2, "p1", resp_code.VerdictAccepted, start.Add(4*time.Minute), 10, 10),
+	}
+
+	board := contest.BuildScoreboard(ct, users, judgements, false)
+	require.Len(t, board.Rows, 2)
+	require.Equal(t, int64(2), board.Rows[0].UserID)
+	require.Equal(t, 100, board.Rows[0].Score)
+	require.Equal(t, 2, board.Rows[1].Rank)
+	require.Equal(t, 95, board.Rows[1].Score)
+	require.Equal(t, 70, board.Rows[1].Results[0].Score)
+	require.Equal(t, 25, board.Rows[1].Results[1].Score)
+}
+
+func TestFrozenScoreboardResolver(t *testing.T) {
+	ct, start := scoreboardContest(consts.ContestRuleICPC)
+	ct.FreezeMinutes = 60
+	users := []mysql.ContestUser{{UserID: 1, Username: "a"}, {UserID: 2, Username: "b"}}
+	judgements := []mysql.Judgement{
+		judgement(1, "p1", resp_code.VerdictAccepted, start.Add(30*time.Minute), 0, 0),
+		// 封榜后的提交
+		judgement(2, "p1", resp_code.VerdictAccepted, start.Add(250*time.Minute), 0, 0),
+		judgement(2, "p2", resp_code.VerdictAccepted, start.Add(260*time.Minute), 0, 0),
+		judgement(1, "p2", resp_code.VerdictWrongAnswer, start.Add(270*time.Minute), 0, 0),
+	}
+
+	frozen := contest.BuildScoreboard(ct, users, judgements, true)
+	require.True(t, frozen.Frozen)
+	require.Equal(t, int64(1), frozen.Rows[0].UserID)
+	require.Equal(t, 0, frozen.Rows[1].Solved)
+	require.Equal(t, 1, frozen.Rows[1].Results[0].Pending)
+
+	resolver := contest.BuildResolver(ct, users, judgements)
+	require.Len(t, resolver.Steps, 3)
+	// 从排名最后的用户开始揭晓
+	require.Equal(t, int64(2), resolver.Steps[0].UserID)
+	require.Equal(t, "A", resolver.Steps[0].Label)
+	require.Equal(t, 2, resolver.Steps[0].RankAfter)
+	require.Equal(t, int64(2), resolver.Steps[1].UserID)
+	require.Equal(t, 1, resolver.Steps[1].RankAfter)
+	require.Equal(t, int64(1), resolver.Steps[2].UserID)
+	require.False(t, resolver.Steps[2].Result.Solved)
+
+	final := contest.BuildScoreboard(ct, users, judgements, false)
+	require.Equal(t, int64(2), final.Rows[0].UserID)
+}
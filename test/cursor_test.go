@@ -0,0 +1,21 @@
+package test
+
+import (
+	"github.com/stretchr/testify/require"
+	"online_judge/pkg/utils"
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	now := time.Now()
+	cursor := utils.EncodeCursor(now, "b3f1c1a4-0000-0000-0000-000000000001")
+
+	ts, id, err := utils.DecodeCursor(cursor)
+	require.NoError(t, err)
+	require.True(t, ts.Equal(now))
+	require.Equal(t, "b3f1c1a4-0000-0000-0000-000000000001", id)
+
+	_, _, err = utils.DecodeCursor("not-a-cursor")
+	require.ErrorIs(t, err, utils.ErrInvalidCursor)
+}
@@ -0,0 +1,61 @@
+package test
+
+import (
+	"github.com/stretchr/testify/require"
+	"online_judge/consts/resp_code"
+	"online_judge/models/evaluation/response"
+	"testing"
+)
+
+func frozenDetail() *response.EvaluationDetail {
+	return &response.EvaluationDetail{
+		UserID:      1,
+		Verdict:     resp_code.VerdictAccepted,
+		Runtime:     15,
+		MemoryUsage: 2048,
+		Output:      "ok",
+		Code:        "int main(){}",
+		Groups:      []response.Group{{Name: "main", Verdict: resp_code.VerdictAccepted}},
+		Frozen:      true,
+	}
+}
+
+func TestHidePrivateFrozen(t *testing.T) {
+	// 其他用户看到封榜期间的提交为等待评测
+	d := frozenDetail()
+	d.HidePrivate(2, false)
+	require.Equal(t, resp_code.VerdictPending, d.Verdict)
+	require.Zero(t, d.Runtime)
+	require.Zero(t, d.MemoryUsage)
+	require.Empty(t, d.Code)
+	require.Empty(t, d.Output)
+	require.Nil(t, d.Groups)
+
+	// 未登录用户同样看不到
+	d = frozenDetail()
+	d.HidePrivate(0, false)
+	require.Equal(t, resp_code.VerdictPending, d.Verdict)
+
+	// 提交者和管理员看到真实结果
+	for _, viewer := range []struct {
+		id    int64
+		admin bool
+	}{{1, false}, {2, true}} {
+		d = frozenDetail()
+		d.HidePrivate(viewer.id, viewer.admin)
+		require.Equal(t, resp_code.VerdictAccepted, d.Verdict)
+		require.Equal(t, 15, d.Runtime)
+		require.NotEmpty(t, d.Code)
+		require.Len(t, d.Groups, 1)
+	}
+}
+
+func TestHidePrivateNotFrozen(t *testing.T) {
+	d := frozenDetail()
+	d.Frozen = false
+	d.HidePrivate(2, false)
+	require.Equal(t, resp_code.VerdictAccepted, d.Verdict)
+	require.Equal(t, 15, d.Runtime)
+	require.Empty(t, d.Code)
+	require.Empty(t, d.Output)
+}
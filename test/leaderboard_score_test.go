@@ -0,0 +1,21 @@
+package test
+
+import (
+	"github.com/stretchr/testify/require"
+	"online_judge/dao/redis"
+	"testing"
+	"time"
+)
+
+func TestLeaderboardScore(t *testing.T) {
+	early := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	late := early.Add(time.Hour)
+
+	// 通过数量优先，数量相同时最后通过时间较早者在前
+	require.Greater(t, redis.LeaderboardScore(3, &late), redis.LeaderboardScore(2, &early))
+	require.Greater(t, redis.LeaderboardScore(3, &early), redis.LeaderboardScore(3, &late))
+
+	finishNum, lastAcceptedAt := redis.ParseLeaderboardScore(redis.LeaderboardScore(120, &late))
+	require.Equal(t, int64(120), finishNum)
+	require.True(t, lastAcceptedAt.Equal(late))
+}
@@ -0,0 +1,118 @@
+package test
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/require"
+	"online_judge/consts"
+	"online_judge/pkg/problempkg"
+	"online_judge/pkg/testcase"
+	"testing"
+)
+
+func TestProblemPackageRoundTrip(t *testing.T) {
+	pkg := &problempkg.Package{
+		Meta: problempkg.Meta{
+			Title:       "A+B",
+			Difficulty:  "easy",
+			TimeLimit:   1000,
+			MemoryLimit: 256,
+			Categories:  []string{"math"},
+			Checker:     &problempkg.Checker{Mode: consts.CheckerCustom, Language: "cpp"},
+		},
+		Statement:   "# A+B",
+		CheckerCode: "int main() {}",
+		TestCases: []testcase.Case{
+			{Input: "1 2", Expected: "3", IsSample: true},
+			{Input: "2 3", Expected: "5"},
+		},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, problempkg.Write(&buf, pkg))
+
+	got, err := problempkg.Read(bytes.NewReader(buf.Bytes()), int64(buf.Len()), testcase.Limits{})
+	require.NoError(t, err)
+	require.Equal(t, problempkg.Version, got.Meta.Version)
+	require.Equal(t, pkg.Meta.Title, got.Meta.Title)
+	require.Equal(t, pkg.Meta.Categories, got.Meta.Categories)
+	require.Equal(t, pkg.Statement, got.Statement)
+	require.Equal(t, pkg.CheckerCode, got.CheckerCode)
+	require.Len(t, got.TestCases, 2)
+	require.True(t, got.TestCases[0].IsSample)
+	require.Equal(t, "5", got.TestCases[1].Expected)
+}
+
+func TestProblemPackageMissingMeta(t *testing.T) {
+	r := buildZip(t, map[string]string{"statement.md": "x", "tests/1.in": "1", "tests/1.out": "1"})
+	_, err := problempkg.Read(r, r.Size(), testcase.Limits{})
+	require.ErrorIs(t, err, problempkg.ErrMissingFile)
+}
+
+func TestProblemPackageHydro(t *testing.T) {
+	r := buildZip(t, map[string]string{
+		"P1000/problem.yaml":  "pid: P1000\ntitle: A+B Problem\ntag:\n  - math\ndifficulty: 2\n",
+		"P1000/problem_zh.md": "# A+B",
+		"P1000/testdata/config.yaml": "time: 2s\nmemory: 128m\nchecker_type: testlib\nchecker: chk.cc\n" +
+			"subtasks:\n  - score: 100\n    cases:\n      - input: a.in\n        output: a.ans\n      - input: b.in\n        output: b.ans\n",
+		"P1000/testdata/chk.cc": "int main() {}",
+		"P1000/testdata/a.in":   "1 2",
+		"P1000/testdata/a.ans":  "3",
+		"P1000/testdata/b.in":   "2 3",
+		"P1000/testdata/b.ans":  "5",
+	})
+	pkg, err := problempkg.Read(r, r.Size(), testcase.Limits{})
+	require.NoError(t, err)
+	require.Equal(t, "A+B Problem", pkg.Meta.Title)
+	require.Equal(t, "easy", pkg.Meta.Difficulty)
+	require.Equal(t, []string{"math"}, pkg.Meta.Categories)
+	require.Equal(t, 2000, pkg.Meta.TimeLimit)
+	require.Equal(t, 128*1024, pkg.Meta.MemoryLimit)
+	require.Equal(t, "# A+B", pkg.Statement)
+	require.Equal(t, consts.CheckerCustom, pkg.Meta.Checker.Mode)
+	require.Equal(t, "C++", pkg.Meta.Checker.Language)
+	require.Equal(t, "int main() {}", pkg.CheckerCode)
+	require.Len(t, pkg.TestCases, 2)
+	require.Equal(t, "2 3", pkg.TestCases[1].Input)
+	require.Equal(t, "5", pkg.TestCases[1].Expected)
+}
+
+func TestProblemPackageHydroDefaults(t *testing.T) {
+	// 没有 config.yaml 时按文件名配对，使用默认限制和忽略空白的比较
+	r := buildZip(t, map[string]string{
+		"problem.yaml":    "title: Echo\n",
+		"problem.md":      "echo",
+		"testdata/1.in":   "x",
+		"testdata/1.out":  "x",
+		"testdata/10.in":  "y",
+		"testdata/10.out": "y",
+		"testdata/2.in":   "z",
+		"testdata/2.out":  "z",
+	})
+	pkg, err := problempkg.Read(r, r.Size(), testcase.Limits{})
+	require.NoError(t, err)
+	require.Equal(t, 1000, pkg.Meta.TimeLimit)
+	require.Equal(t, 256*1024, pkg.Meta.MemoryLimit)
+	require.Equal(t, consts.CheckerToken, pkg.Meta.Checker.Mode)
+	require.Len(t, pkg.TestCases, 3)
+	require.Equal(t, "z", pkg.TestCases[1].Input)
+}
+
+func TestProblemPackageHydroInvalid(t *testing.T) {
+	r := buildZip(t, map[string]string{
+		"problem.yaml":         "title: Bad\n",
+		"problem.md":           "bad",
+		"testdata/config.yaml": "time: fast\n",
+		"testdata/1.in":        "1",
+		"testdata/1.out":       "1",
+	})
+	_, err := problempkg.Read(r, r.Size(), testcase.Limits{})
+	require.ErrorIs(t, err, problempkg.ErrInvalidMeta)
+
+	r = buildZip(t, map[string]string{
+		"problem.yaml":         "title: Missing\n",
+		"problem.md":           "missing",
+		"testdata/config.yaml": "cases:\n  - input: 1.in\n    output: 1.out\n",
+		"testdata/1.in":        "1",
+	})
+	_, err = problempkg.Read(r, r.Size(), testcase.Limits{})
+	require.ErrorIs(t, err, testcase.ErrUnpaired)
+}
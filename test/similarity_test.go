@@ -0,0 +1,79 @@
+package test
+
+import (
+	"github.com/stretchr/testify/require"
+	"online_judge/pkg/similarity"
+	"testing"
+)
+
+const similarityOriginal = `#include <stdio.h>
+
+int main() {
+    int n, sum = 0;
+    scanf("%d", &n);
+    for (int i = 1; i <= n; i++) {
+        if (i % 3 == 0 || i % 5 == 0) {
+            sum += i;
+        }
+    }
+    printf("%d\n", sum);
+    return 0;
+}
+`
+
+// 重命名变量、修改注释和格式
+const similarityRenamed = `#include <stdio.h>
+/* my own solution */
+int main()
+{
+    int count, total = 0; // total of multiples
+    scanf("%d", &count);
+    for (int k = 1; k <= count; k++)
+    {
+        if (k % 3 == 0 || k % 5 == 0) { total += k; }
+    }
+    printf("%d\n", total);
+    return 0;
+}
+`
+
+const similarityUnrelated = `#include <stdio.h>
+
+int main() {
+    long long a, b;
+    while (scanf("%lld %lld", &a, &b) == 2) {
+        while (b) {
+            long long t = a % b;
+            a = b;
+            b = t;
+        }
+        printf("%lld\n", a);
+    }
+    return 0;
+}
+`
+
+func TestSimilarityIgnoresRenaming(t *testing.T) {
+	a := similarity.NewDocument("a", similarity.SyntaxC, similarityOriginal)
+	b := similarity.NewDocument("b", similarity.SyntaxC, similarityRenamed)
+	c := similarity.NewDocument("c", similarity.SyntaxC, similarityUnrelated)
+
+	res := similarity.Compare(a, b, nil)
+	require.Greater(t, res.Score, 0.9)
+	require.NotEmpty(t, res.Fragments)
+	require.Equal(t, similarity.Fragment{AStartLine: 1, AEndLine: 13, BStartLine: 1, BEndLine: 13, Tokens: len(a.Tokens)}, res.Fragments[0])
+	require.Less(t, similarity.Compare(a, c, nil).Score, 0.3)
+
+	pairs := similarity.FindPairs([]*similarity.Document{a, b, c}, similarity.Options{Threshold: 0.6})
+	require.Len(t, pairs, 1)
+	require.Equal(t, 0, pairs[0].A)
+	require.Equal(t, 1, pairs[0].B)
+}
+
+func TestSimilarityPythonComments(t *testing.T) {
+	a := similarity.NewDocument("a", similarity.SyntaxFor("main.py"), "# read\nn = int(input())\nprint(sum(range(n)))\n")
+	b := similarity.NewDocument("b", similarity.SyntaxFor("main.py"), "x = int(input())  # count\n\n\nprint(sum(range(x)))\n")
+	require.Equal(t, a.Fingerprints, b.Fingerprints)
+	require.Equal(t, 2, a.Tokens[0].Line)
+	require.Equal(t, 1, b.Tokens[0].Line)
+}
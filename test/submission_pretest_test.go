@@ -0,0 +1,49 @@
+package test
+
+import (
+	"github.com/stretchr/testify/require"
+	"online_judge/pkg/testcase"
+	"online_judge/services/submission"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestPretestGroups(t *testing.T) {
+	groups := submission.PretestGroups([]bool{false, true, false, true}, true)
+	require.Len(t, groups, 2)
+	require.Equal(t, "pretest", groups[0].Name)
+	require.True(t, groups[0].StopOnFirstFailure)
+	require.Equal(t, []int32{1, 3}, groups[0].Cases)
+	require.Equal(t, "main", groups[1].Name)
+	require.False(t, groups[1].StopOnFirstFailure)
+	require.Equal(t, []int32{0, 2}, groups[1].Cases)
+
+	// 没有预测试时不分组
+	require.Nil(t, submission.PretestGroups([]bool{false, false}, true))
+	require.Nil(t, submission.PretestGroups(nil, false))
+}
+
+func TestPretestGroupsWithFile(t *testing.T) {
+	// 文件题目的预测试标记保存在清单中，读取后按文件的顺序分组
+	var cases []testcase.Case
+	for i := 1; i <= 11; i++ {
+		cases = append(cases, testcase.Case{Input: strconv.Itoa(i), Expected: strconv.Itoa(i), IsPretest: i == 2 || i == 10})
+	}
+	dir := t.TempDir()
+	inputDir, expectedDir := filepath.Join(dir, "input"), filepath.Join(dir, "expected")
+	require.NoError(t, testcase.WriteFiles(inputDir, expectedDir, cases))
+
+	got, err := testcase.ReadFiles(inputDir, expectedDir)
+	require.NoError(t, err)
+	isPretest := make([]bool, len(got))
+	for i, c := range got {
+		isPretest[i] = c.IsPretest
+	}
+	groups := submission.PretestGroups(isPretest, false)
+	require.Len(t, groups, 2)
+	require.Equal(t, []int32{1, 9}, groups[0].Cases)
+	require.False(t, groups[0].StopOnFirstFailure)
+	require.Equal(t, []int32{0, 2, 3, 4, 5, 6, 7, 8, 10}, groups[1].Cases)
+	require.Equal(t, "10", got[9].Input)
+}
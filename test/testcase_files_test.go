@@ -0,0 +1,104 @@
+package test
+
+import (
+	"github.com/stretchr/testify/require"
+	"online_judge/pkg/testcase"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestTestCaseFilesRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	inputDir, expectedDir := filepath.Join(dir, "input"), filepath.Join(dir, "expected")
+
+	require.NoError(t, testcase.WriteFiles(inputDir, expectedDir, []testcase.Case{
+		{Input: "1", Expected: "1"},
+		{Input: "2", Expected: "2"},
+		{Input: "3", Expected: "3"},
+	}))
+	cases := []testcase.Case{
+		{Input: "1 2", Expected: "3", IsSample: true},
+		{Input: "2 3", Expected: "5", IsPretest: true},
+	}
+	require.NoError(t, testcase.WriteFiles(inputDir, expectedDir, cases))
+
+	got, err := testcase.ReadFiles(inputDir, expectedDir)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	for i := range cases {
+		require.Equal(t, cases[i].Input, got[i].Input)
+		require.Equal(t, cases[i].Expected, got[i].Expected)
+		require.Equal(t, cases[i].IsSample, got[i].IsSample)
+		require.Equal(t, cases[i].IsPretest, got[i].IsPretest)
+	}
+
+	// 替换之后不留下临时目录
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+}
+
+func TestTestCaseFilesWithoutManifest(t *testing.T) {
+	// 上传的文件没有清单，都不是样例
+	inputDir, expectedDir := t.TempDir(), t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(inputDir, "input1.in"), []byte("1"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(expectedDir, "expected1.out"), []byte("2"), 0644))
+
+	got, err := testcase.ReadFiles(inputDir, expectedDir)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, "2", got[0].Expected)
+	require.False(t, got[0].IsSample)
+
+	require.NoError(t, os.Remove(filepath.Join(expectedDir, "expected1.out")))
+	_, err = testcase.ReadFiles(inputDir, expectedDir)
+	require.Error(t, err)
+}
+
+func TestTestCaseFilesOrder(t *testing.T) {
+	// 超过 10 组时按编号的数值排序，导入再导出不改变顺序
+	var cases []testcase.Case
+	for i := 1; i <= 12; i++ {
+		cases = append(cases, testcase.Case{Input: strconv.Itoa(i), Expected: strconv.Itoa(i * 2), IsPretest: i <= 3})
+	}
+	dir := t.TempDir()
+	inputDir, expectedDir := filepath.Join(dir, "input"), filepath.Join(dir, "expected")
+	require.NoError(t, testcase.WriteFiles(inputDir, expectedDir, cases))
+
+	got, err := testcase.ReadFiles(inputDir, expectedDir)
+	require.NoError(t, err)
+	require.Len(t, got, len(cases))
+	for i := range cases {
+		require.Equal(t, strconv.Itoa(i+1), got[i].Name)
+		require.Equal(t, cases[i].Input, got[i].Input)
+		require.Equal(t, cases[i].Expected, got[i].Expected)
+		require.Equal(t, cases[i].IsPretest, got[i].IsPretest)
+	}
+
+	// 再次写入后读取的结果相同
+	require.NoError(t, testcase.WriteFiles(inputDir, expectedDir, got))
+	again, err := testcase.ReadFiles(inputDir, expectedDir)
+	require.NoError(t, err)
+	require.Equal(t, got, again)
+
+	// 没有清单时同样按数值排序
+	require.NoError(t, os.Remove(filepath.Join(inputDir, testcase.ManifestName)))
+	got, err = testcase.ReadFiles(inputDir, expectedDir)
+	require.NoError(t, err)
+	require.Len(t, got, len(cases))
+	for i := range cases {
+		require.Equal(t, cases[i].Input, got[i].Input)
+		require.False(t, got[i].IsPretest)
+	}
+}
+
+func TestTestCaseFilesManifestPath(t *testing.T) {
+	// 清单中的文件名不能指向其他目录
+	inputDir, expectedDir := t.TempDir(), t.TempDir()
+	manifest := `{"test_cases":[{"name":"1","input":"../secret","expected":"expected1.out"}]}`
+	require.NoError(t, os.WriteFile(filepath.Join(inputDir, testcase.ManifestName), []byte(manifest), 0644))
+	_, err := testcase.ReadFiles(inputDir, expectedDir)
+	require.ErrorIs(t, err, testcase.ErrInvalidManifest)
+}
@@ -0,0 +1,68 @@
+package test
+
+import (
+	"archive/zip"
+	"bytes"
+	"github.com/stretchr/testify/require"
+	"online_judge/pkg/testcase"
+	"testing"
+)
+
+func buildZip(t *testing.T, files map[string]string) *bytes.Reader {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestReadTestCaseZip(t *testing.T) {
+	r := buildZip(t, map[string]string{
+		"input1.in":     "1 2",
+		"expected1.out": "3",
+		"10.in":         "10 20",
+		"10.out":        "30",
+		"2.in":          "2 3",
+		"2.out":         "5",
+		"README.md":     "readme",
+	})
+	cases, report, err := testcase.ReadZip(r, r.Size(), testcase.Limits{})
+	require.NoError(t, err)
+	require.Len(t, cases, 3)
+	// 名称都是数字时按数值排序
+	require.Equal(t, []string{"1", "2", "10"}, []string{cases[0].Name, cases[1].Name, cases[2].Name})
+	require.Equal(t, "30", cases[2].Expected)
+	require.Equal(t, []string{"README.md"}, report.Ignored)
+
+	r = buildZip(t, map[string]string{"1.in": "1", "1.out": "1", "2.in": "2"})
+	_, report, err = testcase.ReadZip(r, r.Size(), testcase.Limits{})
+	require.ErrorIs(t, err, testcase.ErrUnpaired)
+	require.Equal(t, []string{"2.in"}, report.Unpaired)
+
+	r = buildZip(t, map[string]string{"1.in": "12345", "1.out": "1"})
+	_, _, err = testcase.ReadZip(r, r.Size(), testcase.Limits{MaxFileSize: 4})
+	require.ErrorIs(t, err, testcase.ErrFileTooLarge)
+}
+
+func TestTestCaseZipRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, testcase.WriteZip(&buf, []testcase.Case{
+		{Input: "1 2", Expected: "3", IsSample: true},
+		{Input: "4 5", Expected: "9", IsPretest: true},
+	}))
+
+	r := bytes.NewReader(buf.Bytes())
+	cases, _, err := testcase.ReadZip(r, r.Size(), testcase.Limits{})
+	require.NoError(t, err)
+	require.Len(t, cases, 2)
+	require.True(t, cases[0].IsSample)
+	require.False(t, cases[1].IsSample)
+	require.False(t, cases[0].IsPretest)
+	require.True(t, cases[1].IsPretest)
+	require.Equal(t, "9", cases[1].Expected)
+}
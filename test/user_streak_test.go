@@ -0,0 +1,26 @@
+package test
+
+import (
+	"github.com/stretchr/testify/require"
+	"online_judge/services/user"
+	"testing"
+	"time"
+)
+
+func TestUserStreaks(t *testing.T) {
+	now := time.Date(2024, 3, 10, 15, 0, 0, 0, time.UTC)
+	dates := []string{"2024-02-01", "2024-02-02", "2024-02-03", "2024-02-04", "2024-03-08", "2024-03-09"}
+
+	// 今天还没有提交，截止到昨天的连续天数仍然有效
+	current, longest := user.Streaks(dates, now)
+	require.Equal(t, 2, current)
+	require.Equal(t, 4, longest)
+
+	current, longest = user.Streaks(dates, now.AddDate(0, 0, 2))
+	require.Equal(t, 0, current)
+	require.Equal(t, 4, longest)
+
+	current, longest = user.Streaks(nil, now)
+	require.Equal(t, 0, current)
+	require.Equal(t, 0, longest)
+}